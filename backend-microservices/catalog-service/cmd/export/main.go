@@ -0,0 +1,145 @@
+package main
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/data_layer/repository"
+	"catalog-service/domain_layer/service"
+	"catalog-service/helpers/config"
+	"catalog-service/helpers/duration"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Load environment variables from .env file
+	if err := godotenv.Load(); err != nil {
+		log.Printf("Warning: Could not load .env file: %v", err)
+	}
+
+	// Command line flags
+	var (
+		format      = flag.String("format", service.ExportFormatJSON, "Output format: json, ndjson or csv")
+		rows        = flag.String("rows", service.ExportRowsAudiobook, "CSV rows: audiobook or track")
+		out         = flag.String("out", "", "Output file (default: standard output)")
+		authorID    = flag.Uint("author-id", 0, "Only export audiobooks by this author")
+		readerID    = flag.Uint("reader-id", 0, "Only export audiobooks read by this reader")
+		genreIDs    = flag.String("genre-ids", "", "Comma-separated genre IDs to filter by")
+		genreMatch  = flag.String("genre-match", "any", "Match any or all of the genres")
+		language    = flag.String("language", "", "Only export audiobooks in this language")
+		yearFrom    = flag.Int("year-from", 0, "Earliest year of publishing")
+		yearTo      = flag.Int("year-to", 0, "Latest year of publishing")
+		hasTracks   = flag.String("has-tracks", "", "Only export audiobooks with (true) or without (false) tracks")
+		minDuration = flag.String("min-duration", "", "Minimum total duration, e.g. 3h or 01:30:00")
+		maxDuration = flag.String("max-duration", "", "Maximum total duration, e.g. 10h")
+		help        = flag.Bool("help", false, "Show help information")
+	)
+
+	flag.Parse()
+
+	if *help {
+		showHelp()
+		return
+	}
+
+	req, err := service.NormalizeExportRequest(dto.ExportRequest{Format: *format, Rows: *rows})
+	if err != nil {
+		log.Fatalf("Invalid export options: %v", err)
+	}
+
+	filter := dto.AudiobookFilter{
+		AuthorID:   *authorID,
+		ReaderID:   *readerID,
+		GenreMatch: *genreMatch,
+		Language:   strings.TrimSpace(*language),
+		YearFrom:   *yearFrom,
+		YearTo:     *yearTo,
+	}
+	if filter.GenreMatch != "any" && filter.GenreMatch != "all" {
+		log.Fatalf("Invalid -genre-match %q. Use any or all", filter.GenreMatch)
+	}
+	if *genreIDs != "" {
+		for _, value := range strings.Split(*genreIDs, ",") {
+			genreID, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+			if err != nil || genreID == 0 {
+				log.Fatalf("Invalid -genre-ids %q", *genreIDs)
+			}
+			filter.GenreIDs = append(filter.GenreIDs, uint(genreID))
+		}
+	}
+	if *hasTracks != "" {
+		value, err := strconv.ParseBool(*hasTracks)
+		if err != nil {
+			log.Fatalf("Invalid -has-tracks %q", *hasTracks)
+		}
+		filter.HasTracks = &value
+	}
+	if filter.Duration.MinSeconds, err = duration.Parse(*minDuration); err != nil {
+		log.Fatalf("Invalid -min-duration %q", *minDuration)
+	}
+	if filter.Duration.MaxSeconds, err = duration.Parse(*maxDuration); err != nil {
+		log.Fatalf("Invalid -max-duration %q", *maxDuration)
+	}
+
+	// Load database configuration
+	db, err := config.InitDatabase()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("Failed to create %s: %v", *out, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	exportService := service.NewExportService(repository.NewAudiobookRepository(db))
+	if err := exportService.Export(w, req, filter); err != nil {
+		log.Fatalf("Failed to export catalog: %v", err)
+	}
+
+	if *out != "" {
+		fmt.Fprintf(os.Stderr, "✅ Exported catalog to %s\n", *out)
+	}
+}
+
+func showHelp() {
+	fmt.Println("Catalog Export Tool")
+	fmt.Println("===================")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  go run cmd/export/main.go [options]")
+	fmt.Println()
+	fmt.Println("Streams the catalog as nested JSON, NDJSON (one audiobook per line) or CSV")
+	fmt.Println("(one row per audiobook or per track). The filters match the audiobook list.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -format string       Output format: json, ndjson or csv (default json)")
+	fmt.Println("  -rows string         CSV rows: audiobook or track (default audiobook)")
+	fmt.Println("  -out string          Output file (default: standard output)")
+	fmt.Println("  -author-id uint      Only export audiobooks by this author")
+	fmt.Println("  -reader-id uint      Only export audiobooks read by this reader")
+	fmt.Println("  -genre-ids string    Comma-separated genre IDs to filter by")
+	fmt.Println("  -genre-match string  Match any or all of the genres (default any)")
+	fmt.Println("  -language string     Only export audiobooks in this language")
+	fmt.Println("  -year-from int       Earliest year of publishing")
+	fmt.Println("  -year-to int         Latest year of publishing")
+	fmt.Println("  -has-tracks bool     Only export audiobooks with or without tracks")
+	fmt.Println("  -min-duration string Minimum total duration, e.g. 3h or 01:30:00")
+	fmt.Println("  -max-duration string Maximum total duration")
+	fmt.Println("  -help                Show this help information")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  go run cmd/export/main.go -format csv -rows track -out tracks.csv")
+	fmt.Println("  go run cmd/export/main.go -format ndjson -language English > catalog.ndjson")
+}
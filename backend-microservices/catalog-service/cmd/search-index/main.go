@@ -0,0 +1,71 @@
+package main
+
+import (
+	"catalog-service/data_layer/migration"
+	"catalog-service/data_layer/repository"
+	"catalog-service/helpers/config"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Load environment variables from .env file
+	if err := godotenv.Load(); err != nil {
+		log.Printf("Warning: Could not load .env file: %v", err)
+	}
+
+	// Command line flags
+	var (
+		rebuild = flag.Bool("rebuild", false, "Rebuild the search documents of all audiobooks")
+		help    = flag.Bool("help", false, "Show help information")
+	)
+
+	flag.Parse()
+
+	if *help {
+		showHelp()
+		return
+	}
+
+	// Load database configuration
+	db, err := config.InitDatabase()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	// Make sure the search column and index exist before filling them
+	if err := migration.EnsureSearchIndex(db); err != nil {
+		log.Fatalf("Failed to prepare search index: %v", err)
+	}
+
+	searchIndexRepo := repository.NewSearchIndexRepository(db)
+
+	if *rebuild {
+		fmt.Println("Rebuilding search index...")
+		indexed, err := searchIndexRepo.RebuildAll()
+		if err != nil {
+			log.Fatalf("Failed to rebuild search index: %v", err)
+		}
+		fmt.Printf("✅ Rebuilt search documents for %d audiobooks\n", indexed)
+		return
+	}
+
+	fmt.Println("✅ Search index is up to date")
+}
+
+func showHelp() {
+	fmt.Println("Search Index Tool")
+	fmt.Println("=================")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  go run cmd/search-index/main.go [options]")
+	fmt.Println()
+	fmt.Println("Without options, builds search documents only for audiobooks that have none.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -rebuild           Rebuild the search documents of all audiobooks")
+	fmt.Println("  -help              Show this help information")
+}
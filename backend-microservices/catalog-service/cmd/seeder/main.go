@@ -1,11 +1,12 @@
 package main
 
 import (
-	"content-management-service/data_layer/migration"
-	"content-management-service/helpers/config"
+	"catalog-service/data_layer/migration"
+	"catalog-service/helpers/config"
 	"flag"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/joho/godotenv"
 )
@@ -23,6 +24,8 @@ func main() {
 		seedSpecific = flag.String("seed-specific", "", "Run specific seeder (authors, genres, readers, users, audiobooks, tracks, analytics)")
 		clearData    = flag.Bool("clear", false, "Clear all seeded data")
 		showStats    = flag.Bool("stats", false, "Show seeding statistics")
+		importDir    = flag.String("import-librivox", "", "Import the LibriVox export in this directory (e.g. ../../data)")
+		dryRun       = flag.Bool("dry-run", false, "With -import-librivox, only report what would change")
 		help         = flag.Bool("help", false, "Show help information")
 	)
 
@@ -55,6 +58,21 @@ func main() {
 			fmt.Printf("%-20s: %d records\n", table, count)
 		}
 
+	case *importDir != "":
+		if *dryRun {
+			fmt.Printf("Comparing LibriVox export in %s with the database...\n", *importDir)
+		} else {
+			fmt.Printf("Importing LibriVox export from %s...\n", *importDir)
+		}
+		report, err := migration.ImportLibriVox(db, *importDir, *dryRun)
+		if err != nil {
+			log.Fatalf("Import failed: %v", err)
+		}
+		report.Print(os.Stdout)
+		if !*dryRun {
+			fmt.Println("✅ Import completed successfully!")
+		}
+
 	case *migrateOnly:
 		fmt.Println("Running database migrations...")
 		if err := migration.AutoMigrate(db); err != nil {
@@ -107,6 +125,9 @@ func showHelp() {
 	fmt.Println("  -seed-specific     Run specific seeder:")
 	fmt.Println("                     authors, genres, readers, users,")
 	fmt.Println("                     audiobooks, tracks, analytics")
+	fmt.Println("  -import-librivox   Import authors, readers, genres, audiobooks and")
+	fmt.Println("                     tracks from a LibriVox export directory")
+	fmt.Println("  -dry-run           With -import-librivox, print the changes without writing")
 	fmt.Println("  -clear             Clear all seeded data")
 	fmt.Println("  -stats             Show seeding statistics")
 	fmt.Println("  -help              Show this help message")
@@ -116,6 +137,8 @@ func showHelp() {
 	fmt.Println("  go run cmd/seeder/main.go -migrate           # Run only migrations")
 	fmt.Println("  go run cmd/seeder/main.go -seed              # Run only seeding")
 	fmt.Println("  go run cmd/seeder/main.go -seed-specific authors  # Seed only authors")
+	fmt.Println("  go run cmd/seeder/main.go -import-librivox ../../data -dry-run  # Preview an import")
+	fmt.Println("  go run cmd/seeder/main.go -import-librivox ../../data           # Import LibriVox data")
 	fmt.Println("  go run cmd/seeder/main.go -clear             # Clear all data")
 	fmt.Println("  go run cmd/seeder/main.go -stats             # Show statistics")
 }
@@ -5,7 +5,7 @@ import "time"
 // CreateAnalyticsRequest represents the request to create analytics event
 type CreateAnalyticsRequest struct {
 	AudiobookID uint   `json:"audiobook_id" binding:"required"`
-	EventType   string `json:"event_type" binding:"required,oneof=VIEW PLAY_START PLAY_FINISH DOWNLOAD"`
+	EventType   string `json:"event_type" binding:"required,oneof=VIEW PLAY_START PLAY_FINISH PLAY_ABANDON DOWNLOAD"`
 }
 
 // AnalyticsResponse represents the response for analytics data
@@ -26,37 +26,153 @@ type UpdateAudiobookRequest struct {
 	GenreIDs         []uint `json:"genre_ids"`
 }
 
+// PatchAudiobookRequest represents the request to update some fields of an audiobook; fields left
+// out are kept. GenreIDs replaces the genres, an empty list removing them all.
+type PatchAudiobookRequest struct {
+	Title            *string `json:"title" binding:"omitempty,min=1,max=255"`
+	AuthorID         *uint   `json:"author_id" binding:"omitempty,min=1"`
+	ReaderID         *uint   `json:"reader_id" binding:"omitempty,min=1"`
+	Description      *string `json:"description"`
+	ImageURL         *string `json:"image_url"`
+	Language         *string `json:"language"`
+	YearOfPublishing *int    `json:"year_of_publishing"`
+	TotalDuration    *string `json:"total_duration"`
+	GenreIDs         *[]uint `json:"genre_ids"`
+}
+
 // AudiobookResponse represents the response for audiobook data
 type AudiobookResponse struct {
-	ID               uint            `json:"id"`
-	Title            string          `json:"title"`
-	Author           AuthorResponse  `json:"author"`
-	Reader           ReaderResponse  `json:"reader"`
-	Description      string          `json:"description"`
-	ImageURL         string          `json:"image_url"`
-	Language         string          `json:"language"`
-	YearOfPublishing int             `json:"year_of_publishing"`
-	TotalDuration    string          `json:"total_duration"`
-	Genres           []GenreResponse `json:"genres"`
-	Tracks           []TrackResponse `json:"tracks,omitempty"`
+	ID                   uint                     `json:"id"`
+	Title                string                   `json:"title"`
+	Author               AuthorResponse           `json:"author"`
+	Reader               ReaderResponse           `json:"reader"`
+	Description          string                   `json:"description"`
+	ImageURL             string                   `json:"image_url"`
+	Language             string                   `json:"language"`
+	YearOfPublishing     int                      `json:"year_of_publishing"`
+	TotalDuration        string                   `json:"total_duration"`
+	TotalDurationSeconds int                      `json:"total_duration_seconds"`
+	Genres               []GenreResponse          `json:"genres"`
+	Authors              []CreditResponse         `json:"authors"`
+	Readers              []CreditResponse         `json:"readers"`
+	Series               *AudiobookSeriesResponse `json:"series,omitempty"`
+	Tracks               []TrackResponse          `json:"tracks,omitempty"`
+	Version              uint                     `json:"version"`
+}
+
+// CreditResponse represents an author or reader credited on an audiobook, in credit order
+type CreditResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// AudiobookSeriesResponse represents the series an audiobook is a volume of
+type AudiobookSeriesResponse struct {
+	ID     uint   `json:"id"`
+	Name   string `json:"name"`
+	Volume int    `json:"volume"`
+	Label  string `json:"label,omitempty"`
+}
+
+// AuthorCreditRequest represents an author credit; Role defaults to author
+type AuthorCreditRequest struct {
+	AuthorID uint   `json:"author_id" binding:"required"`
+	Role     string `json:"role" binding:"omitempty,oneof=author translator editor"`
+}
+
+// ReaderCreditRequest represents a reader credit; Role defaults to narrator
+type ReaderCreditRequest struct {
+	ReaderID uint   `json:"reader_id" binding:"required"`
+	Role     string `json:"role" binding:"omitempty,oneof=narrator"`
+}
+
+// SetCreditsRequest represents the complete, ordered credits of an audiobook. The first author
+// credit becomes the primary author and the first narrator the primary reader.
+type SetCreditsRequest struct {
+	Authors []AuthorCreditRequest `json:"authors" binding:"required,min=1,dive"`
+	Readers []ReaderCreditRequest `json:"readers" binding:"required,min=1,dive"`
 }
 
 // AudiobookListResponse represents the response for audiobook list
 type AudiobookListResponse struct {
-	ID                uint              `json:"id"`
-	Title             string            `json:"title"`
-	Author            *AuthorResponse   `json:"author,omitempty"`
-	Reader            *ReaderResponse   `json:"reader,omitempty"`
-	ImageURL          string            `json:"image_url"`
-	Language          string            `json:"language"`
-	YearOfPublishing  int               `json:"year_of_publishing"`
-	TotalDuration     string            `json:"total_duration"`
-	Genres            []GenreResponse   `json:"genres"`
-}
-
-// AudiobookFilter represents filtering options for audiobooks
+	ID                   uint            `json:"id"`
+	Title                string          `json:"title"`
+	Author               *AuthorResponse `json:"author,omitempty"`
+	Reader               *ReaderResponse `json:"reader,omitempty"`
+	ImageURL             string          `json:"image_url"`
+	Language             string          `json:"language"`
+	YearOfPublishing     int             `json:"year_of_publishing"`
+	TotalDuration        string          `json:"total_duration"`
+	TotalDurationSeconds int             `json:"total_duration_seconds"`
+	Genres               []GenreResponse `json:"genres"`
+}
+
+// AudiobookFilter represents filtering options for audiobooks; every option set must match.
+// GenreMatch is "any" (default) or "all" of GenreIDs, and HasTracks is nil when not filtered.
 type AudiobookFilter struct {
-	AuthorID uint `form:"author_id"`
-	ReaderID uint `form:"reader_id"`
-	GenreID  uint `form:"genre_id"`
+	AuthorID   uint           `form:"author_id"`
+	ReaderID   uint           `form:"reader_id"`
+	GenreIDs   []uint         `form:"-"`
+	GenreMatch string         `form:"genre_match"`
+	Language   string         `form:"language"`
+	YearFrom   int            `form:"year_from"`
+	YearTo     int            `form:"year_to"`
+	HasTracks  *bool          `form:"has_tracks"`
+	Duration   DurationFilter `form:"-"`
+}
+
+// AudiobookFacetedListResponse represents a paginated audiobook list with facet counts over
+// every audiobook matching the filter, not only the current page
+type AudiobookFacetedListResponse struct {
+	ListResponse
+	Facets AudiobookFacets `json:"facets"`
+}
+
+// AudiobookFacets represents the number of matching audiobooks per genre, language and decade
+type AudiobookFacets struct {
+	Genres    []GenreFacet    `json:"genres"`
+	Languages []LanguageFacet `json:"languages"`
+	Decades   []DecadeFacet   `json:"decades"`
+}
+
+// GenreFacet represents the number of matching audiobooks in a genre
+type GenreFacet struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// LanguageFacet represents the number of matching audiobooks in a language
+type LanguageFacet struct {
+	Language string `json:"language"`
+	Count    int64  `json:"count"`
+}
+
+// DecadeFacet represents the number of matching audiobooks published in a decade, e.g. 1890 for 1890-1899
+type DecadeFacet struct {
+	Decade int   `json:"decade"`
+	Count  int64 `json:"count"`
+}
+
+// AudiobookSearchRequest represents full-text search parameters for audiobooks.
+// Language selects how the query is stemmed, e.g. "English" or "French".
+type AudiobookSearchRequest struct {
+	Query    string `form:"q"`
+	Language string `form:"language"`
+	PaginationRequest
+}
+
+// AudiobookSearchResult represents an audiobook matched by a search, most relevant first.
+// Highlights are HTML-escaped text with the matched words wrapped in <mark> tags, safe to render as HTML.
+type AudiobookSearchResult struct {
+	AudiobookListResponse
+	Rank       float64             `json:"rank"`
+	Highlights AudiobookHighlights `json:"highlights"`
+}
+
+// AudiobookHighlights represents the matched snippets of an audiobook
+type AudiobookHighlights struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
 }
@@ -10,8 +10,10 @@ type UpdateAuthorRequest struct {
 	Name string `json:"name" binding:"required,min=1,max=255"`
 }
 
-// AuthorResponse represents the response for author data
+// AuthorResponse represents the response for author data.
+// Version is only set where the author is returned on its own, not nested in an audiobook.
 type AuthorResponse struct {
-	ID   uint   `json:"id"`
-	Name string `json:"name"`
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Version uint   `json:"version,omitempty"`
 }
@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+// CreateBookmarkRequest represents the request to create a new bookmark
+type CreateBookmarkRequest struct {
+	TrackID       uint   `json:"track_id" binding:"required"`
+	OffsetSeconds int    `json:"offset_seconds" binding:"min=0"`
+	Label         string `json:"label" binding:"max=255"`
+	Note          string `json:"note"`
+}
+
+// UpdateBookmarkRequest represents the request to update a bookmark
+type UpdateBookmarkRequest struct {
+	OffsetSeconds int    `json:"offset_seconds" binding:"min=0"`
+	Label         string `json:"label" binding:"max=255"`
+	Note          string `json:"note"`
+}
+
+// BookmarkResponse represents the response for bookmark data
+type BookmarkResponse struct {
+	ID            uint      `json:"id"`
+	TrackID       uint      `json:"track_id"`
+	TrackTitle    string    `json:"track_title"`
+	AudiobookID   uint      `json:"audiobook_id"`
+	OffsetSeconds int       `json:"offset_seconds"`
+	Label         string    `json:"label"`
+	Note          string    `json:"note"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// BookmarkExportResponse represents every note of a user for one audiobook
+type BookmarkExportResponse struct {
+	AudiobookID    uint                  `json:"audiobook_id"`
+	AudiobookTitle string                `json:"audiobook_title"`
+	Author         string                `json:"author"`
+	ExportedAt     time.Time             `json:"exported_at"`
+	Tracks         []BookmarkExportTrack `json:"tracks"`
+}
+
+// BookmarkExportTrack groups the exported bookmarks of one track
+type BookmarkExportTrack struct {
+	TrackID    uint               `json:"track_id"`
+	TrackTitle string             `json:"track_title"`
+	Bookmarks  []BookmarkResponse `json:"bookmarks"`
+}
@@ -10,12 +10,14 @@ type APIResponse struct {
 	Error   string      `json:"error,omitempty"`
 }
 
-// PaginationResponse represents pagination metadata
+// PaginationResponse represents pagination metadata. Page is left out for pages fetched by cursor,
+// and NextCursor is empty on the last page.
 type PaginationResponse struct {
-	Page       int   `json:"page"`
-	Limit      int   `json:"limit"`
-	Total      int64 `json:"total"`
-	TotalPages int   `json:"total_pages"`
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	Total      int64  `json:"total"`
+	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // ListResponse represents a paginated list response
@@ -30,8 +32,23 @@ type PaginationRequest struct {
 	Limit int `form:"limit,default=20" binding:"min=1,max=100"`
 }
 
+// ListRequest represents sort and pagination parameters of a list. Sort names a sort key of the
+// list, prefixed with "-" for descending order; a Cursor from a previous page takes precedence over Page.
+type ListRequest struct {
+	PaginationRequest
+	Sort   string `form:"sort"`
+	Cursor string `form:"cursor"`
+}
+
 // SearchRequest represents search parameters
 type SearchRequest struct {
 	Query string `form:"q"`
 	PaginationRequest
 }
+
+// DurationFilter represents length bounds in seconds.
+// The maximum is exclusive so that it reads as "under"; zero leaves a bound open.
+type DurationFilter struct {
+	MinSeconds int
+	MaxSeconds int
+}
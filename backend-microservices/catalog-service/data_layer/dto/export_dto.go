@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// ExportRequest represents the output options of a catalog export. Format is json (one nested
+// document), ndjson (one nested audiobook per line) or csv; Rows picks one CSV row per audiobook or per track.
+type ExportRequest struct {
+	Format string `form:"format"`
+	Rows   string `form:"rows"`
+}
+
+// ExportAudiobook represents an audiobook in a nested JSON or NDJSON export
+type ExportAudiobook struct {
+	ID                   uint            `json:"id"`
+	ExternalID           string          `json:"external_id"`
+	Title                string          `json:"title"`
+	Author               AuthorResponse  `json:"author"`
+	Reader               ReaderResponse  `json:"reader"`
+	Genres               []GenreResponse `json:"genres"`
+	Language             string          `json:"language"`
+	YearOfPublishing     int             `json:"year_of_publishing"`
+	TotalDuration        string          `json:"total_duration"`
+	TotalDurationSeconds int             `json:"total_duration_seconds"`
+	ImageURL             string          `json:"image_url"`
+	Description          string          `json:"description"`
+	CreatedAt            time.Time       `json:"created_at"`
+	UpdatedAt            time.Time       `json:"updated_at"`
+	Tracks               []ExportTrack   `json:"tracks"`
+}
+
+// ExportTrack represents a track of an exported audiobook
+type ExportTrack struct {
+	ID              uint   `json:"id"`
+	ExternalID      string `json:"external_id"`
+	Position        int    `json:"position"`
+	Title           string `json:"title"`
+	URL             string `json:"url"`
+	Duration        string `json:"duration"`
+	DurationSeconds int    `json:"duration_seconds"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// CreateFeedTokenRequest represents the request to create a private feed token
+type CreateFeedTokenRequest struct {
+	Name string `json:"name" binding:"max=100"`
+}
+
+// FeedTokenResponse represents a private feed token. Token is only returned when the token is created.
+type FeedTokenResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Token      string     `json:"token,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// FeedTokenListResponse represents the feed tokens of a user
+type FeedTokenListResponse struct {
+	Tokens []FeedTokenResponse `json:"tokens"`
+}
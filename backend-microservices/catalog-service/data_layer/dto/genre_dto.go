@@ -10,8 +10,10 @@ type UpdateGenreRequest struct {
 	Name string `json:"name" binding:"required,min=1,max=100"`
 }
 
-// GenreResponse represents the response for genre data
+// GenreResponse represents the response for genre data.
+// Version is only set where the genre is returned on its own, not nested in an audiobook.
 type GenreResponse struct {
-	ID   uint   `json:"id"`
-	Name string `json:"name"`
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Version uint   `json:"version,omitempty"`
 }
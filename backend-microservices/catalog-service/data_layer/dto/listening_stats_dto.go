@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// ListeningTotalResponse represents the listening time of a user in one day, week or month
+type ListeningTotalResponse struct {
+	PeriodStart     time.Time `json:"period_start"`
+	ListenedSeconds float64   `json:"listened_seconds"`
+	ContentSeconds  float64   `json:"content_seconds"`
+}
+
+// ListeningTotalsResponse represents the listening time of a user per period between two days
+type ListeningTotalsResponse struct {
+	Period          string                   `json:"period"`
+	From            time.Time                `json:"from"`
+	To              time.Time                `json:"to"`
+	ListenedSeconds float64                  `json:"listened_seconds"`
+	ContentSeconds  float64                  `json:"content_seconds"`
+	Totals          []ListeningTotalResponse `json:"totals"`
+}
+
+// ListeningStreakResponse represents the consecutive listening days of a user
+type ListeningStreakResponse struct {
+	CurrentStreakDays int        `json:"current_streak_days"`
+	LongestStreakDays int        `json:"longest_streak_days"`
+	LastListenedOn    *time.Time `json:"last_listened_on,omitempty"`
+	MinSecondsPerDay  int        `json:"min_seconds_per_day"`
+}
+
+// GenreListeningResponse represents the listening time of a user in one genre
+type GenreListeningResponse struct {
+	GenreID         uint    `json:"genre_id"`
+	GenreName       string  `json:"genre_name"`
+	ListenedSeconds float64 `json:"listened_seconds"`
+}
+
+// TopGenresResponse represents the genres a user listened to most between two days
+type TopGenresResponse struct {
+	From   time.Time                `json:"from"`
+	To     time.Time                `json:"to"`
+	Genres []GenreListeningResponse `json:"genres"`
+}
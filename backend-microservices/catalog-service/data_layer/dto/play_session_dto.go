@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// StartPlaySessionRequest represents the request to start a playback session
+type StartPlaySessionRequest struct {
+	AudiobookID     uint    `json:"audiobook_id" binding:"required"`
+	TrackID         uint    `json:"track_id" binding:"required"`
+	PositionSeconds int     `json:"position_seconds" binding:"min=0"`
+	DurationSeconds int     `json:"duration_seconds" binding:"min=0"`
+	Speed           float64 `json:"speed" binding:"omitempty,gt=0,lte=4"`
+}
+
+// PlaySessionHeartbeatRequest represents a periodic playback report, also sent when stopping
+type PlaySessionHeartbeatRequest struct {
+	TrackID         uint    `json:"track_id" binding:"required"`
+	PositionSeconds int     `json:"position_seconds" binding:"min=0"`
+	DurationSeconds int     `json:"duration_seconds" binding:"min=0"`
+	Speed           float64 `json:"speed" binding:"omitempty,gt=0,lte=4"`
+}
+
+// PlaySessionResponse represents the response for playback session data
+type PlaySessionResponse struct {
+	ID                       uint       `json:"id"`
+	AudiobookID              uint       `json:"audiobook_id"`
+	TrackID                  uint       `json:"track_id"`
+	PositionSeconds          int        `json:"position_seconds"`
+	DurationSeconds          int        `json:"duration_seconds"`
+	Speed                    float64    `json:"speed"`
+	Status                   string     `json:"status"`
+	StartedAt                time.Time  `json:"started_at"`
+	LastHeartbeatAt          time.Time  `json:"last_heartbeat_at"`
+	EndedAt                  *time.Time `json:"ended_at,omitempty"`
+	HeartbeatIntervalSeconds int        `json:"heartbeat_interval_seconds"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// EnqueueRequest represents the request to add a track or a whole audiobook to the play queue
+type EnqueueRequest struct {
+	TrackID     uint `json:"track_id"`
+	AudiobookID uint `json:"audiobook_id"`
+	Position    *int `json:"position" binding:"omitempty,min=0"`
+}
+
+// ReorderQueueRequest represents the new order of every entry in the play queue
+type ReorderQueueRequest struct {
+	EntryIDs []uint `json:"entry_ids" binding:"required"`
+}
+
+// QueueEntryResponse represents one track in the play queue
+type QueueEntryResponse struct {
+	ID          uint      `json:"id"`
+	Position    int       `json:"position"`
+	TrackID     uint      `json:"track_id"`
+	TrackTitle  string    `json:"track_title"`
+	Duration    string    `json:"duration"`
+	AudiobookID uint      `json:"audiobook_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// QueueResponse represents the play queue of a user in play order
+type QueueResponse struct {
+	Entries []QueueEntryResponse `json:"entries"`
+	Total   int                  `json:"total"`
+}
+
+// AdvanceQueueResponse represents the track taken off the head of the queue and what follows it
+type AdvanceQueueResponse struct {
+	NowPlaying *QueueEntryResponse  `json:"now_playing"`
+	UpNext     []QueueEntryResponse `json:"up_next"`
+}
@@ -10,8 +10,10 @@ type UpdateReaderRequest struct {
 	Name string `json:"name" binding:"required,min=1,max=255"`
 }
 
-// ReaderResponse represents the response for reader data
+// ReaderResponse represents the response for reader data.
+// Version is only set where the reader is returned on its own, not nested in an audiobook.
 type ReaderResponse struct {
-	ID   uint   `json:"id"`
-	Name string `json:"name"`
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Version uint   `json:"version,omitempty"`
 }
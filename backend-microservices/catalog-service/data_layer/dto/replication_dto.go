@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ReplicationEventRequest represents a content management event; the payload depends on the event type
+type ReplicationEventRequest struct {
+	Offset        uint            `json:"offset" binding:"required"`
+	AggregateType string          `json:"aggregate_type" binding:"required"`
+	AggregateID   uint            `json:"aggregate_id" binding:"required"`
+	EventType     string          `json:"event_type" binding:"required"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// ReplicationBatchRequest represents the events of a source following its After offset, in offset
+// order, and the latest offset of the source
+type ReplicationBatchRequest struct {
+	Source     string                    `json:"source" binding:"required"`
+	After      uint                      `json:"after"`
+	HeadOffset uint                      `json:"head_offset"`
+	Events     []ReplicationEventRequest `json:"events" binding:"dive"`
+}
+
+// ReplicationOffsetResponse represents the offset of the last event applied
+type ReplicationOffsetResponse struct {
+	Offset uint `json:"offset"`
+}
+
+// ReplicationReplayRequest represents the request to apply events again starting at an offset
+type ReplicationReplayRequest struct {
+	FromOffset uint `json:"from_offset" binding:"required,min=1"`
+}
+
+// ReplicationStatusResponse represents how far the catalog is behind content management
+type ReplicationStatusResponse struct {
+	Source      string     `json:"source"`
+	Offset      uint       `json:"offset"`
+	HeadOffset  uint       `json:"head_offset"`
+	LagEvents   uint       `json:"lag_events"`
+	LagSeconds  int64      `json:"lag_seconds"`
+	LastEventAt *time.Time `json:"last_event_at"`
+	CaughtUpAt  *time.Time `json:"caught_up_at"`
+	LastError   string     `json:"last_error,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// NamedReplicationPayload is the state of an author, reader or genre in its upserted events
+type NamedReplicationPayload struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// TrackReplicationPayload is the state of a track in its upserted events
+type TrackReplicationPayload struct {
+	ID          uint   `json:"id"`
+	AudiobookID uint   `json:"audiobook_id"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Duration    string `json:"duration"`
+}
+
+// AudiobookReplicationPayload is the state of an audiobook in its upserted events, with its genres
+// and its tracks in play order
+type AudiobookReplicationPayload struct {
+	ID               uint                      `json:"id"`
+	Title            string                    `json:"title"`
+	AuthorID         uint                      `json:"author_id"`
+	ReaderID         uint                      `json:"reader_id"`
+	Description      string                    `json:"description"`
+	ImageURL         string                    `json:"image_url"`
+	Language         string                    `json:"language"`
+	YearOfPublishing int                       `json:"year_of_publishing"`
+	TotalDuration    string                    `json:"total_duration"`
+	Status           string                    `json:"status"`
+	GenreIDs         []uint                    `json:"genre_ids"`
+	Tracks           []TrackReplicationPayload `json:"tracks"`
+}
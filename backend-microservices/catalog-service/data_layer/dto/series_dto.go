@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+// CreateSeriesRequest represents the request to create a new series
+type CreateSeriesRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=255"`
+	Description string `json:"description"`
+}
+
+// UpdateSeriesRequest represents the request to update a series
+type UpdateSeriesRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=255"`
+	Description string `json:"description"`
+}
+
+// SeriesVolumeRequest represents one volume of a series; volumes are numbered in request order
+type SeriesVolumeRequest struct {
+	AudiobookID uint   `json:"audiobook_id" binding:"required"`
+	Label       string `json:"label" binding:"max=100"`
+}
+
+// SetSeriesVolumesRequest represents the complete, ordered list of volumes of a series
+type SetSeriesVolumesRequest struct {
+	Volumes []SeriesVolumeRequest `json:"volumes" binding:"required,dive"`
+}
+
+// SeriesVolumeResponse represents a volume of a series with its audiobook
+type SeriesVolumeResponse struct {
+	Volume      int             `json:"volume"`
+	Label       string          `json:"label,omitempty"`
+	AudiobookID uint            `json:"audiobook_id"`
+	Title       string          `json:"title"`
+	ImageURL    string          `json:"image_url"`
+	Author      *AuthorResponse `json:"author,omitempty"`
+}
+
+// SeriesResponse represents the response for series data; Volumes is only listed for a single series
+type SeriesResponse struct {
+	ID          uint                   `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Volumes     []SeriesVolumeResponse `json:"volumes,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
@@ -1,24 +1,52 @@
 package dto
 
-// CreateTrackRequest represents the request to create a new track
+// CreateTrackRequest represents the request to create a new track.
+// Position inserts the track at a 1-based place in the audiobook; it is appended by default.
 type CreateTrackRequest struct {
 	AudiobookID uint   `json:"audiobook_id" binding:"required"`
 	Title       string `json:"title" binding:"required,min=1,max=255"`
 	URL         string `json:"url" binding:"required,url,max=255"`
 	Duration    string `json:"duration"`
+	Position    *int   `json:"position" binding:"omitempty,min=1"`
 }
 
-// UpdateTrackRequest represents the request to update a track
+// UpdateTrackRequest represents the request to update a track.
+// Position moves the track to a 1-based place in the audiobook; it stays put by default.
 type UpdateTrackRequest struct {
 	Title    string `json:"title" binding:"required,min=1,max=255"`
 	URL      string `json:"url" binding:"required,url,max=255"`
 	Duration string `json:"duration"`
+	Position *int   `json:"position" binding:"omitempty,min=1"`
+}
+
+// PatchTrackRequest represents the request to update some fields of a track; fields left out are kept
+type PatchTrackRequest struct {
+	Title    *string `json:"title" binding:"omitempty,min=1,max=255"`
+	URL      *string `json:"url" binding:"omitempty,url,max=255"`
+	Duration *string `json:"duration"`
+	Position *int    `json:"position" binding:"omitempty,min=1"`
+}
+
+// TrackOrder represents the place of one track in an audiobook
+type TrackOrder struct {
+	TrackID uint `json:"track_id" binding:"required"`
+	Order   int  `json:"order" binding:"required,min=1"`
+}
+
+// UpdateTrackOrderRequest represents the new order of every track in an audiobook
+type UpdateTrackOrderRequest struct {
+	TrackOrders []TrackOrder `json:"track_orders" binding:"required,min=1,dive"`
 }
 
 // TrackResponse represents the response for track data
+// URL and HLSURL are short-lived signed links bound to the requesting user
 type TrackResponse struct {
-	ID       uint   `json:"id"`
-	Title    string `json:"title"`
-	URL      string `json:"url"`
-	Duration string `json:"duration"`
+	ID              uint   `json:"id"`
+	Position        int    `json:"position"`
+	Title           string `json:"title"`
+	URL             string `json:"url"`
+	HLSURL          string `json:"hls_url,omitempty"`
+	Duration        string `json:"duration"`
+	DurationSeconds int    `json:"duration_seconds"`
+	Version         uint   `json:"version"`
 }
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// TrashItemResponse represents a deleted catalog entry in the trash. AudiobookID is only set for tracks.
+type TrashItemResponse struct {
+	Type        string    `json:"type"`
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	AudiobookID uint      `json:"audiobook_id,omitempty"`
+	DeletedAt   time.Time `json:"deleted_at"`
+	PurgeAt     time.Time `json:"purge_at"`
+}
+
+// TrashPurgeResponse represents how many entries of each type a purge deleted permanently
+type TrashPurgeResponse struct {
+	Purged map[string]int64 `json:"purged"`
+}
@@ -2,27 +2,38 @@ package entity
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // Audiobook represents the audiobooks table
 type Audiobook struct {
-	ID               uint      `gorm:"primaryKey;autoIncrement" json:"id"`
-	Title            string    `gorm:"not null" json:"title"`
-	AuthorID         uint      `gorm:"not null" json:"author_id"`
-	ReaderID         uint      `gorm:"not null" json:"reader_id"`
-	Description      string    `gorm:"type:text" json:"description"`
-	ImageURL         string    `json:"image_url"`
-	Language         string    `json:"language"`
-	YearOfPublishing int       `json:"year_of_publishing"`
-	TotalDuration    string    `json:"total_duration"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID                   uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	Title                string         `gorm:"not null" json:"title"`
+	ExternalID           string         `gorm:"size:64;index:idx_audiobooks_external_id,unique,where:external_id <> ''" json:"external_id,omitempty"`
+	AuthorID             uint           `gorm:"not null" json:"author_id"`
+	ReaderID             uint           `gorm:"not null" json:"reader_id"`
+	Description          string         `gorm:"type:text" json:"description"`
+	ImageURL             string         `json:"image_url"`
+	Language             string         `json:"language"`
+	YearOfPublishing     int            `json:"year_of_publishing"`
+	TotalDuration        string         `json:"total_duration"`
+	TotalDurationSeconds int            `gorm:"not null;default:0;index" json:"total_duration_seconds"`
+	Version              uint           `gorm:"not null;default:1" json:"version"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 
 	// Relationships
-	Author    *Author      `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
-	Reader    *Reader      `gorm:"foreignKey:ReaderID" json:"reader,omitempty"`
-	Genres    []Genre     `gorm:"many2many:audiobook_genres" json:"genres,omitempty"`
-	Tracks    []Track     `gorm:"foreignKey:AudiobookID" json:"tracks,omitempty"`
+	Author *Author `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	Reader *Reader `gorm:"foreignKey:ReaderID" json:"reader,omitempty"`
+	Genres []Genre `gorm:"many2many:audiobook_genres" json:"genres,omitempty"`
+	Tracks []Track `gorm:"foreignKey:AudiobookID" json:"tracks,omitempty"`
+
+	// Every credited author and reader, in credit order, and the series volume if any
+	Authors      []AudiobookAuthor `gorm:"foreignKey:AudiobookID" json:"authors,omitempty"`
+	Readers      []AudiobookReader `gorm:"foreignKey:AudiobookID" json:"readers,omitempty"`
+	SeriesVolume *SeriesVolume     `gorm:"foreignKey:AudiobookID" json:"series_volume,omitempty"`
 }
 
 // TableName specifies the table name for the Audiobook model
@@ -0,0 +1,44 @@
+package entity
+
+// Credit roles. Authors are credited as author, translator or editor, readers as narrator.
+const (
+	RoleAuthor     = "author"
+	RoleTranslator = "translator"
+	RoleEditor     = "editor"
+	RoleNarrator   = "narrator"
+)
+
+// AudiobookAuthor represents the audiobook_authors table, an author credited on an audiobook.
+// Position is the 1-based order of the credits of an audiobook. The first author-role credit is the
+// primary author mirrored in Audiobook.AuthorID.
+type AudiobookAuthor struct {
+	AudiobookID uint   `json:"audiobook_id" gorm:"primaryKey"`
+	AuthorID    uint   `json:"author_id" gorm:"primaryKey;index"`
+	Role        string `json:"role" gorm:"primaryKey;size:20"`
+	Position    int    `json:"position" gorm:"not null;default:0"`
+
+	// Relationships
+	Author *Author `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+}
+
+// TableName specifies the table name for the AudiobookAuthor model
+func (AudiobookAuthor) TableName() string {
+	return "audiobook_authors"
+}
+
+// AudiobookReader represents the audiobook_readers table, a reader credited on an audiobook, such as
+// each member of a group recording. The first narrator is the primary reader mirrored in Audiobook.ReaderID.
+type AudiobookReader struct {
+	AudiobookID uint   `json:"audiobook_id" gorm:"primaryKey"`
+	ReaderID    uint   `json:"reader_id" gorm:"primaryKey;index"`
+	Role        string `json:"role" gorm:"primaryKey;size:20"`
+	Position    int    `json:"position" gorm:"not null;default:0"`
+
+	// Relationships
+	Reader *Reader `json:"reader,omitempty" gorm:"foreignKey:ReaderID"`
+}
+
+// TableName specifies the table name for the AudiobookReader model
+func (AudiobookReader) TableName() string {
+	return "audiobook_readers"
+}
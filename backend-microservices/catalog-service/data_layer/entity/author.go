@@ -2,14 +2,19 @@ package entity
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // Author represents the authors table
 type Author struct {
-	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name      string    `json:"name" gorm:"size:255;not null;unique"`
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID         uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name       string         `json:"name" gorm:"size:255;not null;unique"`
+	ExternalID string         `json:"external_id,omitempty" gorm:"size:64;index:idx_authors_external_id,unique,where:external_id <> ''"`
+	Version    uint           `json:"version" gorm:"not null;default:1"`
+	CreatedAt  time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt  gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 
 	// Relationships
 	Audiobooks []Audiobook `json:"audiobooks,omitempty" gorm:"foreignKey:AuthorID"`
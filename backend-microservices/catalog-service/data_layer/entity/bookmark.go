@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+)
+
+// Bookmark represents the bookmarks table, a user's timestamped mark and note inside a track
+type Bookmark struct {
+	ID            uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID        string    `json:"user_id" gorm:"size:255;not null;uniqueIndex:idx_bookmarks_user_track_offset"`
+	TrackID       uint      `json:"track_id" gorm:"not null;uniqueIndex:idx_bookmarks_user_track_offset"`
+	OffsetSeconds int       `json:"offset_seconds" gorm:"not null;default:0;uniqueIndex:idx_bookmarks_user_track_offset"`
+	Label         string    `json:"label" gorm:"size:255"`
+	Note          string    `json:"note" gorm:"type:text"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Track Track `json:"track,omitempty" gorm:"foreignKey:TrackID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for the Bookmark model
+func (Bookmark) TableName() string {
+	return "bookmarks"
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+)
+
+// FeedToken represents the feed_tokens table, a revocable secret that opens a user's private podcast feeds.
+// Only the SHA-256 hash of the token is stored; the token itself is shown once when it is created.
+type FeedToken struct {
+	ID         uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID     string     `json:"user_id" gorm:"size:255;not null;index"`
+	Name       string     `json:"name" gorm:"size:100"`
+	TokenHash  string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for the FeedToken model
+func (FeedToken) TableName() string {
+	return "feed_tokens"
+}
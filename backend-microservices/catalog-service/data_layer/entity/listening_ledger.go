@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+)
+
+// ListeningLedger represents the listening_ledger table, the seconds a user listened to a track on a day.
+// ListenedSeconds is wall-clock listening time; ContentSeconds is how much of the recording it covered,
+// which differs from it when playback runs faster or slower than 1x.
+// Rows deliberately carry no foreign keys so listening history outlives catalog changes.
+type ListeningLedger struct {
+	ID              uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID          string    `json:"user_id" gorm:"size:255;not null;uniqueIndex:idx_listening_ledger_user_track_day,priority:1"`
+	Day             time.Time `json:"day" gorm:"type:date;not null;uniqueIndex:idx_listening_ledger_user_track_day,priority:3"`
+	TrackID         uint      `json:"track_id" gorm:"not null;uniqueIndex:idx_listening_ledger_user_track_day,priority:2"`
+	AudiobookID     uint      `json:"audiobook_id" gorm:"not null;index"`
+	ListenedSeconds float64   `json:"listened_seconds" gorm:"not null;default:0"`
+	ContentSeconds  float64   `json:"content_seconds" gorm:"not null;default:0"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for the ListeningLedger model
+func (ListeningLedger) TableName() string {
+	return "listening_ledger"
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+)
+
+// Play session statuses
+const (
+	PlaySessionActive    = "ACTIVE"
+	PlaySessionFinished  = "FINISHED"
+	PlaySessionStopped   = "STOPPED"
+	PlaySessionAbandoned = "ABANDONED"
+)
+
+// PlaySession represents the play_sessions table, one listening session reported through heartbeats
+type PlaySession struct {
+	ID              uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID          string     `json:"user_id" gorm:"size:255;not null;index"`
+	AudiobookID     uint       `json:"audiobook_id" gorm:"not null;index"`
+	TrackID         uint       `json:"track_id" gorm:"not null"`
+	PositionSeconds int        `json:"position_seconds" gorm:"not null;default:0"`
+	DurationSeconds int        `json:"duration_seconds" gorm:"not null;default:0"`
+	Speed           float64    `json:"speed" gorm:"not null;default:1"`
+	Status          string     `json:"status" gorm:"size:20;not null;index"`
+	StartedAt       time.Time  `json:"started_at" gorm:"not null"`
+	LastHeartbeatAt time.Time  `json:"last_heartbeat_at" gorm:"not null;index"`
+	EndedAt         *time.Time `json:"ended_at"`
+
+	// Relationships
+	Audiobook Audiobook `json:"audiobook,omitempty" gorm:"foreignKey:AudiobookID"`
+}
+
+// TableName specifies the table name for the PlaySession model
+func (PlaySession) TableName() string {
+	return "play_sessions"
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+)
+
+// QueueEntry represents the queue_entries table, one track in a user's "Up Next" queue.
+// Entries carry no foreign key on the track; the queue drops entries whose track left the catalog when it is read.
+// Positions are unique per user through a deferrable constraint added by migration.EnsureQueuePositions.
+type QueueEntry struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID      string    `json:"user_id" gorm:"size:255;not null"`
+	TrackID     uint      `json:"track_id" gorm:"not null"`
+	AudiobookID uint      `json:"audiobook_id" gorm:"not null"`
+	Position    int       `json:"position" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	// Relationships
+	Track Track `json:"track,omitempty" gorm:"foreignKey:TrackID;constraint:-"`
+}
+
+// TableName specifies the table name for the QueueEntry model
+func (QueueEntry) TableName() string {
+	return "queue_entries"
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+)
+
+// ReplicationCursor represents the replication_cursors table: how far the events of a source service
+// have been applied to the catalog. Offset is the last applied event and HeadOffset the latest event
+// the source reported. CaughtUpAt is the last time the two met, so a lagging catalog is as far
+// behind as the time since then; one that never caught up counts from LastEventAt or CreatedAt.
+type ReplicationCursor struct {
+	Source      string     `json:"source" gorm:"primaryKey;size:40"`
+	Offset      uint       `json:"offset" gorm:"not null;default:0"`
+	HeadOffset  uint       `json:"head_offset" gorm:"not null;default:0"`
+	LastEventAt *time.Time `json:"last_event_at"`
+	CaughtUpAt  *time.Time `json:"caught_up_at"`
+	LastError   string     `json:"last_error" gorm:"type:text"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime;not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for the ReplicationCursor model
+func (ReplicationCursor) TableName() string {
+	return "replication_cursors"
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+)
+
+// Series represents the series table, a sequence of audiobooks such as the volumes of a novel
+type Series struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string    `json:"name" gorm:"size:255;not null;index"`
+	Description string    `json:"description" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Volumes []SeriesVolume `json:"volumes,omitempty" gorm:"foreignKey:SeriesID"`
+}
+
+// TableName specifies the table name for the Series model
+func (Series) TableName() string {
+	return "series"
+}
+
+// SeriesVolume represents the series_volumes table, an audiobook at its 1-based Position in a series.
+// An audiobook belongs to at most one series; Label is an optional display name such as "Book II".
+type SeriesVolume struct {
+	ID          uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	SeriesID    uint   `json:"series_id" gorm:"not null;index:idx_series_volumes_series_position,priority:1"`
+	AudiobookID uint   `json:"audiobook_id" gorm:"not null;uniqueIndex"`
+	Position    int    `json:"position" gorm:"not null;index:idx_series_volumes_series_position,priority:2"`
+	Label       string `json:"label" gorm:"size:100"`
+
+	// Relationships
+	Series    *Series    `json:"series,omitempty" gorm:"foreignKey:SeriesID"`
+	Audiobook *Audiobook `json:"audiobook,omitempty" gorm:"foreignKey:AudiobookID"`
+}
+
+// TableName specifies the table name for the SeriesVolume model
+func (SeriesVolume) TableName() string {
+	return "series_volumes"
+}
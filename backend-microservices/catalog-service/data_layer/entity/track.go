@@ -2,17 +2,25 @@ package entity
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
-// Track represents the tracks table
+// Track represents the tracks table.
+// Position is the 1-based play order of the track within its audiobook, kept gapless.
 type Track struct {
-	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
-	AudiobookID uint      `json:"audiobook_id" gorm:"not null"`
-	Title       string    `json:"title" gorm:"size:255;not null"`
-	URL         string    `json:"url" gorm:"size:255;not null"`
-	Duration    string    `json:"duration" gorm:"size:20"`
-	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID              uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	AudiobookID     uint           `json:"audiobook_id" gorm:"not null;index:idx_tracks_audiobook_position,priority:1"`
+	Position        int            `json:"position" gorm:"not null;default:0;index:idx_tracks_audiobook_position,priority:2"`
+	Title           string         `json:"title" gorm:"size:255;not null"`
+	ExternalID      string         `json:"external_id,omitempty" gorm:"size:64;index:idx_tracks_external_id,unique,where:external_id <> ''"`
+	URL             string         `json:"url" gorm:"size:255;not null"`
+	Duration        string         `json:"duration" gorm:"size:20"`
+	DurationSeconds int            `json:"duration_seconds" gorm:"not null;default:0;index"`
+	Version         uint           `json:"version" gorm:"not null;default:1"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 
 	// Relationships
 	Audiobook Audiobook `json:"audiobook,omitempty" gorm:"foreignKey:AudiobookID"`
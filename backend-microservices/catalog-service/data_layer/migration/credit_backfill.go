@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// BackfillCredits credits the single author and reader of every audiobook that has no credits yet,
+// as its author and narrator. Audiobooks with credits are left untouched, so it is safe to run on every start.
+func BackfillCredits(db *gorm.DB) error {
+	authors := db.Exec(`
+		INSERT INTO audiobook_authors (audiobook_id, author_id, role, position)
+		SELECT a.id, a.author_id, 'author', 1
+		FROM audiobooks a
+		WHERE a.author_id <> 0
+			AND NOT EXISTS (SELECT 1 FROM audiobook_authors aa WHERE aa.audiobook_id = a.id)`)
+	if authors.Error != nil {
+		return authors.Error
+	}
+
+	readers := db.Exec(`
+		INSERT INTO audiobook_readers (audiobook_id, reader_id, role, position)
+		SELECT a.id, a.reader_id, 'narrator', 1
+		FROM audiobooks a
+		WHERE a.reader_id <> 0
+			AND NOT EXISTS (SELECT 1 FROM audiobook_readers ar WHERE ar.audiobook_id = a.id)`)
+	if readers.Error != nil {
+		return readers.Error
+	}
+
+	if authors.RowsAffected > 0 || readers.RowsAffected > 0 {
+		log.Printf("Credited %d authors and %d readers of existing audiobooks", authors.RowsAffected, readers.RowsAffected)
+	}
+	return nil
+}
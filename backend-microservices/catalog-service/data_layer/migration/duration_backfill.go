@@ -0,0 +1,113 @@
+package migration
+
+import (
+	"catalog-service/data_layer/entity"
+	"catalog-service/helpers/duration"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// backfillBatchSize bounds how many rows the duration backfill loads at once
+const backfillBatchSize = 500
+
+// BackfillDurations fills the seconds columns of tracks and audiobooks from their free-form duration text.
+// Only rows still at zero seconds are touched, so it is safe to run on every start.
+// Audiobook totals are summed from their tracks; the hand-entered text is used for audiobooks without tracks.
+func BackfillDurations(db *gorm.DB) error {
+	if err := backfillTrackDurations(db); err != nil {
+		return err
+	}
+	return backfillAudiobookDurations(db)
+}
+
+func backfillTrackDurations(db *gorm.DB) error {
+	var tracks []entity.Track
+	filled, unparsable := 0, 0
+
+	err := db.Select("id", "duration").
+		Where("duration_seconds = 0 AND duration <> ''").
+		FindInBatches(&tracks, backfillBatchSize, func(tx *gorm.DB, batch int) error {
+			for _, track := range tracks {
+				seconds, err := duration.Parse(track.Duration)
+				if err != nil {
+					unparsable++
+					continue
+				}
+				if seconds == 0 {
+					continue
+				}
+				if err := db.Model(&entity.Track{}).Where("id = ?", track.ID).Update("duration_seconds", seconds).Error; err != nil {
+					return err
+				}
+				filled++
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	if filled > 0 || unparsable > 0 {
+		log.Printf("Backfilled durations of %d tracks (%d unparsable)", filled, unparsable)
+	}
+	return nil
+}
+
+func backfillAudiobookDurations(db *gorm.DB) error {
+	var sums []struct {
+		AudiobookID uint
+		Seconds     int
+	}
+	err := db.Model(&entity.Track{}).
+		Select("audiobook_id, SUM(duration_seconds) AS seconds").
+		Group("audiobook_id").
+		Scan(&sums).Error
+	if err != nil {
+		return err
+	}
+
+	trackSeconds := make(map[uint]int, len(sums))
+	for _, sum := range sums {
+		trackSeconds[sum.AudiobookID] = sum.Seconds
+	}
+
+	var audiobooks []entity.Audiobook
+	filled, unparsable := 0, 0
+
+	err = db.Select("id", "total_duration").
+		Where("total_duration_seconds = 0").
+		FindInBatches(&audiobooks, backfillBatchSize, func(tx *gorm.DB, batch int) error {
+			for _, audiobook := range audiobooks {
+				updates := map[string]interface{}{}
+				if seconds := trackSeconds[audiobook.ID]; seconds > 0 {
+					updates["total_duration_seconds"] = seconds
+					updates["total_duration"] = duration.Format(seconds)
+				} else {
+					seconds, err := duration.Parse(audiobook.TotalDuration)
+					if err != nil {
+						unparsable++
+						continue
+					}
+					if seconds == 0 {
+						continue
+					}
+					updates["total_duration_seconds"] = seconds
+				}
+
+				if err := db.Model(&entity.Audiobook{}).Where("id = ?", audiobook.ID).Updates(updates).Error; err != nil {
+					return err
+				}
+				filled++
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	if filled > 0 || unparsable > 0 {
+		log.Printf("Backfilled durations of %d audiobooks (%d unparsable)", filled, unparsable)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package librivox
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// objectID is a MongoDB export of a document ID: {"$oid": "5e8b7d2b8d317cbee202bd03"}
+type objectID struct {
+	OID string `json:"$oid"`
+}
+
+// PersonRecord is a line of authors.json or readers.json. ID is the number audiobooks refer to.
+type PersonRecord struct {
+	ObjectID objectID `json:"_id"`
+	ID       int      `json:"id"`
+	Name     string   `json:"name"`
+}
+
+// GenreRecord is a line of genres.json; audiobooks refer to genres by name
+type GenreRecord struct {
+	ObjectID objectID `json:"_id"`
+	Name     string   `json:"name"`
+}
+
+// AudiobookRecord is an entry of audiobooks.json. ImageURL is only set in exports that carry covers,
+// or from updateAudiobooks.json.
+type AudiobookRecord struct {
+	ObjectID objectID         `json:"_id"`
+	ID       int              `json:"id"`
+	ImageURL string           `json:"imgUrl"`
+	Details  AudiobookDetails `json:"details"`
+	Tracks   []TrackRecord    `json:"tracks"`
+}
+
+// AudiobookDetails holds the catalog fields of an audiobook record
+type AudiobookDetails struct {
+	Title            string   `json:"title"`
+	AuthorID         int      `json:"authorId"`
+	Author           string   `json:"author"`
+	Genres           []string `json:"genres"`
+	YearOfPublishing int      `json:"yearOfPublishing"`
+	Language         string   `json:"language"`
+	ReaderID         int      `json:"readerId"`
+	Reader           string   `json:"reader"`
+	YoutubeVideoURL  string   `json:"youtubeVideoUrl"`
+	LibrivoxPageURL  string   `json:"librivoxPageUrl"`
+	TotalDuration    string   `json:"totalDuration"`
+	Description      string   `json:"description"`
+}
+
+// TrackRecord is a track of an audiobook record, listed in play order
+type TrackRecord struct {
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Duration string `json:"duration"`
+}
+
+// Dataset is the content of a LibriVox export directory
+type Dataset struct {
+	Authors    []PersonRecord
+	Readers    []PersonRecord
+	Genres     []GenreRecord
+	Audiobooks []AudiobookRecord
+}
+
+// Load reads authors.json, readers.json, genres.json and audiobooks.json from dir, and the covers of
+// updateAudiobooks.json when it is there. The first three hold one JSON document per line;
+// audiobooks.json is either {"data": [...]} or a plain array.
+func Load(dir string) (*Dataset, error) {
+	dataset := &Dataset{}
+
+	err := readDocuments(filepath.Join(dir, "authors.json"), func(decoder *json.Decoder) error {
+		var author PersonRecord
+		err := decoder.Decode(&author)
+		if err == nil {
+			dataset.Authors = append(dataset.Authors, author)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readDocuments(filepath.Join(dir, "readers.json"), func(decoder *json.Decoder) error {
+		var reader PersonRecord
+		err := decoder.Decode(&reader)
+		if err == nil {
+			dataset.Readers = append(dataset.Readers, reader)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readDocuments(filepath.Join(dir, "genres.json"), func(decoder *json.Decoder) error {
+		var genre GenreRecord
+		err := decoder.Decode(&genre)
+		if err == nil {
+			dataset.Genres = append(dataset.Genres, genre)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "audiobooks.json"))
+	if err != nil {
+		return nil, err
+	}
+	raw = bytes.TrimSpace(raw)
+	if bytes.HasPrefix(raw, []byte("[")) {
+		err = json.Unmarshal(raw, &dataset.Audiobooks)
+	} else {
+		var export struct {
+			Data []AudiobookRecord `json:"data"`
+		}
+		err = json.Unmarshal(raw, &export)
+		dataset.Audiobooks = export.Data
+	}
+	if err != nil {
+		return nil, fmt.Errorf("audiobooks.json: %w", err)
+	}
+
+	covers, err := loadCovers(filepath.Join(dir, "updateAudiobooks.json"))
+	if err != nil {
+		return nil, err
+	}
+	for i := range dataset.Audiobooks {
+		audiobook := &dataset.Audiobooks[i]
+		if audiobook.ImageURL == "" {
+			audiobook.ImageURL = covers[audiobook.ObjectID.OID]
+		}
+	}
+
+	return dataset, nil
+}
+
+// loadCovers reads the cover images of updateAudiobooks.json, {"data": [...]} with an imgUrl on each
+// audiobook, by the _id.$oid of the audiobook. A missing file has no covers.
+func loadCovers(path string) (map[string]string, error) {
+	covers := make(map[string]string)
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return covers, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var export struct {
+		Data []struct {
+			ObjectID objectID `json:"_id"`
+			ImageURL string   `json:"imgUrl"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &export); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+
+	for _, audiobook := range export.Data {
+		if audiobook.ObjectID.OID != "" && audiobook.ImageURL != "" {
+			covers[audiobook.ObjectID.OID] = audiobook.ImageURL
+		}
+	}
+	return covers, nil
+}
+
+// readDocuments calls decode for every JSON document of a stream, such as one document per line
+func readDocuments(path string, decode func(decoder *json.Decoder) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(file))
+	for decoder.More() {
+		if err := decode(decoder); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+	}
+	return nil
+}
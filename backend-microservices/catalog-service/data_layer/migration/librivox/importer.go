@@ -0,0 +1,461 @@
+package librivox
+
+import (
+	"catalog-service/data_layer/entity"
+	"catalog-service/data_layer/repository"
+	"catalog-service/helpers/duration"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// errDryRun rolls back the import transaction of a dry run
+var errDryRun = errors.New("dry run")
+
+// namedRow is the part of an author, reader or genre an import compares
+type namedRow struct {
+	ID         uint
+	Name       string
+	ExternalID string
+}
+
+// importer upserts a dataset inside one transaction, remembering the IDs it resolved
+type importer struct {
+	tx         *gorm.DB
+	report     *Report
+	authorIDs  map[int]uint
+	readerIDs  map[int]uint
+	genreIDs   map[string]uint
+	audiobooks []uint
+}
+
+// Import upserts the authors, readers, genres, audiobooks and tracks of a dataset. Rows are matched
+// by the external ID of their record, so running it again only applies what changed in the export.
+// Rows created before the import are adopted by name (audiobooks by title and author). Tracks of an
+// imported audiobook that are no longer in the export are moved to the trash. Trashed rows are
+// still matched and updated, but stay in the trash. A dry run rolls everything back and only
+// returns the report.
+func Import(db *gorm.DB, dataset *Dataset, dryRun bool) (*Report, error) {
+	report := newReport(dryRun)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		imp := &importer{
+			tx:        tx,
+			report:    report,
+			authorIDs: map[int]uint{},
+			readerIDs: map[int]uint{},
+			genreIDs:  map[string]uint{},
+		}
+
+		if err := imp.importPeople(dataset); err != nil {
+			return err
+		}
+		if err := imp.importGenres(dataset); err != nil {
+			return err
+		}
+		for _, record := range dataset.Audiobooks {
+			if err := imp.importAudiobook(record); err != nil {
+				return fmt.Errorf("audiobook %q: %w", record.Details.Title, err)
+			}
+		}
+
+		if err := repository.NewSearchIndexRepository(tx).RefreshAudiobooks(imp.audiobooks); err != nil {
+			return err
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// importPeople upserts the authors and readers files, then any author or reader an audiobook
+// names without a record; those have no external ID and are matched by name
+func (imp *importer) importPeople(dataset *Dataset) error {
+	for _, record := range dataset.Authors {
+		id, err := imp.upsertNamed(&entity.Author{}, &imp.report.Authors, record.ObjectID.OID, record.Name, func() (uint, error) {
+			author := entity.Author{Name: record.Name, ExternalID: record.ObjectID.OID}
+			err := imp.tx.Create(&author).Error
+			return author.ID, err
+		})
+		if err != nil {
+			return fmt.Errorf("author %q: %w", record.Name, err)
+		}
+		imp.authorIDs[record.ID] = id
+	}
+
+	for _, record := range dataset.Readers {
+		id, err := imp.upsertNamed(&entity.Reader{}, &imp.report.Readers, record.ObjectID.OID, record.Name, func() (uint, error) {
+			reader := entity.Reader{Name: record.Name, ExternalID: record.ObjectID.OID}
+			err := imp.tx.Create(&reader).Error
+			return reader.ID, err
+		})
+		if err != nil {
+			return fmt.Errorf("reader %q: %w", record.Name, err)
+		}
+		imp.readerIDs[record.ID] = id
+	}
+
+	for _, record := range dataset.Audiobooks {
+		details := record.Details
+		if _, ok := imp.authorIDs[details.AuthorID]; !ok {
+			id, err := imp.upsertNamed(&entity.Author{}, &imp.report.Authors, "", details.Author, func() (uint, error) {
+				author := entity.Author{Name: details.Author}
+				err := imp.tx.Create(&author).Error
+				return author.ID, err
+			})
+			if err != nil {
+				return fmt.Errorf("author %q: %w", details.Author, err)
+			}
+			imp.authorIDs[details.AuthorID] = id
+		}
+		if _, ok := imp.readerIDs[details.ReaderID]; !ok {
+			id, err := imp.upsertNamed(&entity.Reader{}, &imp.report.Readers, "", details.Reader, func() (uint, error) {
+				reader := entity.Reader{Name: details.Reader}
+				err := imp.tx.Create(&reader).Error
+				return reader.ID, err
+			})
+			if err != nil {
+				return fmt.Errorf("reader %q: %w", details.Reader, err)
+			}
+			imp.readerIDs[details.ReaderID] = id
+		}
+	}
+
+	return nil
+}
+
+// importGenres upserts the genres file, then any genre an audiobook names without a record
+func (imp *importer) importGenres(dataset *Dataset) error {
+	upsert := func(externalID, name string) error {
+		id, err := imp.upsertNamed(&entity.Genre{}, &imp.report.Genres, externalID, name, func() (uint, error) {
+			genre := entity.Genre{Name: name, ExternalID: externalID}
+			err := imp.tx.Create(&genre).Error
+			return genre.ID, err
+		})
+		if err != nil {
+			return fmt.Errorf("genre %q: %w", name, err)
+		}
+		imp.genreIDs[strings.ToLower(name)] = id
+		return nil
+	}
+
+	for _, record := range dataset.Genres {
+		if err := upsert(record.ObjectID.OID, record.Name); err != nil {
+			return err
+		}
+	}
+	for _, record := range dataset.Audiobooks {
+		for _, name := range record.Details.Genres {
+			if _, ok := imp.genreIDs[strings.ToLower(name)]; ok {
+				continue
+			}
+			if err := upsert("", name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// upsertNamed finds an author, reader or genre by external ID, then by name, updating its name and
+// external ID when they differ from the record, or creates it with create when it does not exist.
+// Trashed rows are matched too, since names and external IDs stay unique in the trash.
+func (imp *importer) upsertNamed(model interface{}, section *Section, externalID, name string, create func() (uint, error)) (uint, error) {
+	var row namedRow
+	err := gorm.ErrRecordNotFound
+	if externalID != "" {
+		err = imp.tx.Unscoped().Model(model).Where("external_id = ?", externalID).Take(&row).Error
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = imp.tx.Unscoped().Model(model).Where("name = ?", name).Take(&row).Error
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		id, err := create()
+		if err != nil {
+			return 0, err
+		}
+		section.created(name)
+		return id, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	updates := map[string]interface{}{}
+	var fields []string
+	if row.Name != name {
+		updates["name"] = name
+		fields = append(fields, fieldChange("name", row.Name, name))
+	}
+	// Rows replicated from another service keep the external ID replication finds them by
+	if externalID != "" && row.ExternalID != externalID && !repository.IsReplicatedExternalID(row.ExternalID) {
+		updates["external_id"] = externalID
+		fields = append(fields, fieldChange("external_id", row.ExternalID, externalID))
+	}
+	if len(updates) > 0 {
+		updates["version"] = gorm.Expr("version + 1")
+		if err := imp.tx.Unscoped().Model(model).Where("id = ?", row.ID).Updates(updates).Error; err != nil {
+			return 0, err
+		}
+	}
+	section.updated(name, fields)
+
+	return row.ID, nil
+}
+
+// importAudiobook upserts an audiobook, its genres and its tracks. The total length is the sum of the
+// track durations, or the record's total for audiobooks without tracks.
+func (imp *importer) importAudiobook(record AudiobookRecord) error {
+	details := record.Details
+	externalID := record.ObjectID.OID
+	if externalID == "" {
+		return errors.New("missing _id")
+	}
+
+	tracks := make([]entity.Track, len(record.Tracks))
+	totalSeconds := 0
+	for i, trackRecord := range record.Tracks {
+		seconds, err := duration.Parse(trackRecord.Duration)
+		if err != nil {
+			return fmt.Errorf("track %q: %w", trackRecord.Title, err)
+		}
+		tracks[i] = entity.Track{
+			ExternalID:      fmt.Sprintf("%s/%d", externalID, i+1),
+			Position:        i + 1,
+			Title:           trackRecord.Title,
+			URL:             trackRecord.URL,
+			Duration:        trackRecord.Duration,
+			DurationSeconds: seconds,
+		}
+		totalSeconds += seconds
+	}
+	if len(tracks) == 0 {
+		seconds, err := duration.Parse(details.TotalDuration)
+		if err != nil {
+			return err
+		}
+		totalSeconds = seconds
+	}
+	totalDuration := ""
+	if totalSeconds > 0 {
+		totalDuration = duration.Format(totalSeconds)
+	}
+
+	desired := entity.Audiobook{
+		ExternalID:           externalID,
+		Title:                details.Title,
+		AuthorID:             imp.authorIDs[details.AuthorID],
+		ReaderID:             imp.readerIDs[details.ReaderID],
+		Description:          details.Description,
+		ImageURL:             record.ImageURL,
+		Language:             details.Language,
+		YearOfPublishing:     details.YearOfPublishing,
+		TotalDuration:        totalDuration,
+		TotalDurationSeconds: totalSeconds,
+	}
+
+	var audiobook entity.Audiobook
+	err := imp.tx.Unscoped().Where("external_id = ?", externalID).Take(&audiobook).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = imp.tx.Unscoped().Where("title = ? AND author_id = ? AND (external_id IS NULL OR external_id = '')", desired.Title, desired.AuthorID).Take(&audiobook).Error
+	}
+
+	var fields []string
+	created := false
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		audiobook = desired
+		if err := imp.tx.Create(&audiobook).Error; err != nil {
+			return err
+		}
+		created = true
+	case err != nil:
+		return err
+	default:
+		updates := map[string]interface{}{}
+		compare := func(column string, from, to interface{}) {
+			if change := fieldChange(column, from, to); change != "" {
+				updates[column] = to
+				fields = append(fields, change)
+			}
+		}
+		compare("external_id", audiobook.ExternalID, desired.ExternalID)
+		compare("title", audiobook.Title, desired.Title)
+		compare("author_id", audiobook.AuthorID, desired.AuthorID)
+		compare("reader_id", audiobook.ReaderID, desired.ReaderID)
+		compare("description", audiobook.Description, desired.Description)
+		compare("language", audiobook.Language, desired.Language)
+		compare("year_of_publishing", audiobook.YearOfPublishing, desired.YearOfPublishing)
+		compare("total_duration", audiobook.TotalDuration, desired.TotalDuration)
+		compare("total_duration_seconds", audiobook.TotalDurationSeconds, desired.TotalDurationSeconds)
+		// Exports without covers keep the cover already set
+		if desired.ImageURL != "" {
+			compare("image_url", audiobook.ImageURL, desired.ImageURL)
+		}
+
+		if len(updates) > 0 {
+			updates["version"] = gorm.Expr("version + 1")
+			if err := imp.tx.Unscoped().Model(&entity.Audiobook{}).Where("id = ?", audiobook.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	// The record's author and reader are the primary credits; co-credits added in the catalog are kept
+	if err := repository.NewAudiobookRepository(imp.tx).SyncPrimaryCredits(audiobook.ID, desired.AuthorID, desired.ReaderID); err != nil {
+		return err
+	}
+
+	genreChange, err := imp.syncGenres(audiobook.ID, details.Genres)
+	if err != nil {
+		return err
+	}
+	if created {
+		imp.report.Audiobooks.created(desired.Title)
+	} else {
+		imp.report.Audiobooks.updated(desired.Title, appendChange(fields, genreChange))
+	}
+
+	if err := imp.syncTracks(audiobook.ID, desired.Title, audiobook.DeletedAt, tracks); err != nil {
+		return err
+	}
+
+	imp.audiobooks = append(imp.audiobooks, audiobook.ID)
+	return nil
+}
+
+// syncGenres sets the genres of an audiobook to the named ones, describing the change for the report
+func (imp *importer) syncGenres(audiobookID uint, names []string) (string, error) {
+	var current []entity.Genre
+	err := imp.tx.Unscoped().Table("genres").
+		Joins("JOIN audiobook_genres ON audiobook_genres.genre_id = genres.id").
+		Where("audiobook_genres.audiobook_id = ?", audiobookID).
+		Order("genres.name ASC").
+		Find(&current).Error
+	if err != nil {
+		return "", err
+	}
+
+	var genres []entity.Genre
+	seen := map[uint]bool{}
+	for _, name := range names {
+		id := imp.genreIDs[strings.ToLower(name)]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		genres = append(genres, entity.Genre{ID: id, Name: name})
+	}
+	sort.Slice(genres, func(i, j int) bool { return genres[i].Name < genres[j].Name })
+
+	from := genreNames(current)
+	to := genreNames(genres)
+	if from == to {
+		return "", nil
+	}
+
+	audiobook := entity.Audiobook{ID: audiobookID}
+	if err := imp.tx.Model(&audiobook).Association("Genres").Replace(genres); err != nil {
+		return "", err
+	}
+	return fieldChange("genres", from, to), nil
+}
+
+// syncTracks makes the tracks of an audiobook match the export: tracks are matched by external ID,
+// then tracks without one by URL, and tracks left over are moved to the trash. New tracks of a
+// trashed audiobook are created in the trash along with it.
+func (imp *importer) syncTracks(audiobookID uint, audiobookTitle string, deletedAt gorm.DeletedAt, tracks []entity.Track) error {
+	var existing []entity.Track
+	if err := imp.tx.Unscoped().Where("audiobook_id = ?", audiobookID).Order("position ASC").Find(&existing).Error; err != nil {
+		return err
+	}
+
+	byExternalID := map[string]*entity.Track{}
+	byURL := map[string]*entity.Track{}
+	for i := range existing {
+		track := &existing[i]
+		if track.ExternalID != "" {
+			byExternalID[track.ExternalID] = track
+		} else if _, ok := byURL[track.URL]; !ok {
+			byURL[track.URL] = track
+		}
+	}
+
+	matched := map[uint]bool{}
+	for _, desired := range tracks {
+		label := fmt.Sprintf("%s #%d %s", audiobookTitle, desired.Position, desired.Title)
+
+		current, ok := byExternalID[desired.ExternalID]
+		if !ok {
+			current, ok = byURL[desired.URL]
+			if ok && matched[current.ID] {
+				ok = false
+			}
+		}
+		if !ok {
+			desired.AudiobookID = audiobookID
+			desired.DeletedAt = deletedAt
+			if err := imp.tx.Create(&desired).Error; err != nil {
+				return err
+			}
+			imp.report.Tracks.created(label)
+			continue
+		}
+		matched[current.ID] = true
+
+		updates := map[string]interface{}{}
+		var fields []string
+		compare := func(column string, from, to interface{}) {
+			if change := fieldChange(column, from, to); change != "" {
+				updates[column] = to
+				fields = append(fields, change)
+			}
+		}
+		compare("external_id", current.ExternalID, desired.ExternalID)
+		compare("position", current.Position, desired.Position)
+		compare("title", current.Title, desired.Title)
+		compare("url", current.URL, desired.URL)
+		compare("duration", current.Duration, desired.Duration)
+		compare("duration_seconds", current.DurationSeconds, desired.DurationSeconds)
+
+		if len(updates) > 0 {
+			updates["version"] = gorm.Expr("version + 1")
+			if err := imp.tx.Unscoped().Model(&entity.Track{}).Where("id = ?", current.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		imp.report.Tracks.updated(label, fields)
+	}
+
+	for _, track := range existing {
+		if matched[track.ID] || track.DeletedAt.Valid {
+			continue
+		}
+		if err := imp.tx.Delete(&entity.Track{}, track.ID).Error; err != nil {
+			return err
+		}
+		imp.report.Tracks.deleted(fmt.Sprintf("%s #%d %s", audiobookTitle, track.Position, track.Title))
+	}
+
+	return nil
+}
+
+// genreNames lists genre names for a report
+func genreNames(genres []entity.Genre) string {
+	names := make([]string, len(genres))
+	for i, genre := range genres {
+		names[i] = genre.Name
+	}
+	return strings.Join(names, ", ")
+}
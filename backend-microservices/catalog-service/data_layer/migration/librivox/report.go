@@ -0,0 +1,105 @@
+package librivox
+
+import (
+	"fmt"
+	"io"
+)
+
+// Kinds of change recorded in a report
+const (
+	ChangeCreated = "+"
+	ChangeUpdated = "~"
+	ChangeDeleted = "-"
+)
+
+// Change is one row an import creates, updates or deletes; Fields lists what an update changes
+type Change struct {
+	Kind   string
+	Label  string
+	Fields []string
+}
+
+// Section summarizes the changes of an import to one table
+type Section struct {
+	Name      string
+	Created   int
+	Updated   int
+	Deleted   int
+	Unchanged int
+	Changes   []Change
+}
+
+// Report describes what an import changed, or would change when it is a dry run
+type Report struct {
+	DryRun     bool
+	Authors    Section
+	Readers    Section
+	Genres     Section
+	Audiobooks Section
+	Tracks     Section
+}
+
+func newReport(dryRun bool) *Report {
+	return &Report{
+		DryRun:     dryRun,
+		Authors:    Section{Name: "authors"},
+		Readers:    Section{Name: "readers"},
+		Genres:     Section{Name: "genres"},
+		Audiobooks: Section{Name: "audiobooks"},
+		Tracks:     Section{Name: "tracks"},
+	}
+}
+
+func (s *Section) created(label string) {
+	s.Created++
+	s.Changes = append(s.Changes, Change{Kind: ChangeCreated, Label: label})
+}
+
+// updated records an update when fields is not empty and an unchanged row otherwise
+func (s *Section) updated(label string, fields []string) {
+	if len(fields) == 0 {
+		s.Unchanged++
+		return
+	}
+	s.Updated++
+	s.Changes = append(s.Changes, Change{Kind: ChangeUpdated, Label: label, Fields: fields})
+}
+
+func (s *Section) deleted(label string) {
+	s.Deleted++
+	s.Changes = append(s.Changes, Change{Kind: ChangeDeleted, Label: label})
+}
+
+// Print writes the report as a diff: a summary line per table followed by its changes
+func (r *Report) Print(w io.Writer) {
+	if r.DryRun {
+		fmt.Fprintln(w, "Dry run: no changes were written")
+	}
+
+	for _, section := range []*Section{&r.Authors, &r.Readers, &r.Genres, &r.Audiobooks, &r.Tracks} {
+		fmt.Fprintf(w, "%-12s %d created, %d updated, %d deleted, %d unchanged\n",
+			section.Name+":", section.Created, section.Updated, section.Deleted, section.Unchanged)
+		for _, change := range section.Changes {
+			fmt.Fprintf(w, "  %s %s\n", change.Kind, change.Label)
+			for _, field := range change.Fields {
+				fmt.Fprintf(w, "      %s\n", field)
+			}
+		}
+	}
+}
+
+// fieldChange describes a changed field for a report, or returns "" when it did not change
+func fieldChange(name string, from, to interface{}) string {
+	if from == to {
+		return ""
+	}
+	return fmt.Sprintf("%s: %q -> %q", name, fmt.Sprint(from), fmt.Sprint(to))
+}
+
+// appendChange appends a field change unless it is empty
+func appendChange(fields []string, change string) []string {
+	if change == "" {
+		return fields
+	}
+	return append(fields, change)
+}
@@ -2,7 +2,9 @@ package migration
 
 import (
 	"catalog-service/data_layer/entity"
+	"catalog-service/data_layer/migration/librivox"
 	"catalog-service/data_layer/migration/seed"
+	"catalog-service/data_layer/repository"
 	"log"
 
 	"gorm.io/gorm"
@@ -20,6 +22,16 @@ func AutoMigrate(db *gorm.DB) error {
 		&entity.Audiobook{},
 		&entity.Track{},
 		&entity.Analytics{},
+		&entity.Bookmark{},
+		&entity.PlaySession{},
+		&entity.ListeningLedger{},
+		&entity.QueueEntry{},
+		&entity.FeedToken{},
+		&entity.AudiobookAuthor{},
+		&entity.AudiobookReader{},
+		&entity.Series{},
+		&entity.SeriesVolume{},
+		&entity.ReplicationCursor{},
 	)
 
 	if err != nil {
@@ -27,6 +39,36 @@ func AutoMigrate(db *gorm.DB) error {
 		return err
 	}
 
+	if err := BackfillDurations(db); err != nil {
+		log.Printf("Duration backfill failed: %v", err)
+		return err
+	}
+
+	if err := BackfillTrackPositions(db); err != nil {
+		log.Printf("Track position backfill failed: %v", err)
+		return err
+	}
+
+	if err := EnsureTrackPositions(db); err != nil {
+		log.Printf("Track position migration failed: %v", err)
+		return err
+	}
+
+	if err := BackfillCredits(db); err != nil {
+		log.Printf("Credit backfill failed: %v", err)
+		return err
+	}
+
+	if err := EnsureQueuePositions(db); err != nil {
+		log.Printf("Queue position migration failed: %v", err)
+		return err
+	}
+
+	if err := EnsureSearchIndex(db); err != nil {
+		log.Printf("Search index migration failed: %v", err)
+		return err
+	}
+
 	log.Println("Database migration completed successfully")
 	return nil
 }
@@ -44,7 +86,20 @@ func AutoMigrateAndSeed(db *gorm.DB) error {
 		return err
 	}
 
-	return nil
+	// Seeded tracks and audiobooks carry only duration text and no credits
+	if err := BackfillDurations(db); err != nil {
+		return err
+	}
+	if err := BackfillTrackPositions(db); err != nil {
+		return err
+	}
+	if err := BackfillCredits(db); err != nil {
+		return err
+	}
+
+	// Seeded audiobooks are inserted after the search index was built
+	_, err := repository.NewSearchIndexRepository(db).RefreshMissing()
+	return err
 }
 
 // SeedDatabase runs only the seeders without migration
@@ -57,6 +112,16 @@ func SeedSpecific(db *gorm.DB, seederName string) error {
 	return seed.RunSpecificSeeder(db, seederName)
 }
 
+// ImportLibriVox upserts the LibriVox export in dir (authors.json, readers.json, genres.json and
+// audiobooks.json). A dry run writes nothing and only reports what would change.
+func ImportLibriVox(db *gorm.DB, dir string, dryRun bool) (*librivox.Report, error) {
+	dataset, err := librivox.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	return librivox.Import(db, dataset, dryRun)
+}
+
 // ClearSeededData clears all seeded data
 func ClearSeededData(db *gorm.DB) error {
 	return seed.ClearAllData(db)
@@ -0,0 +1,46 @@
+package migration
+
+import (
+	"catalog-service/data_layer/entity"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// queuePositionConstraint keeps the positions of a user's queue entries unique. It is checked at
+// commit, so a transaction can shift the positions of a queue one entry at a time.
+const queuePositionConstraint = "uq_queue_entries_user_position"
+
+// EnsureQueuePositions adds the unique (user_id, position) constraint of queue entries, first
+// numbering the queue of every user 0..n-1 in its current order so duplicates left by earlier
+// concurrent writes do not block it. Does nothing once the constraint exists.
+func EnsureQueuePositions(db *gorm.DB) error {
+	if db.Migrator().HasConstraint(&entity.QueueEntry{}, queuePositionConstraint) {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`
+			UPDATE queue_entries SET position = ordered.position
+			FROM (
+				SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY position, id) - 1 AS position
+				FROM queue_entries
+			) AS ordered
+			WHERE queue_entries.id = ordered.id AND queue_entries.position <> ordered.position`)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			log.Printf("Renumbered positions of %d queue entries", result.RowsAffected)
+		}
+
+		err := tx.Exec(`ALTER TABLE queue_entries ADD CONSTRAINT ` + queuePositionConstraint +
+			` UNIQUE (user_id, position) DEFERRABLE INITIALLY DEFERRED`).Error
+		if err != nil {
+			return err
+		}
+
+		// The constraint's index replaces the plain one queue entries were created with
+		return tx.Exec(`DROP INDEX IF EXISTS idx_queue_entries_user_position`).Error
+	})
+}
@@ -0,0 +1,30 @@
+package migration
+
+import (
+	"catalog-service/data_layer/repository"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// EnsureSearchIndex adds the audiobook search document column and its GIN index, then builds
+// the documents of audiobooks that have none. The column is managed here rather than on the
+// entity so that saving an audiobook never overwrites its search document.
+func EnsureSearchIndex(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE audiobooks ADD COLUMN IF NOT EXISTS search_vector tsvector`).Error; err != nil {
+		return err
+	}
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_audiobooks_search ON audiobooks USING GIN (search_vector)`).Error; err != nil {
+		return err
+	}
+
+	indexed, err := repository.NewSearchIndexRepository(db).RefreshMissing()
+	if err != nil {
+		return err
+	}
+
+	if indexed > 0 {
+		log.Printf("Built search documents for %d audiobooks", indexed)
+	}
+	return nil
+}
@@ -72,6 +72,10 @@ func ClearAllData(db *gorm.DB) error {
 		"analytics",
 		"tracks",
 		"audiobook_genres",
+		"audiobook_authors",
+		"audiobook_readers",
+		"series_volumes",
+		"series",
 		"audiobooks",
 		"users",
 		"readers",
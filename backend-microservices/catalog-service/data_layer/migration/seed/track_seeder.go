@@ -87,9 +87,10 @@ func TrackSeeder(db *gorm.DB) error {
 		// Try to find matching tracks in JSON data
 		if tracksData, exists := jsonDataMap[audiobook.Title]; exists {
 			// Use actual track data from JSON
-			for _, trackData := range tracksData {
+			for i, trackData := range tracksData {
 				track := entity.Track{
 					AudiobookID: audiobook.ID,
+					Position:    i + 1,
 					Title:       trackData.Title,
 					URL:         trackData.URL,
 					Duration:    trackData.Duration,
@@ -153,6 +154,7 @@ func generateFallbackTracks(audiobook entity.Audiobook) []entity.Track {
 	for i := 1; i <= numTracks; i++ {
 		track := entity.Track{
 			AudiobookID: audiobook.ID,
+			Position:    i,
 			Title:       fmt.Sprintf("Chapter %d", i),
 			URL:         fmt.Sprintf("https://example.com/audio/audiobook_%d/track_%02d.mp3", audiobook.ID, i),
 			Duration:    "00:15:00", // Default 15 minutes
@@ -0,0 +1,49 @@
+package migration
+
+import (
+	"catalog-service/data_layer/entity"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// BackfillTrackPositions numbers the tracks of every audiobook 1..n, keeping their current order
+// and falling back to ID order for tracks created without a position. Tracks in the trash keep the
+// position a restore puts them back at.
+// Already gapless audiobooks are left untouched, so it is safe to run on every start.
+func BackfillTrackPositions(db *gorm.DB) error {
+	result := db.Exec(`
+		UPDATE tracks SET position = ordered.position
+		FROM (
+			SELECT id, ROW_NUMBER() OVER (PARTITION BY audiobook_id ORDER BY position, id) AS position
+			FROM tracks
+			WHERE deleted_at IS NULL
+		) AS ordered
+		WHERE tracks.id = ordered.id AND tracks.position <> ordered.position`)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		log.Printf("Renumbered positions of %d tracks", result.RowsAffected)
+	}
+	return nil
+}
+
+// trackPositionConstraint keeps the positions of the tracks of an audiobook unique. It is an
+// exclusion constraint rather than a unique one so it can leave out tracks in the trash and still be
+// checked at commit, which lets a transaction shift a play order one track at a time.
+const trackPositionConstraint = "ex_tracks_audiobook_position"
+
+// EnsureTrackPositions adds the constraint keeping the positions of the tracks of an audiobook that
+// are not in the trash unique. It runs after BackfillTrackPositions, which numbers them gapless, and
+// does nothing once the constraint exists.
+func EnsureTrackPositions(db *gorm.DB) error {
+	if db.Migrator().HasConstraint(&entity.Track{}, trackPositionConstraint) {
+		return nil
+	}
+
+	return db.Exec(`ALTER TABLE tracks ADD CONSTRAINT ` + trackPositionConstraint +
+		` EXCLUDE USING btree (audiobook_id WITH =, position WITH =) WHERE (deleted_at IS NULL)` +
+		` DEFERRABLE INITIALLY DEFERRED`).Error
+}
@@ -7,16 +7,26 @@ import (
 	"gorm.io/gorm"
 )
 
+// analyticsSort lists the sort keys of analytics lists; newest events come first by default
+var analyticsSort = listSort{
+	table:      "analytics",
+	defaultKey: "-created_at",
+	keys: map[string][]sortColumn{
+		"id":         nil,
+		"created_at": {{expression: "analytics.event_timestamp", kind: sortTimestamp}},
+	},
+}
+
 // AnalyticsRepositoryInterface defines the contract for analytics repository
 type AnalyticsRepositoryInterface interface {
 	Create(analytics *entity.Analytics) error
 	GetByID(id uint) (*entity.Analytics, error)
-	GetAll(offset, limit int) ([]entity.Analytics, int64, error)
+	GetAll(page ListPage) ([]entity.Analytics, PageResult, error)
 	Delete(id uint) error
-	GetByUserID(userID string, offset, limit int) ([]entity.Analytics, int64, error)
-	GetByAudiobookID(audiobookID uint, offset, limit int) ([]entity.Analytics, int64, error)
-	GetByEventType(eventType string, offset, limit int) ([]entity.Analytics, int64, error)
-	GetByDateRange(startDate, endDate time.Time, offset, limit int) ([]entity.Analytics, int64, error)
+	GetByUserID(userID string, page ListPage) ([]entity.Analytics, PageResult, error)
+	GetByAudiobookID(audiobookID uint, page ListPage) ([]entity.Analytics, PageResult, error)
+	GetByEventType(eventType string, page ListPage) ([]entity.Analytics, PageResult, error)
+	GetByDateRange(startDate, endDate time.Time, page ListPage) ([]entity.Analytics, PageResult, error)
 	GetAnalyticsSummary(audiobookID uint) (map[string]int64, error)
 	DeleteByUserID(userID string) error
 	DeleteByAudiobookID(audiobookID uint) error
@@ -47,22 +57,9 @@ func (r *AnalyticsRepository) GetByID(id uint) (*entity.Analytics, error) {
 	return &analytics, nil
 }
 
-// GetAll retrieves all analytics records with pagination
-func (r *AnalyticsRepository) GetAll(offset, limit int) ([]entity.Analytics, int64, error) {
-	var analytics []entity.Analytics
-	var total int64
-
-	// Count total records
-	if err := r.db.Model(&entity.Analytics{}).Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// Get paginated results
-	if err := r.db.Preload("Audiobook").Preload("User").Offset(offset).Limit(limit).Find(&analytics).Error; err != nil {
-		return nil, 0, err
-	}
-
-	return analytics, total, nil
+// GetAll retrieves one page of all analytics records
+func (r *AnalyticsRepository) GetAll(page ListPage) ([]entity.Analytics, PageResult, error) {
+	return r.getPage(r.db.Model(&entity.Analytics{}), page)
 }
 
 // Delete deletes an analytics record by ID
@@ -70,86 +67,54 @@ func (r *AnalyticsRepository) Delete(id uint) error {
 	return r.db.Delete(&entity.Analytics{}, id).Error
 }
 
-// GetByUserID retrieves analytics records by user ID
-func (r *AnalyticsRepository) GetByUserID(userID string, offset, limit int) ([]entity.Analytics, int64, error) {
-	var analytics []entity.Analytics
-	var total int64
-
-	dbQuery := r.db.Model(&entity.Analytics{}).Where("user_id = ?", userID).Preload("Audiobook").Preload("User")
-
-	// Count total records
-	if err := dbQuery.Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// Get paginated results
-	if err := dbQuery.Offset(offset).Limit(limit).Find(&analytics).Error; err != nil {
-		return nil, 0, err
-	}
-
-	return analytics, total, nil
+// GetByUserID retrieves one page of the analytics records of a user
+func (r *AnalyticsRepository) GetByUserID(userID string, page ListPage) ([]entity.Analytics, PageResult, error) {
+	return r.getPage(r.db.Model(&entity.Analytics{}).Where("analytics.user_id = ?", userID), page)
 }
 
-// GetByAudiobookID retrieves analytics records by audiobook ID
-func (r *AnalyticsRepository) GetByAudiobookID(audiobookID uint, offset, limit int) ([]entity.Analytics, int64, error) {
-	var analytics []entity.Analytics
-	var total int64
-
-	dbQuery := r.db.Model(&entity.Analytics{}).Where("audiobook_id = ?", audiobookID).Preload("Audiobook").Preload("User")
-
-	// Count total records
-	if err := dbQuery.Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
+// GetByAudiobookID retrieves one page of the analytics records of an audiobook
+func (r *AnalyticsRepository) GetByAudiobookID(audiobookID uint, page ListPage) ([]entity.Analytics, PageResult, error) {
+	return r.getPage(r.db.Model(&entity.Analytics{}).Where("analytics.audiobook_id = ?", audiobookID), page)
+}
 
-	// Get paginated results
-	if err := dbQuery.Offset(offset).Limit(limit).Find(&analytics).Error; err != nil {
-		return nil, 0, err
-	}
+// GetByEventType retrieves one page of the analytics records of an event type
+func (r *AnalyticsRepository) GetByEventType(eventType string, page ListPage) ([]entity.Analytics, PageResult, error) {
+	return r.getPage(r.db.Model(&entity.Analytics{}).Where("analytics.event_type = ?", eventType), page)
+}
 
-	return analytics, total, nil
+// GetByDateRange retrieves one page of the analytics records within a date range
+func (r *AnalyticsRepository) GetByDateRange(startDate, endDate time.Time, page ListPage) ([]entity.Analytics, PageResult, error) {
+	return r.getPage(r.db.Model(&entity.Analytics{}).Where("analytics.event_timestamp BETWEEN ? AND ?", startDate, endDate), page)
 }
 
-// GetByEventType retrieves analytics records by event type
-func (r *AnalyticsRepository) GetByEventType(eventType string, offset, limit int) ([]entity.Analytics, int64, error) {
+// getPage counts the analytics records matched by query and fetches the requested page of them with relations
+func (r *AnalyticsRepository) getPage(query *gorm.DB, page ListPage) ([]entity.Analytics, PageResult, error) {
 	var analytics []entity.Analytics
-	var total int64
+	var result PageResult
 
-	dbQuery := r.db.Model(&entity.Analytics{}).Where("event_type = ?", eventType).Preload("Audiobook").Preload("User")
-
-	// Count total records
-	if err := dbQuery.Count(&total).Error; err != nil {
-		return nil, 0, err
+	order, err := analyticsSort.resolve(page)
+	if err != nil {
+		return nil, result, err
 	}
 
-	// Get paginated results
-	if err := dbQuery.Offset(offset).Limit(limit).Find(&analytics).Error; err != nil {
-		return nil, 0, err
+	// Count total records
+	if err := query.Count(&result.Total).Error; err != nil {
+		return nil, result, err
 	}
 
-	return analytics, total, nil
-}
-
-// GetByDateRange retrieves analytics records within a date range
-func (r *AnalyticsRepository) GetByDateRange(startDate, endDate time.Time, offset, limit int) ([]entity.Analytics, int64, error) {
-	var analytics []entity.Analytics
-	var total int64
-
-	dbQuery := r.db.Model(&entity.Analytics{}).
-		Where("event_timestamp BETWEEN ? AND ?", startDate, endDate).
-		Preload("Audiobook").Preload("User")
-
-	// Count total records
-	if err := dbQuery.Count(&total).Error; err != nil {
-		return nil, 0, err
+	// Get the page with relations
+	if err := order.apply(query).Preload("Audiobook").Preload("User").Find(&analytics).Error; err != nil {
+		return nil, result, err
 	}
 
-	// Get paginated results
-	if err := dbQuery.Offset(offset).Limit(limit).Find(&analytics).Error; err != nil {
-		return nil, 0, err
+	if order.more(len(analytics)) {
+		analytics = analytics[:page.Limit]
+		if result.NextCursor, err = order.cursorAfter(r.db, analytics[len(analytics)-1].ID); err != nil {
+			return nil, result, err
+		}
 	}
 
-	return analytics, total, nil
+	return analytics, result, nil
 }
 
 // GetAnalyticsSummary retrieves analytics summary for an audiobook
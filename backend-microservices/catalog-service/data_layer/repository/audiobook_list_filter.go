@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// Genre match modes of an audiobook list filter
+const (
+	GenreMatchAny = "any"
+	GenreMatchAll = "all"
+)
+
+// AudiobookListFilter narrows an audiobook list; every criterion set is combined with AND and
+// zero fields are not applied. AuthorID and ReaderID match any credit, not only the primary one. GenreMatch decides whether an audiobook needs any or all of
+// GenreIDs (any by default), and the year range is inclusive on both ends.
+type AudiobookListFilter struct {
+	AuthorID   uint
+	ReaderID   uint
+	GenreIDs   []uint
+	GenreMatch string
+	Language   string
+	YearFrom   int
+	YearTo     int
+	HasTracks  *bool
+	Duration   DurationFilter
+}
+
+// apply adds every criterion of the filter to a query on audiobooks. Genres and tracks are
+// matched through subqueries so that an audiobook is never returned twice.
+func (f AudiobookListFilter) apply(query *gorm.DB) *gorm.DB {
+	if f.AuthorID > 0 {
+		query = query.Where("audiobooks.id IN (SELECT audiobook_id FROM audiobook_authors WHERE author_id = ?)", f.AuthorID)
+	}
+	if f.ReaderID > 0 {
+		query = query.Where("audiobooks.id IN (SELECT audiobook_id FROM audiobook_readers WHERE reader_id = ?)", f.ReaderID)
+	}
+	if genreIDs := uniqueIDs(f.GenreIDs); len(genreIDs) > 0 {
+		if f.GenreMatch == GenreMatchAll {
+			query = query.Where(`audiobooks.id IN (
+				SELECT audiobook_id FROM audiobook_genres WHERE genre_id IN ?
+				GROUP BY audiobook_id HAVING COUNT(DISTINCT genre_id) = ?)`, genreIDs, len(genreIDs))
+		} else {
+			query = query.Where("audiobooks.id IN (SELECT audiobook_id FROM audiobook_genres WHERE genre_id IN ?)", genreIDs)
+		}
+	}
+	if f.Language != "" {
+		query = query.Where("LOWER(audiobooks.language) = LOWER(?)", f.Language)
+	}
+	if f.YearFrom > 0 {
+		query = query.Where("audiobooks.year_of_publishing >= ?", f.YearFrom)
+	}
+	if f.YearTo > 0 {
+		query = query.Where("audiobooks.year_of_publishing <= ?", f.YearTo)
+	}
+	if f.HasTracks != nil {
+		if *f.HasTracks {
+			query = query.Where("EXISTS (SELECT 1 FROM tracks WHERE tracks.audiobook_id = audiobooks.id AND tracks.deleted_at IS NULL)")
+		} else {
+			query = query.Where("NOT EXISTS (SELECT 1 FROM tracks WHERE tracks.audiobook_id = audiobooks.id AND tracks.deleted_at IS NULL)")
+		}
+	}
+	return f.Duration.apply(query, "audiobooks.total_duration_seconds")
+}
+
+// uniqueIDs returns ids without duplicates, keeping their order
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// GenreFacet is the number of listed audiobooks in a genre
+type GenreFacet struct {
+	GenreID uint
+	Name    string
+	Count   int64
+}
+
+// LanguageFacet is the number of listed audiobooks in a language
+type LanguageFacet struct {
+	Language string
+	Count    int64
+}
+
+// DecadeFacet is the number of listed audiobooks published in a decade, e.g. 1890 for 1890-1899
+type DecadeFacet struct {
+	Decade int
+	Count  int64
+}
+
+// AudiobookFacets counts the audiobooks matching a filter by genre, language and decade
+type AudiobookFacets struct {
+	Genres    []GenreFacet
+	Languages []LanguageFacet
+	Decades   []DecadeFacet
+}
@@ -2,10 +2,58 @@ package repository
 
 import (
 	"catalog-service/data_layer/entity"
+	"html"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// AudiobookSearchHit is an audiobook matched by a full-text search, with its relevance and its
+// title and description as HTML, escaped, with the matched words wrapped in <mark> tags
+type AudiobookSearchHit struct {
+	Audiobook            entity.Audiobook
+	Rank                 float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
+
+// ts_headline marks matches with these control characters rather than <mark> tags, so the text can
+// be HTML-escaped before they are turned into tags; they are removed from the text beforehand
+const (
+	highlightStart = "\x01"
+	highlightStop  = "\x02"
+)
+
+// highlightReplacer turns the match markers of an escaped headline into <mark> tags
+var highlightReplacer = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// searchQuerySQL parses a web-style search (quoted phrases, "or", -exclusions) once with the
+// text search configuration named after the language and once unstemmed, matching either
+const searchQuerySQL = `(
+	SELECT websearch_to_tsquery(cfg.name, @query) || websearch_to_tsquery('simple', @query) AS query, cfg.name AS config
+	FROM (
+		SELECT COALESCE(
+			(SELECT cfgname::text::regconfig FROM pg_ts_config WHERE cfgname = lower(@language) LIMIT 1),
+			'simple'::regconfig
+		) AS name
+	) cfg
+) q`
+
+// audiobookSort lists the sort keys of audiobook lists; popularity is the number of plays started
+var audiobookSort = listSort{
+	table:      "audiobooks",
+	defaultKey: "id",
+	keys: map[string][]sortColumn{
+		"id":         nil,
+		"title":      {{expression: "audiobooks.title", kind: sortText}},
+		"year":       {{expression: "audiobooks.year_of_publishing", kind: sortInteger}},
+		"created_at": {{expression: "audiobooks.created_at", kind: sortTimestamp}},
+		"duration":   {{expression: "audiobooks.total_duration_seconds", kind: sortInteger}},
+		"popularity": {{expression: "(SELECT COUNT(*) FROM analytics WHERE analytics.audiobook_id = audiobooks.id AND analytics.event_type = 'PLAY_START')", kind: sortInteger}},
+	},
+}
+
 // AudiobookRepositoryInterface defines the contract for audiobook repository
 type AudiobookRepositoryInterface interface {
 	Create(audiobook *entity.Audiobook) error
@@ -14,14 +62,20 @@ type AudiobookRepositoryInterface interface {
 	GetAll(offset, limit int) ([]entity.Audiobook, int64, error)
 	GetAllWithRelations(offset, limit int) ([]entity.Audiobook, int64, error)
 	Update(audiobook *entity.Audiobook) error
-	Delete(id uint) error
-	SearchByTitle(query string, offset, limit int) ([]entity.Audiobook, int64, error)
+	Delete(id, version uint) error
+	Search(query, language string, offset, limit int) ([]AudiobookSearchHit, int64, error)
 	GetByAuthorID(authorID uint, offset, limit int) ([]entity.Audiobook, int64, error)
 	GetByReaderID(readerID uint, offset, limit int) ([]entity.Audiobook, int64, error)
 	GetByGenreID(genreID uint, offset, limit int) ([]entity.Audiobook, int64, error)
-	AssignGenres(audiobookID uint, genreIDs []uint) error
-	RemoveGenres(audiobookID uint, genreIDs []uint) error
-	RemoveAllGenres(audiobookID uint) error
+	GetFiltered(filter AudiobookListFilter, page ListPage) ([]entity.Audiobook, PageResult, error)
+	GetFacets(filter AudiobookListFilter) (*AudiobookFacets, error)
+	ForEachBatch(filter AudiobookListFilter, batchSize int, fn func(audiobooks []entity.Audiobook) error) error
+	UpdateTotalDuration(id uint, seconds int, formatted string) error
+	AssignGenres(audiobookID, version uint, genreIDs []uint) error
+	RemoveGenres(audiobookID, version uint, genreIDs []uint) error
+	ReplaceGenres(audiobookID uint, genreIDs []uint) error
+	SetCredits(audiobookID, version uint, authors []entity.AudiobookAuthor, readers []entity.AudiobookReader) error
+	SyncPrimaryCredits(audiobookID, authorID, readerID uint) error
 }
 
 // AudiobookRepository implements AudiobookRepositoryInterface
@@ -52,7 +106,13 @@ func (r *AudiobookRepository) GetByID(id uint) (*entity.Audiobook, error) {
 // GetByIDWithRelations retrieves an audiobook by ID with all relations
 func (r *AudiobookRepository) GetByIDWithRelations(id uint) (*entity.Audiobook, error) {
 	var audiobook entity.Audiobook
-	err := r.db.Preload("Author").Preload("Reader").Preload("Genres").Preload("Tracks").First(&audiobook, id).Error
+	err := r.db.Preload("Author").Preload("Reader").Preload("Genres").Preload("Tracks", func(db *gorm.DB) *gorm.DB {
+		return db.Order("tracks.position ASC").Order("tracks.id ASC")
+	}).Preload("Authors", func(db *gorm.DB) *gorm.DB {
+		return db.Order("audiobook_authors.position ASC")
+	}).Preload("Authors.Author").Preload("Readers", func(db *gorm.DB) *gorm.DB {
+		return db.Order("audiobook_readers.position ASC")
+	}).Preload("Readers.Reader").Preload("SeriesVolume.Series").First(&audiobook, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +130,7 @@ func (r *AudiobookRepository) GetAll(offset, limit int) ([]entity.Audiobook, int
 	}
 
 	// Get paginated results
-	if err := r.db.Offset(offset).Limit(limit).Find(&audiobooks).Error; err != nil {
+	if err := r.db.Order("audiobooks.id ASC").Offset(offset).Limit(limit).Find(&audiobooks).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -88,52 +148,131 @@ func (r *AudiobookRepository) GetAllWithRelations(offset, limit int) ([]entity.A
 	}
 
 	// Get paginated results with relations
-	if err := r.db.Preload("Author").Preload("Reader").Preload("Genres").Offset(offset).Limit(limit).Find(&audiobooks).Error; err != nil {
+	if err := r.db.Preload("Author").Preload("Reader").Preload("Genres").Order("audiobooks.id ASC").Offset(offset).Limit(limit).Find(&audiobooks).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return audiobooks, total, nil
 }
 
-// Update updates an existing audiobook
+// Update updates an existing audiobook if it is still at its version, bumping the version.
+// Associations are left alone.
 func (r *AudiobookRepository) Update(audiobook *entity.Audiobook) error {
-	return r.db.Save(audiobook).Error
+	return updateVersioned(r.db, audiobook, &audiobook.Version)
 }
 
-// Delete deletes an audiobook by ID
-func (r *AudiobookRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Audiobook{}, id).Error
+// Delete moves an audiobook at version and its tracks to the trash; version 0 deletes any version.
+// The tracks get the audiobook's deletion time, which is how restoring the audiobook tells them
+// from tracks trashed on their own before. Genres, credits, the series volume and analytics are
+// kept until the audiobook is purged.
+func (r *AudiobookRepository) Delete(id, version uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		deletedAt := time.Now()
+
+		query := tx.Model(&entity.Audiobook{}).Where("id = ?", id)
+		if version != 0 {
+			query = query.Where("version = ?", version)
+		}
+		result := query.Update("deleted_at", deletedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 && version != 0 {
+			return ErrVersionConflict
+		}
+
+		return tx.Model(&entity.Track{}).Where("audiobook_id = ?", id).Update("deleted_at", deletedAt).Error
+	})
 }
 
-// SearchByTitle searches audiobooks by title
-func (r *AudiobookRepository) SearchByTitle(query string, offset, limit int) ([]entity.Audiobook, int64, error) {
-	var audiobooks []entity.Audiobook
-	var total int64
-
-	dbQuery := r.db.Model(&entity.Audiobook{}).Preload("Author").Preload("Reader").Preload("Genres")
-	if query != "" {
-		dbQuery = dbQuery.Where("title LIKE ?", "%"+query+"%")
+// Search finds audiobooks whose title, description, author, reader or genres match a query,
+// most relevant first, with pagination
+func (r *AudiobookRepository) Search(query, language string, offset, limit int) ([]AudiobookSearchHit, int64, error) {
+	args := map[string]interface{}{
+		"query":               query,
+		"language":            language,
+		"offset":              offset,
+		"limit":               limit,
+		"title_options":       `HighlightAll=true, StartSel="` + highlightStart + `", StopSel="` + highlightStop + `"`,
+		"description_options": `StartSel="` + highlightStart + `", StopSel="` + highlightStop + `", MaxFragments=2, MinWords=10, MaxWords=30`,
+		"markers":             highlightStart + highlightStop,
 	}
 
 	// Count total records
-	if err := dbQuery.Count(&total).Error; err != nil {
+	var total int64
+	err := r.db.Raw(`SELECT COUNT(*) FROM audiobooks a, `+searchQuerySQL+` WHERE a.search_vector @@ q.query AND a.deleted_at IS NULL`, args).
+		Scan(&total).Error
+	if err != nil {
 		return nil, 0, err
 	}
 
-	// Get paginated results
-	if err := dbQuery.Offset(offset).Limit(limit).Find(&audiobooks).Error; err != nil {
+	// Rank and paginate first so only the returned page is highlighted
+	var rows []struct {
+		ID                   uint
+		Rank                 float64
+		TitleHighlight       string
+		DescriptionHighlight string
+	}
+	err = r.db.Raw(`
+		SELECT hit.id, hit.rank,
+			ts_headline(q.config, hit.title, q.query, @title_options) AS title_highlight,
+			ts_headline(q.config, hit.description, q.query, @description_options) AS description_highlight
+		FROM (
+			SELECT a.id, translate(a.title, @markers, '') AS title, translate(COALESCE(a.description, ''), @markers, '') AS description,
+				ts_rank_cd(a.search_vector, q.query) AS rank
+			FROM audiobooks a, `+searchQuerySQL+`
+			WHERE a.search_vector @@ q.query AND a.deleted_at IS NULL
+			ORDER BY rank DESC, a.id ASC
+			OFFSET @offset LIMIT @limit
+		) hit, `+searchQuerySQL+`
+		ORDER BY hit.rank DESC, hit.id ASC`, args).
+		Scan(&rows).Error
+	if err != nil {
 		return nil, 0, err
 	}
+	if len(rows) == 0 {
+		return []AudiobookSearchHit{}, total, nil
+	}
 
-	return audiobooks, total, nil
+	ids := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	var audiobooks []entity.Audiobook
+	if err := r.db.Preload("Author").Preload("Reader").Preload("Genres").Where("id IN ?", ids).Find(&audiobooks).Error; err != nil {
+		return nil, 0, err
+	}
+
+	byID := make(map[uint]entity.Audiobook, len(audiobooks))
+	for _, audiobook := range audiobooks {
+		byID[audiobook.ID] = audiobook
+	}
+
+	hits := make([]AudiobookSearchHit, 0, len(rows))
+	for _, row := range rows {
+		audiobook, ok := byID[row.ID]
+		if !ok {
+			// Deleted between the two queries
+			continue
+		}
+		hits = append(hits, AudiobookSearchHit{
+			Audiobook:            audiobook,
+			Rank:                 row.Rank,
+			TitleHighlight:       highlightReplacer.Replace(html.EscapeString(row.TitleHighlight)),
+			DescriptionHighlight: highlightReplacer.Replace(html.EscapeString(row.DescriptionHighlight)),
+		})
+	}
+
+	return hits, total, nil
 }
 
-// GetByAuthorID retrieves audiobooks by author ID
+// GetByAuthorID retrieves audiobooks crediting an author in any role
 func (r *AudiobookRepository) GetByAuthorID(authorID uint, offset, limit int) ([]entity.Audiobook, int64, error) {
 	var audiobooks []entity.Audiobook
 	var total int64
 
-	dbQuery := r.db.Model(&entity.Audiobook{}).Where("author_id = ?", authorID).Preload("Author").Preload("Reader").Preload("Genres")
+	dbQuery := r.db.Model(&entity.Audiobook{}).Where("audiobooks.id IN (SELECT audiobook_id FROM audiobook_authors WHERE author_id = ?)", authorID).Preload("Author").Preload("Reader").Preload("Genres")
 
 	// Count total records
 	if err := dbQuery.Count(&total).Error; err != nil {
@@ -141,19 +280,19 @@ func (r *AudiobookRepository) GetByAuthorID(authorID uint, offset, limit int) ([
 	}
 
 	// Get paginated results
-	if err := dbQuery.Offset(offset).Limit(limit).Find(&audiobooks).Error; err != nil {
+	if err := dbQuery.Order("audiobooks.id ASC").Offset(offset).Limit(limit).Find(&audiobooks).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return audiobooks, total, nil
 }
 
-// GetByReaderID retrieves audiobooks by reader ID
+// GetByReaderID retrieves audiobooks crediting a reader
 func (r *AudiobookRepository) GetByReaderID(readerID uint, offset, limit int) ([]entity.Audiobook, int64, error) {
 	var audiobooks []entity.Audiobook
 	var total int64
 
-	dbQuery := r.db.Model(&entity.Audiobook{}).Where("reader_id = ?", readerID).Preload("Author").Preload("Reader").Preload("Genres")
+	dbQuery := r.db.Model(&entity.Audiobook{}).Where("audiobooks.id IN (SELECT audiobook_id FROM audiobook_readers WHERE reader_id = ?)", readerID).Preload("Author").Preload("Reader").Preload("Genres")
 
 	// Count total records
 	if err := dbQuery.Count(&total).Error; err != nil {
@@ -161,7 +300,7 @@ func (r *AudiobookRepository) GetByReaderID(readerID uint, offset, limit int) ([
 	}
 
 	// Get paginated results
-	if err := dbQuery.Offset(offset).Limit(limit).Find(&audiobooks).Error; err != nil {
+	if err := dbQuery.Order("audiobooks.id ASC").Offset(offset).Limit(limit).Find(&audiobooks).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -184,50 +323,269 @@ func (r *AudiobookRepository) GetByGenreID(genreID uint, offset, limit int) ([]e
 	}
 
 	// Get paginated results
-	if err := dbQuery.Offset(offset).Limit(limit).Find(&audiobooks).Error; err != nil {
+	if err := dbQuery.Order("audiobooks.id ASC").Offset(offset).Limit(limit).Find(&audiobooks).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return audiobooks, total, nil
 }
 
-// AssignGenres assigns genres to an audiobook
-func (r *AudiobookRepository) AssignGenres(audiobookID uint, genreIDs []uint) error {
-	var audiobook entity.Audiobook
-	if err := r.db.First(&audiobook, audiobookID).Error; err != nil {
-		return err
+// GetFiltered retrieves one page of the audiobooks matching every criterion of the filter with relations
+func (r *AudiobookRepository) GetFiltered(filter AudiobookListFilter, page ListPage) ([]entity.Audiobook, PageResult, error) {
+	var audiobooks []entity.Audiobook
+	var result PageResult
+
+	order, err := audiobookSort.resolve(page)
+	if err != nil {
+		return nil, result, err
 	}
 
-	var genres []entity.Genre
-	if err := r.db.Where("id IN ?", genreIDs).Find(&genres).Error; err != nil {
-		return err
+	// Count total records
+	if err := filter.apply(r.db.Model(&entity.Audiobook{})).Count(&result.Total).Error; err != nil {
+		return nil, result, err
+	}
+
+	// Get the page with relations
+	err = order.apply(filter.apply(r.db.Model(&entity.Audiobook{}))).
+		Preload("Author").Preload("Reader").Preload("Genres").
+		Find(&audiobooks).Error
+	if err != nil {
+		return nil, result, err
+	}
+
+	if order.more(len(audiobooks)) {
+		audiobooks = audiobooks[:page.Limit]
+		if result.NextCursor, err = order.cursorAfter(r.db, audiobooks[len(audiobooks)-1].ID); err != nil {
+			return nil, result, err
+		}
 	}
 
-	return r.db.Model(&audiobook).Association("Genres").Append(genres)
+	return audiobooks, result, nil
 }
 
-// RemoveGenres removes genres from an audiobook
-func (r *AudiobookRepository) RemoveGenres(audiobookID uint, genreIDs []uint) error {
-	var audiobook entity.Audiobook
-	if err := r.db.First(&audiobook, audiobookID).Error; err != nil {
-		return err
+// GetFacets counts the audiobooks matching the filter per genre, language and decade of publishing.
+// Audiobooks without a language or year are left out of those counts.
+func (r *AudiobookRepository) GetFacets(filter AudiobookListFilter) (*AudiobookFacets, error) {
+	facets := &AudiobookFacets{}
+
+	matching := filter.apply(r.db.Model(&entity.Audiobook{})).Select("audiobooks.id")
+	err := r.db.Table("audiobook_genres").
+		Select("genres.id AS genre_id, genres.name AS name, COUNT(*) AS count").
+		Joins("JOIN genres ON genres.id = audiobook_genres.genre_id").
+		Where("audiobook_genres.audiobook_id IN (?) AND genres.deleted_at IS NULL", matching).
+		Group("genres.id, genres.name").
+		Order("count DESC").Order("genres.name ASC").
+		Scan(&facets.Genres).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = filter.apply(r.db.Model(&entity.Audiobook{})).
+		Select("audiobooks.language AS language, COUNT(*) AS count").
+		Where("audiobooks.language <> ''").
+		Group("audiobooks.language").
+		Order("count DESC").Order("audiobooks.language ASC").
+		Scan(&facets.Languages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = filter.apply(r.db.Model(&entity.Audiobook{})).
+		Select("(audiobooks.year_of_publishing / 10) * 10 AS decade, COUNT(*) AS count").
+		Where("audiobooks.year_of_publishing > 0").
+		Group("decade").
+		Order("decade ASC").
+		Scan(&facets.Decades).Error
+	if err != nil {
+		return nil, err
 	}
 
+	return facets, nil
+}
+
+// ForEachBatch calls fn with consecutive batches of the audiobooks matching the filter in ID order,
+// with author, reader, genres and tracks loaded, so that a whole catalog can be walked in bounded memory
+func (r *AudiobookRepository) ForEachBatch(filter AudiobookListFilter, batchSize int, fn func(audiobooks []entity.Audiobook) error) error {
+	var audiobooks []entity.Audiobook
+
+	return filter.apply(r.db.Model(&entity.Audiobook{})).
+		Preload("Author").Preload("Reader").
+		Preload("Genres", func(db *gorm.DB) *gorm.DB {
+			return db.Order("genres.name ASC")
+		}).
+		Preload("Tracks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("tracks.position ASC").Order("tracks.id ASC")
+		}).
+		FindInBatches(&audiobooks, batchSize, func(tx *gorm.DB, batch int) error {
+			return fn(audiobooks)
+		}).Error
+}
+
+// UpdateTotalDuration stores the total length of an audiobook in seconds and in display form, bumping
+// its version
+func (r *AudiobookRepository) UpdateTotalDuration(id uint, seconds int, formatted string) error {
+	return r.db.Model(&entity.Audiobook{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"total_duration_seconds": seconds,
+		"total_duration":         formatted,
+		"version":                gorm.Expr("version + 1"),
+	}).Error
+}
+
+// AssignGenres assigns genres to an audiobook at version, bumping the version; version 0 matches any
+// version
+func (r *AudiobookRepository) AssignGenres(audiobookID, version uint, genreIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var audiobook entity.Audiobook
+		if err := tx.First(&audiobook, audiobookID).Error; err != nil {
+			return err
+		}
+		if err := bumpVersion(tx, &entity.Audiobook{}, audiobookID, version); err != nil {
+			return err
+		}
+
+		var genres []entity.Genre
+		if err := tx.Where("id IN ?", genreIDs).Find(&genres).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&audiobook).Association("Genres").Append(genres)
+	})
+}
+
+// RemoveGenres removes genres from an audiobook at version, bumping the version; version 0 matches
+// any version
+func (r *AudiobookRepository) RemoveGenres(audiobookID, version uint, genreIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var audiobook entity.Audiobook
+		if err := tx.First(&audiobook, audiobookID).Error; err != nil {
+			return err
+		}
+		if err := bumpVersion(tx, &entity.Audiobook{}, audiobookID, version); err != nil {
+			return err
+		}
+
+		var genres []entity.Genre
+		if err := tx.Where("id IN ?", genreIDs).Find(&genres).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&audiobook).Association("Genres").Delete(genres)
+	})
+}
+
+// ReplaceGenres replaces every genre of an audiobook without bumping its version. It belongs in the
+// same transaction as the versioned write it is part of, so the audiobook moves one version per write.
+func (r *AudiobookRepository) ReplaceGenres(audiobookID uint, genreIDs []uint) error {
 	var genres []entity.Genre
-	if err := r.db.Where("id IN ?", genreIDs).Find(&genres).Error; err != nil {
-		return err
+	if len(genreIDs) > 0 {
+		if err := r.db.Where("id IN ?", genreIDs).Find(&genres).Error; err != nil {
+			return err
+		}
 	}
 
-	return r.db.Model(&audiobook).Association("Genres").Delete(genres)
+	association := r.db.Model(&entity.Audiobook{ID: audiobookID}).Association("Genres")
+	if len(genres) == 0 {
+		return association.Clear()
+	}
+	return association.Replace(genres)
 }
 
-// RemoveAllGenres removes all genres from an audiobook
-func (r *AudiobookRepository) RemoveAllGenres(audiobookID uint) error {
-    var audiobook entity.Audiobook
-    if err := r.db.First(&audiobook, audiobookID).Error; err != nil {
-        return err
-    }
+// SetCredits replaces every author and reader credit of an audiobook at version in one transaction,
+// bumping the version, and points AuthorID and ReaderID at the new primary author and narrator;
+// version 0 matches any version. Credits are saved in the given order.
+func (r *AudiobookRepository) SetCredits(audiobookID, version uint, authors []entity.AudiobookAuthor, readers []entity.AudiobookReader) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := bumpVersion(tx, &entity.Audiobook{}, audiobookID, version); err != nil {
+			return err
+		}
+		if err := tx.Where("audiobook_id = ?", audiobookID).Delete(&entity.AudiobookAuthor{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("audiobook_id = ?", audiobookID).Delete(&entity.AudiobookReader{}).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		for i := range authors {
+			authors[i].AudiobookID = audiobookID
+			authors[i].Position = i + 1
+			if _, found := updates["author_id"]; !found && authors[i].Role == entity.RoleAuthor {
+				updates["author_id"] = authors[i].AuthorID
+			}
+		}
+		for i := range readers {
+			readers[i].AudiobookID = audiobookID
+			readers[i].Position = i + 1
+			if _, found := updates["reader_id"]; !found && readers[i].Role == entity.RoleNarrator {
+				updates["reader_id"] = readers[i].ReaderID
+			}
+		}
+
+		if len(authors) > 0 {
+			if err := tx.Omit("Author").Create(&authors).Error; err != nil {
+				return err
+			}
+		}
+		if len(readers) > 0 {
+			if err := tx.Omit("Reader").Create(&readers).Error; err != nil {
+				return err
+			}
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+		return tx.Model(&entity.Audiobook{}).Where("id = ?", audiobookID).Updates(updates).Error
+	})
+}
 
-    // Clear all genre associations
-    return r.db.Model(&audiobook).Association("Genres").Clear()
+// SyncPrimaryCredits makes authorID the primary author and readerID the primary narrator of an
+// audiobook after AuthorID or ReaderID changed. The new primary takes the place of the old one; if
+// it was already credited further down, the two swap places, so that no co-credit is lost.
+func (r *AudiobookRepository) SyncPrimaryCredits(audiobookID, authorID, readerID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := syncPrimaryCredit(tx, "audiobook_authors", "author_id", entity.RoleAuthor, audiobookID, authorID); err != nil {
+			return err
+		}
+		return syncPrimaryCredit(tx, "audiobook_readers", "reader_id", entity.RoleNarrator, audiobookID, readerID)
+	})
+}
+
+// syncPrimaryCredit moves personID to the first credit in role of an audiobook in a credit table
+func syncPrimaryCredit(tx *gorm.DB, table, column, role string, audiobookID, personID uint) error {
+	var credits []struct {
+		PersonID uint
+		Position int
+	}
+	err := tx.Table(table).
+		Select(column+" AS person_id, position").
+		Where("audiobook_id = ? AND role = ?", audiobookID, role).
+		Order("position ASC").Order(column + " ASC").
+		Scan(&credits).Error
+	if err != nil {
+		return err
+	}
+
+	// Uncredited so far: put the primary in front of every other credit
+	if len(credits) == 0 {
+		if err := tx.Exec("UPDATE "+table+" SET position = position + 1 WHERE audiobook_id = ?", audiobookID).Error; err != nil {
+			return err
+		}
+		return tx.Exec("INSERT INTO "+table+" (audiobook_id, "+column+", role, position) VALUES (?, ?, ?, 1)", audiobookID, personID, role).Error
+	}
+
+	primary := credits[0]
+	if primary.PersonID == personID {
+		return nil
+	}
+
+	credit := tx.Table(table).Where("audiobook_id = ? AND role = ?", audiobookID, role).Session(&gorm.Session{})
+	for _, other := range credits[1:] {
+		if other.PersonID == personID {
+			if err := credit.Where(column+" = ?", personID).Update("position", primary.Position).Error; err != nil {
+				return err
+			}
+			return credit.Where(column+" = ?", primary.PersonID).Update("position", other.Position).Error
+		}
+	}
+	return credit.Where(column+" = ?", primary.PersonID).Update(column, personID).Error
 }
@@ -6,15 +6,28 @@ import (
 	"gorm.io/gorm"
 )
 
+// authorSort lists the sort keys of author lists; popularity is the number of audiobooks crediting them
+var authorSort = listSort{
+	table:      "authors",
+	defaultKey: "name",
+	keys: map[string][]sortColumn{
+		"id":         nil,
+		"name":       {{expression: "authors.name", kind: sortText}},
+		"created_at": {{expression: "authors.created_at", kind: sortTimestamp}},
+		"popularity": {{expression: "(SELECT COUNT(DISTINCT audiobook_id) FROM audiobook_authors WHERE audiobook_authors.author_id = authors.id AND audiobook_id IN (SELECT id FROM audiobooks WHERE deleted_at IS NULL))", kind: sortInteger}},
+	},
+}
+
 // AuthorRepositoryInterface defines the contract for author repository
 type AuthorRepositoryInterface interface {
 	Create(author *entity.Author) error
 	GetByID(id uint) (*entity.Author, error)
-	GetAll(offset, limit int) ([]entity.Author, int64, error)
+	GetAll(page ListPage) ([]entity.Author, PageResult, error)
 	Update(author *entity.Author) error
-	Delete(id uint) error
+	Delete(id, version uint) error
 	SearchByName(query string, offset, limit int) ([]entity.Author, int64, error)
 	ExistsByName(name string) (bool, error)
+	IsPrimaryOfAudiobooks(id uint) (bool, error)
 }
 
 // AuthorRepository implements AuthorRepositoryInterface
@@ -42,32 +55,44 @@ func (r *AuthorRepository) GetByID(id uint) (*entity.Author, error) {
 	return &author, nil
 }
 
-// GetAll retrieves all authors with pagination
-func (r *AuthorRepository) GetAll(offset, limit int) ([]entity.Author, int64, error) {
+// GetAll retrieves one page of all authors
+func (r *AuthorRepository) GetAll(page ListPage) ([]entity.Author, PageResult, error) {
 	var authors []entity.Author
-	var total int64
+	var result PageResult
+
+	order, err := authorSort.resolve(page)
+	if err != nil {
+		return nil, result, err
+	}
 
 	// Count total records
-	if err := r.db.Model(&entity.Author{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+	if err := r.db.Model(&entity.Author{}).Count(&result.Total).Error; err != nil {
+		return nil, result, err
 	}
 
-	// Get paginated results
-	if err := r.db.Offset(offset).Limit(limit).Find(&authors).Error; err != nil {
-		return nil, 0, err
+	// Get the page
+	if err := order.apply(r.db.Model(&entity.Author{})).Find(&authors).Error; err != nil {
+		return nil, result, err
 	}
 
-	return authors, total, nil
+	if order.more(len(authors)) {
+		authors = authors[:page.Limit]
+		if result.NextCursor, err = order.cursorAfter(r.db, authors[len(authors)-1].ID); err != nil {
+			return nil, result, err
+		}
+	}
+
+	return authors, result, nil
 }
 
-// Update updates an existing author
+// Update updates an existing author if it is still at its version, bumping the version
 func (r *AuthorRepository) Update(author *entity.Author) error {
-	return r.db.Save(author).Error
+	return updateVersioned(r.db, author, &author.Version)
 }
 
-// Delete deletes an author by ID
-func (r *AuthorRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Author{}, id).Error
+// Delete moves an author at version to the trash; version 0 deletes any version
+func (r *AuthorRepository) Delete(id, version uint) error {
+	return deleteVersioned(r.db, &entity.Author{}, id, version)
 }
 
 // SearchByName searches authors by name
@@ -86,7 +111,7 @@ func (r *AuthorRepository) SearchByName(query string, offset, limit int) ([]enti
 	}
 
 	// Get paginated results
-	if err := dbQuery.Offset(offset).Limit(limit).Find(&authors).Error; err != nil {
+	if err := dbQuery.Order("name ASC").Order("id ASC").Offset(offset).Limit(limit).Find(&authors).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -99,3 +124,10 @@ func (r *AuthorRepository) ExistsByName(name string) (bool, error) {
 	err := r.db.Model(&entity.Author{}).Where("name = ?", name).Count(&count).Error
 	return count > 0, err
 }
+
+// IsPrimaryOfAudiobooks reports whether an author is the primary author of any audiobook outside the trash
+func (r *AuthorRepository) IsPrimaryOfAudiobooks(id uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&entity.Audiobook{}).Where("author_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"catalog-service/data_layer/entity"
+
+	"gorm.io/gorm"
+)
+
+// BookmarkRepositoryInterface defines the contract for bookmark repository
+type BookmarkRepositoryInterface interface {
+	Create(bookmark *entity.Bookmark) error
+	GetByID(id uint) (*entity.Bookmark, error)
+	Update(bookmark *entity.Bookmark) error
+	Delete(id uint) error
+	GetByUserTrackOffset(userID string, trackID uint, offsetSeconds int) (*entity.Bookmark, error)
+	GetByUserAndTrack(userID string, trackID uint) ([]entity.Bookmark, error)
+	GetByUserAndAudiobook(userID string, audiobookID uint, offset, limit int) ([]entity.Bookmark, int64, error)
+	GetAllByUserAndAudiobook(userID string, audiobookID uint) ([]entity.Bookmark, error)
+}
+
+// BookmarkRepository implements BookmarkRepositoryInterface
+type BookmarkRepository struct {
+	db *gorm.DB
+}
+
+// NewBookmarkRepository creates a new bookmark repository
+func NewBookmarkRepository(db *gorm.DB) BookmarkRepositoryInterface {
+	return &BookmarkRepository{db: db}
+}
+
+// Create creates a new bookmark
+func (r *BookmarkRepository) Create(bookmark *entity.Bookmark) error {
+	return r.db.Create(bookmark).Error
+}
+
+// GetByID retrieves a bookmark by ID with its track
+func (r *BookmarkRepository) GetByID(id uint) (*entity.Bookmark, error) {
+	var bookmark entity.Bookmark
+	err := r.db.Preload("Track").First(&bookmark, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &bookmark, nil
+}
+
+// Update updates a bookmark
+func (r *BookmarkRepository) Update(bookmark *entity.Bookmark) error {
+	return r.db.Omit("Track").Save(bookmark).Error
+}
+
+// Delete deletes a bookmark
+func (r *BookmarkRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.Bookmark{}, id).Error
+}
+
+// GetByUserTrackOffset retrieves the bookmark of a user at an exact offset of a track
+func (r *BookmarkRepository) GetByUserTrackOffset(userID string, trackID uint, offsetSeconds int) (*entity.Bookmark, error) {
+	var bookmark entity.Bookmark
+	err := r.db.Where("user_id = ? AND track_id = ? AND offset_seconds = ?", userID, trackID, offsetSeconds).First(&bookmark).Error
+	if err != nil {
+		return nil, err
+	}
+	return &bookmark, nil
+}
+
+// GetByUserAndTrack retrieves the bookmarks of a user in a track ordered by offset
+func (r *BookmarkRepository) GetByUserAndTrack(userID string, trackID uint) ([]entity.Bookmark, error) {
+	var bookmarks []entity.Bookmark
+	err := r.db.Preload("Track").
+		Where("user_id = ? AND track_id = ?", userID, trackID).
+		Order("offset_seconds ASC").
+		Find(&bookmarks).Error
+	return bookmarks, err
+}
+
+// GetByUserAndAudiobook retrieves the bookmarks of a user in an audiobook in play order with pagination
+func (r *BookmarkRepository) GetByUserAndAudiobook(userID string, audiobookID uint, offset, limit int) ([]entity.Bookmark, int64, error) {
+	var bookmarks []entity.Bookmark
+	var total int64
+
+	query := r.audiobookQuery(userID, audiobookID)
+
+	// Count total records
+	if err := query.Model(&entity.Bookmark{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err := r.audiobookQuery(userID, audiobookID).
+		Preload("Track").
+		Order("tracks.position ASC").
+		Order("bookmarks.offset_seconds ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&bookmarks).Error
+
+	return bookmarks, total, err
+}
+
+// GetAllByUserAndAudiobook retrieves every bookmark of a user in an audiobook in play order
+func (r *BookmarkRepository) GetAllByUserAndAudiobook(userID string, audiobookID uint) ([]entity.Bookmark, error) {
+	var bookmarks []entity.Bookmark
+	err := r.audiobookQuery(userID, audiobookID).
+		Preload("Track").
+		Order("tracks.position ASC").
+		Order("bookmarks.offset_seconds ASC").
+		Find(&bookmarks).Error
+	return bookmarks, err
+}
+
+func (r *BookmarkRepository) audiobookQuery(userID string, audiobookID uint) *gorm.DB {
+	return r.db.Joins("JOIN tracks ON tracks.id = bookmarks.track_id").
+		Where("bookmarks.user_id = ? AND tracks.audiobook_id = ? AND tracks.deleted_at IS NULL", userID, audiobookID)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// DurationFilter narrows a list by length in seconds.
+// MinSeconds is inclusive and MaxSeconds exclusive, so a maximum of 3 hours means "under 3 hours";
+// zero leaves that side unbounded.
+type DurationFilter struct {
+	MinSeconds int
+	MaxSeconds int
+}
+
+// apply adds the bounds of the filter on column to query
+func (f DurationFilter) apply(query *gorm.DB, column string) *gorm.DB {
+	if f.MinSeconds > 0 {
+		query = query.Where(column+" >= ?", f.MinSeconds)
+	}
+	if f.MaxSeconds > 0 {
+		query = query.Where(column+" < ?", f.MaxSeconds)
+	}
+	return query
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"catalog-service/data_layer/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FeedTokenRepositoryInterface defines the contract for feed token repository
+type FeedTokenRepositoryInterface interface {
+	Create(token *entity.FeedToken) error
+	GetActiveByHash(tokenHash string) (*entity.FeedToken, error)
+	GetByUserID(userID string) ([]entity.FeedToken, error)
+	Revoke(userID string, id uint) error
+	TouchLastUsed(id uint, usedAt time.Time) error
+}
+
+// FeedTokenRepository implements FeedTokenRepositoryInterface
+type FeedTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewFeedTokenRepository creates a new feed token repository
+func NewFeedTokenRepository(db *gorm.DB) FeedTokenRepositoryInterface {
+	return &FeedTokenRepository{db: db}
+}
+
+// Create creates a new feed token
+func (r *FeedTokenRepository) Create(token *entity.FeedToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetActiveByHash retrieves the feed token with the given hash unless it was revoked
+func (r *FeedTokenRepository) GetActiveByHash(tokenHash string) (*entity.FeedToken, error) {
+	var token entity.FeedToken
+	err := r.db.Where("token_hash = ? AND revoked_at IS NULL", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetByUserID retrieves every feed token of a user, newest first, including revoked ones
+func (r *FeedTokenRepository) GetByUserID(userID string) ([]entity.FeedToken, error) {
+	var tokens []entity.FeedToken
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Order("id DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+// Revoke marks a feed token of a user as revoked.
+// Returns gorm.ErrRecordNotFound when the user has no such token or it was already revoked.
+func (r *FeedTokenRepository) Revoke(userID string, id uint) error {
+	result := r.db.Model(&entity.FeedToken{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed records when a feed token was last used
+func (r *FeedTokenRepository) TouchLastUsed(id uint, usedAt time.Time) error {
+	return r.db.Model(&entity.FeedToken{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
@@ -6,13 +6,25 @@ import (
 	"gorm.io/gorm"
 )
 
+// genreSort lists the sort keys of genre lists; popularity is the number of its audiobooks
+var genreSort = listSort{
+	table:      "genres",
+	defaultKey: "name",
+	keys: map[string][]sortColumn{
+		"id":         nil,
+		"name":       {{expression: "genres.name", kind: sortText}},
+		"created_at": {{expression: "genres.created_at", kind: sortTimestamp}},
+		"popularity": {{expression: "(SELECT COUNT(*) FROM audiobook_genres WHERE audiobook_genres.genre_id = genres.id AND audiobook_id IN (SELECT id FROM audiobooks WHERE deleted_at IS NULL))", kind: sortInteger}},
+	},
+}
+
 // GenreRepositoryInterface defines the contract for genre repository
 type GenreRepositoryInterface interface {
 	Create(genre *entity.Genre) error
 	GetByID(id uint) (*entity.Genre, error)
-	GetAll(offset, limit int) ([]entity.Genre, int64, error)
+	GetAll(page ListPage) ([]entity.Genre, PageResult, error)
 	Update(genre *entity.Genre) error
-	Delete(id uint) error
+	Delete(id, version uint) error
 	SearchByName(query string, offset, limit int) ([]entity.Genre, int64, error)
 	ExistsByName(name string) (bool, error)
 	GetByIDs(ids []uint) ([]entity.Genre, error)
@@ -43,32 +55,44 @@ func (r *GenreRepository) GetByID(id uint) (*entity.Genre, error) {
 	return &genre, nil
 }
 
-// GetAll retrieves all genres with pagination
-func (r *GenreRepository) GetAll(offset, limit int) ([]entity.Genre, int64, error) {
+// GetAll retrieves one page of all genres
+func (r *GenreRepository) GetAll(page ListPage) ([]entity.Genre, PageResult, error) {
 	var genres []entity.Genre
-	var total int64
+	var result PageResult
+
+	order, err := genreSort.resolve(page)
+	if err != nil {
+		return nil, result, err
+	}
 
 	// Count total records
-	if err := r.db.Model(&entity.Genre{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+	if err := r.db.Model(&entity.Genre{}).Count(&result.Total).Error; err != nil {
+		return nil, result, err
 	}
 
-	// Get paginated results
-	if err := r.db.Offset(offset).Limit(limit).Find(&genres).Error; err != nil {
-		return nil, 0, err
+	// Get the page
+	if err := order.apply(r.db.Model(&entity.Genre{})).Find(&genres).Error; err != nil {
+		return nil, result, err
 	}
 
-	return genres, total, nil
+	if order.more(len(genres)) {
+		genres = genres[:page.Limit]
+		if result.NextCursor, err = order.cursorAfter(r.db, genres[len(genres)-1].ID); err != nil {
+			return nil, result, err
+		}
+	}
+
+	return genres, result, nil
 }
 
-// Update updates an existing genre
+// Update updates an existing genre if it is still at its version, bumping the version
 func (r *GenreRepository) Update(genre *entity.Genre) error {
-	return r.db.Save(genre).Error
+	return updateVersioned(r.db, genre, &genre.Version)
 }
 
-// Delete deletes a genre by ID
-func (r *GenreRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Genre{}, id).Error
+// Delete moves a genre at version to the trash; version 0 deletes any version
+func (r *GenreRepository) Delete(id, version uint) error {
+	return deleteVersioned(r.db, &entity.Genre{}, id, version)
 }
 
 // SearchByName searches genres by name
@@ -87,7 +111,7 @@ func (r *GenreRepository) SearchByName(query string, offset, limit int) ([]entit
 	}
 
 	// Get paginated results
-	if err := dbQuery.Offset(offset).Limit(limit).Find(&genres).Error; err != nil {
+	if err := dbQuery.Order("name ASC").Order("id ASC").Offset(offset).Limit(limit).Find(&genres).Error; err != nil {
 		return nil, 0, err
 	}
 
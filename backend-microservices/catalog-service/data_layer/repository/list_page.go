@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// List paging errors, returned for sort keys a list does not support and for cursors that were
+// not issued for the requested sort
+var (
+	ErrInvalidSort   = errors.New("invalid sort")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// ListPage selects one page of a list. Sort is a key of the list, prefixed with "-" for
+// descending order, and empty for the list's default order. A Cursor from a previous page
+// continues right after that page's last row (keyset pagination); without one, Offset is used.
+type ListPage struct {
+	Sort   string
+	Offset int
+	Limit  int
+	Cursor string
+}
+
+// PageResult describes a fetched page: the number of rows in the whole list and the cursor of
+// the next page, empty on the last page
+type PageResult struct {
+	Total      int64
+	NextCursor string
+}
+
+// Kinds of sort values, used to decode cursors into values the database can compare
+const (
+	sortText = iota
+	sortInteger
+	sortTimestamp
+)
+
+// sortColumn is one SQL expression of a sort key and the kind of value it yields
+type sortColumn struct {
+	expression string
+	kind       int
+}
+
+// listSort lists the sort keys a list supports; ties are always broken by the table's ID
+type listSort struct {
+	table      string
+	defaultKey string
+	keys       map[string][]sortColumn
+}
+
+// listOrder is a sort key resolved for one page request
+type listOrder struct {
+	sort   listSort
+	key    string
+	desc   bool
+	after  []interface{}
+	offset int
+	limit  int
+}
+
+// pageCursor is the decoded form of an opaque cursor: the sort it was issued for, and the sort
+// values and ID of the last row of the page before
+type pageCursor struct {
+	Sort   string            `json:"s"`
+	Values []json.RawMessage `json:"v"`
+	ID     uint              `json:"id"`
+}
+
+// resolve validates the sort and cursor of a page request
+func (s listSort) resolve(page ListPage) (*listOrder, error) {
+	sortKey := page.Sort
+	if sortKey == "" {
+		sortKey = s.defaultKey
+	}
+
+	order := &listOrder{sort: s, key: strings.TrimPrefix(sortKey, "-"), desc: strings.HasPrefix(sortKey, "-"), offset: page.Offset, limit: page.Limit}
+	columns, ok := s.keys[order.key]
+	if !ok {
+		return nil, ErrInvalidSort
+	}
+
+	if page.Cursor == "" {
+		return order, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(page.Cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor pageCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Sort != sortKey || len(cursor.Values) != len(columns) {
+		return nil, ErrInvalidCursor
+	}
+
+	for i, column := range columns {
+		value, err := decodeSortValue(cursor.Values[i], column.kind)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		order.after = append(order.after, value)
+	}
+	order.after = append(order.after, cursor.ID)
+	order.offset = 0
+
+	return order, nil
+}
+
+// apply orders query by the sort key and ID, skips to the cursor or offset and fetches one row
+// more than the limit, so that the caller can tell whether another page follows
+func (o *listOrder) apply(query *gorm.DB) *gorm.DB {
+	direction := " ASC"
+	comparison := ">"
+	if o.desc {
+		direction = " DESC"
+		comparison = "<"
+	}
+
+	expressions := make([]string, 0, len(o.sort.keys[o.key])+1)
+	for _, column := range o.sort.keys[o.key] {
+		expressions = append(expressions, column.expression)
+	}
+	expressions = append(expressions, o.sort.table+".id")
+
+	if o.after != nil {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(o.after)), ", ")
+		query = query.Where("("+strings.Join(expressions, ", ")+") "+comparison+" ("+placeholders+")", o.after...)
+	}
+	for _, expression := range expressions {
+		query = query.Order(expression + direction)
+	}
+
+	return query.Offset(o.offset).Limit(o.limit + 1)
+}
+
+// more reports whether a page fetched by apply has rows beyond the limit
+func (o *listOrder) more(fetched int) bool {
+	return fetched > o.limit
+}
+
+// cursorAfter issues the cursor of the page that follows the row with the given ID
+func (o *listOrder) cursorAfter(db *gorm.DB, lastID uint) (string, error) {
+	columns := o.sort.keys[o.key]
+	cursor := pageCursor{Sort: o.key, Values: []json.RawMessage{}, ID: lastID}
+	if o.desc {
+		cursor.Sort = "-" + o.key
+	}
+
+	selects := make([]string, len(columns))
+	targets := make([]interface{}, len(columns))
+	for i, column := range columns {
+		selects[i] = column.expression
+		switch column.kind {
+		case sortText:
+			targets[i] = new(string)
+		case sortInteger:
+			targets[i] = new(int64)
+		case sortTimestamp:
+			targets[i] = new(time.Time)
+		}
+	}
+
+	if len(columns) > 0 {
+		row := db.Table(o.sort.table).Select(strings.Join(selects, ", ")).Where(o.sort.table+".id = ?", lastID).Row()
+		if err := row.Scan(targets...); err != nil {
+			return "", err
+		}
+	}
+
+	for _, target := range targets {
+		value, err := json.Marshal(target)
+		if err != nil {
+			return "", err
+		}
+		cursor.Values = append(cursor.Values, value)
+	}
+
+	return cursor.encode()
+}
+
+// encode renders a cursor in the opaque form handed to clients
+func (c pageCursor) encode() (string, error) {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeSortValue converts a sort value of a cursor back to the Go type of its kind
+func decodeSortValue(raw json.RawMessage, kind int) (interface{}, error) {
+	switch kind {
+	case sortText:
+		var value string
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	case sortInteger:
+		var value int64
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	case sortTimestamp:
+		var value time.Time
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	default:
+		return nil, ErrInvalidCursor
+	}
+}
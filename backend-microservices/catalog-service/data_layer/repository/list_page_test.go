@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"catalog-service/data_layer/entity"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// testCursor encodes a cursor for sort with the given sort values and last ID
+func testCursor(t *testing.T, sort string, id uint, values ...interface{}) string {
+	t.Helper()
+
+	cursor := pageCursor{Sort: sort, Values: []json.RawMessage{}, ID: id}
+	for _, value := range values {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cursor.Values = append(cursor.Values, raw)
+	}
+
+	encoded, err := cursor.encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
+
+func TestListSortResolve(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		page       ListPage
+		wantKey    string
+		wantDesc   bool
+		wantAfter  []interface{}
+		wantOffset int
+	}{
+		{name: "default sort", page: ListPage{Offset: 40, Limit: 20}, wantKey: "id", wantOffset: 40},
+		{name: "descending sort", page: ListPage{Sort: "-year", Limit: 20}, wantKey: "year", wantDesc: true},
+		{
+			name:      "cursor of the default sort",
+			page:      ListPage{Limit: 20, Cursor: testCursor(t, "id", 5)},
+			wantKey:   "id",
+			wantAfter: []interface{}{uint(5)},
+		},
+		{
+			name:      "text cursor",
+			page:      ListPage{Sort: "title", Limit: 20, Cursor: testCursor(t, "title", 5, "Dune")},
+			wantKey:   "title",
+			wantAfter: []interface{}{"Dune", uint(5)},
+		},
+		{
+			name:      "integer cursor, descending",
+			page:      ListPage{Sort: "-year", Limit: 20, Cursor: testCursor(t, "-year", 5, 1999)},
+			wantKey:   "year",
+			wantDesc:  true,
+			wantAfter: []interface{}{int64(1999), uint(5)},
+		},
+		{
+			name:      "timestamp cursor",
+			page:      ListPage{Sort: "created_at", Limit: 20, Cursor: testCursor(t, "created_at", 5, createdAt)},
+			wantKey:   "created_at",
+			wantAfter: []interface{}{createdAt, uint(5)},
+		},
+		{
+			name:      "a cursor replaces the offset",
+			page:      ListPage{Sort: "title", Offset: 40, Limit: 20, Cursor: testCursor(t, "title", 5, "Dune")},
+			wantKey:   "title",
+			wantAfter: []interface{}{"Dune", uint(5)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := audiobookSort.resolve(tt.page)
+			if err != nil {
+				t.Fatalf("resolve: %v", err)
+			}
+
+			if order.key != tt.wantKey || order.desc != tt.wantDesc {
+				t.Errorf("sort = %q desc %v, want %q desc %v", order.key, order.desc, tt.wantKey, tt.wantDesc)
+			}
+			if !reflect.DeepEqual(order.after, tt.wantAfter) {
+				t.Errorf("after = %#v, want %#v", order.after, tt.wantAfter)
+			}
+			if order.offset != tt.wantOffset || order.limit != tt.page.Limit {
+				t.Errorf("offset %d limit %d, want offset %d limit %d", order.offset, order.limit, tt.wantOffset, tt.page.Limit)
+			}
+		})
+	}
+}
+
+func TestListSortResolveRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    ListPage
+		wantErr error
+	}{
+		{name: "unknown sort", page: ListPage{Sort: "color"}, wantErr: ErrInvalidSort},
+		{name: "unknown descending sort", page: ListPage{Sort: "-color"}, wantErr: ErrInvalidSort},
+		{name: "not base64", page: ListPage{Sort: "title", Cursor: "not a cursor!"}, wantErr: ErrInvalidCursor},
+		{name: "not JSON", page: ListPage{Sort: "title", Cursor: base64.RawURLEncoding.EncodeToString([]byte("title:Dune"))}, wantErr: ErrInvalidCursor},
+		{name: "cursor of another sort", page: ListPage{Sort: "year", Cursor: testCursor(t, "title", 5, "Dune")}, wantErr: ErrInvalidCursor},
+		{name: "cursor of the other direction", page: ListPage{Sort: "-title", Cursor: testCursor(t, "title", 5, "Dune")}, wantErr: ErrInvalidCursor},
+		{name: "cursor of another default sort", page: ListPage{Cursor: testCursor(t, "title", 5, "Dune")}, wantErr: ErrInvalidCursor},
+		{name: "missing sort value", page: ListPage{Sort: "title", Cursor: testCursor(t, "title", 5)}, wantErr: ErrInvalidCursor},
+		{name: "extra sort value", page: ListPage{Sort: "title", Cursor: testCursor(t, "title", 5, "Dune", "Herbert")}, wantErr: ErrInvalidCursor},
+		{name: "sort value of the wrong kind", page: ListPage{Sort: "year", Cursor: testCursor(t, "year", 5, "1999")}, wantErr: ErrInvalidCursor},
+		{name: "malformed timestamp", page: ListPage{Sort: "created_at", Cursor: testCursor(t, "created_at", 5, "yesterday")}, wantErr: ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := audiobookSort.resolve(tt.page); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("resolve error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestListOrderApply(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		page ListPage
+		want []string
+	}{
+		{
+			name: "offset page",
+			page: ListPage{Sort: "title", Offset: 40, Limit: 20},
+			want: []string{"ORDER BY audiobooks.title ASC,audiobooks.id ASC", "LIMIT 21 OFFSET 40"},
+		},
+		{
+			name: "keyset page after a cursor",
+			page: ListPage{Sort: "-year", Offset: 40, Limit: 20, Cursor: testCursor(t, "-year", 5, 1999)},
+			want: []string{
+				"(audiobooks.year_of_publishing, audiobooks.id) < ($1, $2)",
+				"ORDER BY audiobooks.year_of_publishing DESC,audiobooks.id DESC",
+				"LIMIT 21",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := audiobookSort.resolve(tt.page)
+			if err != nil {
+				t.Fatalf("resolve: %v", err)
+			}
+
+			statement := order.apply(db.Model(&entity.Audiobook{})).Find(&[]entity.Audiobook{}).Statement
+			sql := statement.SQL.String()
+			for _, want := range tt.want {
+				if !strings.Contains(sql, want) {
+					t.Errorf("SQL %q does not contain %q", sql, want)
+				}
+			}
+			if tt.page.Cursor != "" && strings.Contains(sql, "OFFSET") {
+				t.Errorf("SQL %q skips rows after a cursor", sql)
+			}
+		})
+	}
+}
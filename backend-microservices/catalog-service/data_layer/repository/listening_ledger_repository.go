@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"catalog-service/data_layer/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListeningTotal is the listening time of a user in one day, week or month
+type ListeningTotal struct {
+	Period          time.Time
+	ListenedSeconds float64
+	ContentSeconds  float64
+}
+
+// GenreListeningTotal is the listening time of a user in one genre
+type GenreListeningTotal struct {
+	GenreID         uint
+	GenreName       string
+	ListenedSeconds float64
+}
+
+// ListeningLedgerRepositoryInterface defines the contract for listening ledger repository
+type ListeningLedgerRepositoryInterface interface {
+	AddListening(entry *entity.ListeningLedger) error
+	GetTotals(userID string, unit string, from, to time.Time) ([]ListeningTotal, error)
+	GetListeningDays(userID string, minSeconds float64) ([]time.Time, error)
+	GetTopGenres(userID string, from, to time.Time, limit int) ([]GenreListeningTotal, error)
+}
+
+// ListeningLedgerRepository implements ListeningLedgerRepositoryInterface
+type ListeningLedgerRepository struct {
+	db *gorm.DB
+}
+
+// NewListeningLedgerRepository creates a new listening ledger repository
+func NewListeningLedgerRepository(db *gorm.DB) ListeningLedgerRepositoryInterface {
+	return &ListeningLedgerRepository{db: db}
+}
+
+// AddListening adds listened seconds to the ledger row of the user, track and day
+func (r *ListeningLedgerRepository) AddListening(entry *entity.ListeningLedger) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "track_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"listened_seconds": gorm.Expr("listening_ledger.listened_seconds + EXCLUDED.listened_seconds"),
+			"content_seconds":  gorm.Expr("listening_ledger.content_seconds + EXCLUDED.content_seconds"),
+			"updated_at":       gorm.Expr("EXCLUDED.updated_at"),
+		}),
+	}).Create(entry).Error
+}
+
+// GetTotals sums the listening time of a user per day, week or month between two days (inclusive)
+func (r *ListeningLedgerRepository) GetTotals(userID string, unit string, from, to time.Time) ([]ListeningTotal, error) {
+	var totals []ListeningTotal
+	err := r.db.Model(&entity.ListeningLedger{}).
+		Select("date_trunc(?, day)::date AS period, SUM(listened_seconds) AS listened_seconds, SUM(content_seconds) AS content_seconds", unit).
+		Where("user_id = ? AND day BETWEEN ? AND ?", userID, from, to).
+		Group("period").
+		Order("period ASC").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// GetListeningDays retrieves the days a user listened at least minSeconds, most recent first
+func (r *ListeningLedgerRepository) GetListeningDays(userID string, minSeconds float64) ([]time.Time, error) {
+	var days []time.Time
+	err := r.db.Model(&entity.ListeningLedger{}).
+		Select("day").
+		Where("user_id = ?", userID).
+		Group("day").
+		Having("SUM(listened_seconds) >= ?", minSeconds).
+		Order("day DESC").
+		Pluck("day", &days).Error
+	return days, err
+}
+
+// GetTopGenres ranks the genres a user listened to between two days (inclusive) by listening time
+func (r *ListeningLedgerRepository) GetTopGenres(userID string, from, to time.Time, limit int) ([]GenreListeningTotal, error) {
+	var totals []GenreListeningTotal
+	err := r.db.Model(&entity.ListeningLedger{}).
+		Select("genres.id AS genre_id, genres.name AS genre_name, SUM(listening_ledger.listened_seconds) AS listened_seconds").
+		Joins("JOIN audiobook_genres ON audiobook_genres.audiobook_id = listening_ledger.audiobook_id").
+		Joins("JOIN genres ON genres.id = audiobook_genres.genre_id AND genres.deleted_at IS NULL").
+		Where("listening_ledger.user_id = ? AND listening_ledger.day BETWEEN ? AND ?", userID, from, to).
+		Group("genres.id, genres.name").
+		Order("listened_seconds DESC, genres.name ASC").
+		Limit(limit).
+		Scan(&totals).Error
+	return totals, err
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"catalog-service/data_layer/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PlaySessionRepositoryInterface defines the contract for play session repository
+type PlaySessionRepositoryInterface interface {
+	Create(session *entity.PlaySession) error
+	GetByID(id uint) (*entity.PlaySession, error)
+	UpdateIfActive(session *entity.PlaySession) (bool, error)
+	CloseIfActive(id uint, status string, endedAt time.Time) (bool, error)
+	GetActiveByUserID(userID string) ([]entity.PlaySession, error)
+	GetStale(lastHeartbeatBefore time.Time, limit int) ([]entity.PlaySession, error)
+	LockUser(userID string) error
+}
+
+// PlaySessionRepository implements PlaySessionRepositoryInterface
+type PlaySessionRepository struct {
+	db *gorm.DB
+}
+
+// NewPlaySessionRepository creates a new play session repository
+func NewPlaySessionRepository(db *gorm.DB) PlaySessionRepositoryInterface {
+	return &PlaySessionRepository{db: db}
+}
+
+// Create creates a new play session
+func (r *PlaySessionRepository) Create(session *entity.PlaySession) error {
+	return r.db.Create(session).Error
+}
+
+// GetByID retrieves a play session by ID
+func (r *PlaySessionRepository) GetByID(id uint) (*entity.PlaySession, error) {
+	var session entity.PlaySession
+	err := r.db.First(&session, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// UpdateIfActive saves the playback state of a session unless it has been closed meanwhile
+func (r *PlaySessionRepository) UpdateIfActive(session *entity.PlaySession) (bool, error) {
+	result := r.db.Model(&entity.PlaySession{}).
+		Where("id = ? AND status = ?", session.ID, entity.PlaySessionActive).
+		Updates(map[string]interface{}{
+			"track_id":          session.TrackID,
+			"position_seconds":  session.PositionSeconds,
+			"duration_seconds":  session.DurationSeconds,
+			"speed":             session.Speed,
+			"last_heartbeat_at": session.LastHeartbeatAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// CloseIfActive closes a session with the given status, reporting whether this call closed it
+func (r *PlaySessionRepository) CloseIfActive(id uint, status string, endedAt time.Time) (bool, error) {
+	result := r.db.Model(&entity.PlaySession{}).
+		Where("id = ? AND status = ?", id, entity.PlaySessionActive).
+		Updates(map[string]interface{}{
+			"status":   status,
+			"ended_at": endedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// GetActiveByUserID retrieves the active sessions of a user
+func (r *PlaySessionRepository) GetActiveByUserID(userID string) ([]entity.PlaySession, error) {
+	var sessions []entity.PlaySession
+	err := r.db.Where("user_id = ? AND status = ?", userID, entity.PlaySessionActive).Find(&sessions).Error
+	return sessions, err
+}
+
+// GetStale retrieves active sessions whose last heartbeat is older than the given time
+func (r *PlaySessionRepository) GetStale(lastHeartbeatBefore time.Time, limit int) ([]entity.PlaySession, error) {
+	var sessions []entity.PlaySession
+	err := r.db.Where("status = ? AND last_heartbeat_at < ?", entity.PlaySessionActive, lastHeartbeatBefore).
+		Order("last_heartbeat_at ASC").
+		Limit(limit).
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// LockUser serializes the session starts and closes of a user until the surrounding transaction
+// ends. It is an advisory lock rather than a row lock, so it also holds while no session is active.
+func (r *PlaySessionRepository) LockUser(userID string) error {
+	return r.db.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "play-session:"+userID).Error
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"catalog-service/data_layer/entity"
+
+	"gorm.io/gorm"
+)
+
+// QueueRepositoryInterface defines the contract for play queue repository
+type QueueRepositoryInterface interface {
+	CreateMany(entries []entity.QueueEntry) error
+	GetByUserID(userID string) ([]entity.QueueEntry, error)
+	LockUser(userID string) error
+	UpdatePositions(entries []entity.QueueEntry) error
+	DeleteByIDs(userID string, ids []uint) error
+	DeleteByUserID(userID string) error
+}
+
+// QueueRepository implements QueueRepositoryInterface
+type QueueRepository struct {
+	db *gorm.DB
+}
+
+// NewQueueRepository creates a new play queue repository
+func NewQueueRepository(db *gorm.DB) QueueRepositoryInterface {
+	return &QueueRepository{db: db}
+}
+
+// CreateMany creates queue entries in one batch
+func (r *QueueRepository) CreateMany(entries []entity.QueueEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return r.db.Omit("Track").Create(&entries).Error
+}
+
+// GetByUserID retrieves the queue of a user in play order with tracks.
+// Entries whose track no longer exists come back with an empty Track.
+func (r *QueueRepository) GetByUserID(userID string) ([]entity.QueueEntry, error) {
+	var entries []entity.QueueEntry
+	err := r.db.Preload("Track").
+		Where("user_id = ?", userID).
+		Order("position ASC").
+		Order("id ASC").
+		Find(&entries).Error
+	return entries, err
+}
+
+// LockUser serializes queue writes of a user until the surrounding transaction ends. It is an
+// advisory lock rather than a row lock, so it also holds while the queue is still empty.
+func (r *QueueRepository) LockUser(userID string) error {
+	return r.db.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "queue:"+userID).Error
+}
+
+// UpdatePositions saves the position of every given entry in one transaction
+func (r *QueueRepository) UpdatePositions(entries []entity.QueueEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, entry := range entries {
+			err := tx.Model(&entity.QueueEntry{}).
+				Where("id = ? AND user_id = ?", entry.ID, entry.UserID).
+				Update("position", entry.Position).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteByIDs deletes queue entries of a user
+func (r *QueueRepository) DeleteByIDs(userID string, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Where("user_id = ? AND id IN ?", userID, ids).Delete(&entity.QueueEntry{}).Error
+}
+
+// DeleteByUserID clears the queue of a user
+func (r *QueueRepository) DeleteByUserID(userID string) error {
+	return r.db.Where("user_id = ?", userID).Delete(&entity.QueueEntry{}).Error
+}
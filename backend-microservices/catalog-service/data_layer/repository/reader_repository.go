@@ -6,15 +6,28 @@ import (
 	"gorm.io/gorm"
 )
 
+// readerSort lists the sort keys of reader lists; popularity is the number of audiobooks they read
+var readerSort = listSort{
+	table:      "readers",
+	defaultKey: "name",
+	keys: map[string][]sortColumn{
+		"id":         nil,
+		"name":       {{expression: "readers.name", kind: sortText}},
+		"created_at": {{expression: "readers.created_at", kind: sortTimestamp}},
+		"popularity": {{expression: "(SELECT COUNT(DISTINCT audiobook_id) FROM audiobook_readers WHERE audiobook_readers.reader_id = readers.id AND audiobook_id IN (SELECT id FROM audiobooks WHERE deleted_at IS NULL))", kind: sortInteger}},
+	},
+}
+
 // ReaderRepositoryInterface defines the contract for reader repository
 type ReaderRepositoryInterface interface {
 	Create(reader *entity.Reader) error
 	GetByID(id uint) (*entity.Reader, error)
-	GetAll(offset, limit int) ([]entity.Reader, int64, error)
+	GetAll(page ListPage) ([]entity.Reader, PageResult, error)
 	Update(reader *entity.Reader) error
-	Delete(id uint) error
+	Delete(id, version uint) error
 	SearchByName(query string, offset, limit int) ([]entity.Reader, int64, error)
 	ExistsByName(name string) (bool, error)
+	IsPrimaryOfAudiobooks(id uint) (bool, error)
 }
 
 // ReaderRepository implements ReaderRepositoryInterface
@@ -42,32 +55,44 @@ func (r *ReaderRepository) GetByID(id uint) (*entity.Reader, error) {
 	return &reader, nil
 }
 
-// GetAll retrieves all readers with pagination
-func (r *ReaderRepository) GetAll(offset, limit int) ([]entity.Reader, int64, error) {
+// GetAll retrieves one page of all readers
+func (r *ReaderRepository) GetAll(page ListPage) ([]entity.Reader, PageResult, error) {
 	var readers []entity.Reader
-	var total int64
+	var result PageResult
+
+	order, err := readerSort.resolve(page)
+	if err != nil {
+		return nil, result, err
+	}
 
 	// Count total records
-	if err := r.db.Model(&entity.Reader{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+	if err := r.db.Model(&entity.Reader{}).Count(&result.Total).Error; err != nil {
+		return nil, result, err
 	}
 
-	// Get paginated results
-	if err := r.db.Offset(offset).Limit(limit).Find(&readers).Error; err != nil {
-		return nil, 0, err
+	// Get the page
+	if err := order.apply(r.db.Model(&entity.Reader{})).Find(&readers).Error; err != nil {
+		return nil, result, err
 	}
 
-	return readers, total, nil
+	if order.more(len(readers)) {
+		readers = readers[:page.Limit]
+		if result.NextCursor, err = order.cursorAfter(r.db, readers[len(readers)-1].ID); err != nil {
+			return nil, result, err
+		}
+	}
+
+	return readers, result, nil
 }
 
-// Update updates an existing reader
+// Update updates an existing reader if it is still at its version, bumping the version
 func (r *ReaderRepository) Update(reader *entity.Reader) error {
-	return r.db.Save(reader).Error
+	return updateVersioned(r.db, reader, &reader.Version)
 }
 
-// Delete deletes a reader by ID
-func (r *ReaderRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Reader{}, id).Error
+// Delete moves a reader at version to the trash; version 0 deletes any version
+func (r *ReaderRepository) Delete(id, version uint) error {
+	return deleteVersioned(r.db, &entity.Reader{}, id, version)
 }
 
 // SearchByName searches readers by name
@@ -86,7 +111,7 @@ func (r *ReaderRepository) SearchByName(query string, offset, limit int) ([]enti
 	}
 
 	// Get paginated results
-	if err := dbQuery.Offset(offset).Limit(limit).Find(&readers).Error; err != nil {
+	if err := dbQuery.Order("name ASC").Order("id ASC").Offset(offset).Limit(limit).Find(&readers).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -99,3 +124,10 @@ func (r *ReaderRepository) ExistsByName(name string) (bool, error) {
 	err := r.db.Model(&entity.Reader{}).Where("name = ?", name).Count(&count).Error
 	return count > 0, err
 }
+
+// IsPrimaryOfAudiobooks reports whether a reader is the primary reader of any audiobook outside the trash
+func (r *ReaderRepository) IsPrimaryOfAudiobooks(id uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&entity.Audiobook{}).Where("reader_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"catalog-service/data_layer/entity"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReplicationRepositoryInterface defines the contract for applying replicated changes to the catalog.
+// Replicated rows are keyed on their external ID, "<source>:<source ID>", and get catalog IDs of their
+// own, so they never take over rows created in the catalog or by an import. Trashed rows are restored
+// when the source upserts them again.
+type ReplicationRepositoryInterface interface {
+	GetCursor(source string) (*entity.ReplicationCursor, error)
+	LockCursor(source string) (*entity.ReplicationCursor, error)
+	SaveCursor(cursor *entity.ReplicationCursor) error
+	GetIDByExternalID(model interface{}, externalID string) (uint, error)
+	GetIDsByExternalIDs(model interface{}, externalIDs []string) ([]uint, error)
+	UpsertAuthor(externalID, name string) (uint, error)
+	UpsertReader(externalID, name string) (uint, error)
+	UpsertGenre(externalID, name string) (uint, error)
+	UpsertAudiobook(audiobook *entity.Audiobook) error
+	UpsertTrack(track *entity.Track) error
+	ReplaceGenres(audiobookID uint, genreIDs []uint) error
+	TrashTracksExcept(audiobookID uint, trackIDs []uint) error
+}
+
+// ReplicatedExternalID returns the external ID of a row replicated from source
+func ReplicatedExternalID(source string, id uint) string {
+	return fmt.Sprintf("%s:%d", source, id)
+}
+
+// IsReplicatedExternalID reports whether an external ID belongs to a replicated row
+func IsReplicatedExternalID(externalID string) bool {
+	return strings.Contains(externalID, ":")
+}
+
+// ReplicationRepository implements ReplicationRepositoryInterface
+type ReplicationRepository struct {
+	db *gorm.DB
+}
+
+// NewReplicationRepository creates a new replication repository
+func NewReplicationRepository(db *gorm.DB) ReplicationRepositoryInterface {
+	return &ReplicationRepository{db: db}
+}
+
+// GetCursor retrieves the cursor of a source; a source that never replicated is at offset 0
+func (r *ReplicationRepository) GetCursor(source string) (*entity.ReplicationCursor, error) {
+	var cursor entity.ReplicationCursor
+	err := r.db.Where("source = ?", source).Take(&cursor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.ReplicationCursor{Source: source}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// LockCursor retrieves the cursor of a source and locks it until the transaction ends, creating it
+// at offset 0 the first time
+func (r *ReplicationRepository) LockCursor(source string) (*entity.ReplicationCursor, error) {
+	err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.ReplicationCursor{Source: source}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor entity.ReplicationCursor
+	err = r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("source = ?", source).Take(&cursor).Error
+	if err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// SaveCursor saves a cursor
+func (r *ReplicationRepository) SaveCursor(cursor *entity.ReplicationCursor) error {
+	return r.db.Save(cursor).Error
+}
+
+// GetIDByExternalID retrieves the catalog ID of the row of model with an external ID, trashed or not
+func (r *ReplicationRepository) GetIDByExternalID(model interface{}, externalID string) (uint, error) {
+	var ids []uint
+	if err := r.db.Unscoped().Model(model).Where("external_id = ?", externalID).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return ids[0], nil
+}
+
+// GetIDsByExternalIDs retrieves the catalog IDs of the rows of model with the given external IDs,
+// trashed or not, leaving out external IDs without a row
+func (r *ReplicationRepository) GetIDsByExternalIDs(model interface{}, externalIDs []string) ([]uint, error) {
+	var ids []uint
+	if len(externalIDs) == 0 {
+		return ids, nil
+	}
+	err := r.db.Unscoped().Model(model).Where("external_id IN ?", externalIDs).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// UpsertAuthor restores and renames the author with an external ID, or creates it, returning its ID
+func (r *ReplicationRepository) UpsertAuthor(externalID, name string) (uint, error) {
+	return r.upsert(&entity.Author{}, externalID, map[string]interface{}{"name": name}, func(tx *gorm.DB) (uint, error) {
+		author := entity.Author{Name: name, ExternalID: externalID}
+		err := tx.Create(&author).Error
+		return author.ID, err
+	})
+}
+
+// UpsertReader restores and renames the reader with an external ID, or creates it, returning its ID
+func (r *ReplicationRepository) UpsertReader(externalID, name string) (uint, error) {
+	return r.upsert(&entity.Reader{}, externalID, map[string]interface{}{"name": name}, func(tx *gorm.DB) (uint, error) {
+		reader := entity.Reader{Name: name, ExternalID: externalID}
+		err := tx.Create(&reader).Error
+		return reader.ID, err
+	})
+}
+
+// UpsertGenre restores and renames the genre with an external ID, or creates it, returning its ID
+func (r *ReplicationRepository) UpsertGenre(externalID, name string) (uint, error) {
+	return r.upsert(&entity.Genre{}, externalID, map[string]interface{}{"name": name}, func(tx *gorm.DB) (uint, error) {
+		genre := entity.Genre{Name: name, ExternalID: externalID}
+		err := tx.Create(&genre).Error
+		return genre.ID, err
+	})
+}
+
+// UpsertAudiobook restores and updates the details of the audiobook with the external ID of audiobook,
+// or creates it, setting its ID. Its total duration, credits, genres and tracks are left alone.
+func (r *ReplicationRepository) UpsertAudiobook(audiobook *entity.Audiobook) error {
+	updates := map[string]interface{}{
+		"title":              audiobook.Title,
+		"author_id":          audiobook.AuthorID,
+		"reader_id":          audiobook.ReaderID,
+		"description":        audiobook.Description,
+		"image_url":          audiobook.ImageURL,
+		"language":           audiobook.Language,
+		"year_of_publishing": audiobook.YearOfPublishing,
+	}
+	id, err := r.upsert(&entity.Audiobook{}, audiobook.ExternalID, updates, func(tx *gorm.DB) (uint, error) {
+		err := tx.Omit(clause.Associations).Create(audiobook).Error
+		return audiobook.ID, err
+	})
+	audiobook.ID = id
+	return err
+}
+
+// UpsertTrack restores and updates the track with the external ID of track, or creates it, setting its
+// ID. The position is saved as given, without shifting the other tracks of the audiobook.
+func (r *ReplicationRepository) UpsertTrack(track *entity.Track) error {
+	updates := map[string]interface{}{
+		"audiobook_id":     track.AudiobookID,
+		"position":         track.Position,
+		"title":            track.Title,
+		"url":              track.URL,
+		"duration":         track.Duration,
+		"duration_seconds": track.DurationSeconds,
+	}
+	id, err := r.upsert(&entity.Track{}, track.ExternalID, updates, func(tx *gorm.DB) (uint, error) {
+		err := tx.Omit(clause.Associations).Create(track).Error
+		return track.ID, err
+	})
+	track.ID = id
+	return err
+}
+
+// ReplaceGenres sets the genres of an audiobook to the given ones, leaving out genres that do not
+// exist or are in the trash
+func (r *ReplicationRepository) ReplaceGenres(audiobookID uint, genreIDs []uint) error {
+	var genres []entity.Genre
+	if len(genreIDs) > 0 {
+		if err := r.db.Where("id IN ?", genreIDs).Find(&genres).Error; err != nil {
+			return err
+		}
+	}
+
+	association := r.db.Model(&entity.Audiobook{ID: audiobookID}).Association("Genres")
+	if len(genres) == 0 {
+		return association.Clear()
+	}
+	return association.Replace(genres)
+}
+
+// TrashTracksExcept moves the tracks of an audiobook other than the given ones to the trash
+func (r *ReplicationRepository) TrashTracksExcept(audiobookID uint, trackIDs []uint) error {
+	query := r.db.Where("audiobook_id = ?", audiobookID)
+	if len(trackIDs) > 0 {
+		query = query.Where("id NOT IN ?", trackIDs)
+	}
+	return query.Delete(&entity.Track{}).Error
+}
+
+// upsert restores the row of model with an external ID, trashed or not, and applies updates to it,
+// bumping its version, or creates it with create when there is none. It returns the ID of the row.
+func (r *ReplicationRepository) upsert(model interface{}, externalID string, updates map[string]interface{}, create func(tx *gorm.DB) (uint, error)) (uint, error) {
+	var id uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		if err := tx.Unscoped().Model(model).Where("external_id = ?", externalID).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+
+		if len(ids) == 0 {
+			var err error
+			id, err = create(tx)
+			return err
+		}
+
+		id = ids[0]
+		updates["deleted_at"] = nil
+		updates["version"] = gorm.Expr("version + 1")
+		return tx.Unscoped().Model(model).Where("id = ?", id).Updates(updates).Error
+	})
+	return id, err
+}
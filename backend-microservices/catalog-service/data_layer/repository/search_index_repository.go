@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// searchDocumentSQL builds the search document of the audiobooks matched by a condition on "a".
+// Title, the names of every credited author and reader, genres and description are weighted A to D.
+// Text is stemmed with the text search configuration named after the audiobook's language (e.g.
+// "English" uses english), falling back to simple; author and reader names are never stemmed.
+// Trashed authors, readers and genres are left out.
+const searchDocumentSQL = `
+	UPDATE audiobooks SET search_vector = doc.vector
+	FROM (
+		SELECT a.id,
+			setweight(to_tsvector(cfg.name, COALESCE(a.title, '')), 'A') ||
+			setweight(to_tsvector('simple', COALESCE(people.names, '')), 'B') ||
+			setweight(to_tsvector(cfg.name, COALESCE(genre_names.names, '')), 'C') ||
+			setweight(to_tsvector(cfg.name, COALESCE(a.description, '')), 'D') AS vector
+		FROM audiobooks a
+		LEFT JOIN LATERAL (
+			SELECT string_agg(credited.name, ' ') AS names
+			FROM (
+				SELECT au.name FROM authors au WHERE au.id = a.author_id AND au.deleted_at IS NULL
+				UNION
+				SELECT r.name FROM readers r WHERE r.id = a.reader_id AND r.deleted_at IS NULL
+				UNION
+				SELECT au.name FROM audiobook_authors aa JOIN authors au ON au.id = aa.author_id WHERE aa.audiobook_id = a.id AND au.deleted_at IS NULL
+				UNION
+				SELECT r.name FROM audiobook_readers ar JOIN readers r ON r.id = ar.reader_id WHERE ar.audiobook_id = a.id AND r.deleted_at IS NULL
+			) credited
+		) people ON true
+		CROSS JOIN LATERAL (
+			SELECT COALESCE(
+				(SELECT cfgname::text::regconfig FROM pg_ts_config WHERE cfgname = lower(a.language) LIMIT 1),
+				'simple'::regconfig
+			) AS name
+		) cfg
+		LEFT JOIN LATERAL (
+			SELECT string_agg(g.name, ' ') AS names
+			FROM audiobook_genres ag
+			JOIN genres g ON g.id = ag.genre_id
+			WHERE ag.audiobook_id = a.id AND g.deleted_at IS NULL
+		) genre_names ON true
+		WHERE `
+
+const searchDocumentSQLEnd = `
+	) doc
+	WHERE audiobooks.id = doc.id`
+
+// SearchIndexRepositoryInterface defines the contract for the audiobook full-text search index
+type SearchIndexRepositoryInterface interface {
+	RefreshAudiobooks(audiobookIDs []uint) error
+	RefreshByAuthorID(authorID uint) error
+	RefreshByReaderID(readerID uint) error
+	RefreshByGenreID(genreID uint) error
+	RefreshMissing() (int64, error)
+	RebuildAll() (int64, error)
+}
+
+// SearchIndexRepository implements SearchIndexRepositoryInterface
+type SearchIndexRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchIndexRepository creates a new search index repository
+func NewSearchIndexRepository(db *gorm.DB) SearchIndexRepositoryInterface {
+	return &SearchIndexRepository{db: db}
+}
+
+// RefreshAudiobooks rebuilds the search documents of the given audiobooks
+func (r *SearchIndexRepository) RefreshAudiobooks(audiobookIDs []uint) error {
+	if len(audiobookIDs) == 0 {
+		return nil
+	}
+	_, err := r.refresh("a.id IN ?", audiobookIDs)
+	return err
+}
+
+// RefreshByAuthorID rebuilds the search documents of every audiobook crediting an author
+func (r *SearchIndexRepository) RefreshByAuthorID(authorID uint) error {
+	_, err := r.refresh("(a.author_id = ? OR a.id IN (SELECT audiobook_id FROM audiobook_authors WHERE author_id = ?))", authorID, authorID)
+	return err
+}
+
+// RefreshByReaderID rebuilds the search documents of every audiobook crediting a reader
+func (r *SearchIndexRepository) RefreshByReaderID(readerID uint) error {
+	_, err := r.refresh("(a.reader_id = ? OR a.id IN (SELECT audiobook_id FROM audiobook_readers WHERE reader_id = ?))", readerID, readerID)
+	return err
+}
+
+// RefreshByGenreID rebuilds the search documents of every audiobook in a genre
+func (r *SearchIndexRepository) RefreshByGenreID(genreID uint) error {
+	_, err := r.refresh("a.id IN (SELECT audiobook_id FROM audiobook_genres WHERE genre_id = ?)", genreID)
+	return err
+}
+
+// RefreshMissing builds the search documents of audiobooks that have none yet
+func (r *SearchIndexRepository) RefreshMissing() (int64, error) {
+	return r.refresh("a.search_vector IS NULL")
+}
+
+// RebuildAll rebuilds the search document of every audiobook
+func (r *SearchIndexRepository) RebuildAll() (int64, error) {
+	return r.refresh("TRUE")
+}
+
+func (r *SearchIndexRepository) refresh(condition string, args ...interface{}) (int64, error) {
+	result := r.db.Exec(searchDocumentSQL+condition+searchDocumentSQLEnd, args...)
+	return result.RowsAffected, result.Error
+}
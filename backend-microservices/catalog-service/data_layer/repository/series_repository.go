@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"catalog-service/data_layer/entity"
+
+	"gorm.io/gorm"
+)
+
+// seriesSort lists the sort keys of series lists; popularity is the number of their volumes
+var seriesSort = listSort{
+	table:      "series",
+	defaultKey: "name",
+	keys: map[string][]sortColumn{
+		"id":         nil,
+		"name":       {{expression: "series.name", kind: sortText}},
+		"created_at": {{expression: "series.created_at", kind: sortTimestamp}},
+		"popularity": {{expression: "(SELECT COUNT(*) FROM series_volumes WHERE series_volumes.series_id = series.id AND audiobook_id IN (SELECT id FROM audiobooks WHERE deleted_at IS NULL))", kind: sortInteger}},
+	},
+}
+
+// SeriesRepositoryInterface defines the contract for series repository
+type SeriesRepositoryInterface interface {
+	Create(series *entity.Series) error
+	GetByID(id uint) (*entity.Series, error)
+	GetByIDWithVolumes(id uint) (*entity.Series, error)
+	GetAll(page ListPage) ([]entity.Series, PageResult, error)
+	Update(series *entity.Series) error
+	Delete(id uint) error
+	ReplaceVolumes(seriesID uint, volumes []entity.SeriesVolume) error
+}
+
+// SeriesRepository implements SeriesRepositoryInterface
+type SeriesRepository struct {
+	db *gorm.DB
+}
+
+// NewSeriesRepository creates a new series repository
+func NewSeriesRepository(db *gorm.DB) SeriesRepositoryInterface {
+	return &SeriesRepository{db: db}
+}
+
+// Create creates a new series
+func (r *SeriesRepository) Create(series *entity.Series) error {
+	return r.db.Omit("Volumes").Create(series).Error
+}
+
+// GetByID retrieves a series by ID
+func (r *SeriesRepository) GetByID(id uint) (*entity.Series, error) {
+	var series entity.Series
+	err := r.db.First(&series, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// GetByIDWithVolumes retrieves a series by ID with its volumes in order and their audiobooks.
+// Volumes of trashed audiobooks are left out.
+func (r *SeriesRepository) GetByIDWithVolumes(id uint) (*entity.Series, error) {
+	var series entity.Series
+	err := r.db.Preload("Volumes", func(db *gorm.DB) *gorm.DB {
+		return db.Where("series_volumes.audiobook_id IN (SELECT id FROM audiobooks WHERE deleted_at IS NULL)").
+			Order("series_volumes.position ASC").Order("series_volumes.id ASC")
+	}).Preload("Volumes.Audiobook").Preload("Volumes.Audiobook.Author").First(&series, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// GetAll retrieves one page of all series
+func (r *SeriesRepository) GetAll(page ListPage) ([]entity.Series, PageResult, error) {
+	var series []entity.Series
+	var result PageResult
+
+	order, err := seriesSort.resolve(page)
+	if err != nil {
+		return nil, result, err
+	}
+
+	// Count total records
+	if err := r.db.Model(&entity.Series{}).Count(&result.Total).Error; err != nil {
+		return nil, result, err
+	}
+
+	// Get the page
+	if err := order.apply(r.db.Model(&entity.Series{})).Find(&series).Error; err != nil {
+		return nil, result, err
+	}
+
+	if order.more(len(series)) {
+		series = series[:page.Limit]
+		if result.NextCursor, err = order.cursorAfter(r.db, series[len(series)-1].ID); err != nil {
+			return nil, result, err
+		}
+	}
+
+	return series, result, nil
+}
+
+// Update updates an existing series
+func (r *SeriesRepository) Update(series *entity.Series) error {
+	return r.db.Omit("Volumes").Save(series).Error
+}
+
+// Delete deletes a series and its volumes; the audiobooks themselves are kept
+func (r *SeriesRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", id).Delete(&entity.SeriesVolume{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.Series{}, id).Error
+	})
+}
+
+// ReplaceVolumes sets the volumes of a series in one transaction. Audiobooks that were volumes of
+// another series move to this one, since an audiobook belongs to at most one series.
+func (r *SeriesRepository) ReplaceVolumes(seriesID uint, volumes []entity.SeriesVolume) error {
+	audiobookIDs := make([]uint, len(volumes))
+	for i := range volumes {
+		volumes[i].SeriesID = seriesID
+		audiobookIDs[i] = volumes[i].AudiobookID
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Where("series_id = ?", seriesID)
+		if len(audiobookIDs) > 0 {
+			query = query.Or("audiobook_id IN ?", audiobookIDs)
+		}
+		if err := query.Delete(&entity.SeriesVolume{}).Error; err != nil {
+			return err
+		}
+
+		if len(volumes) == 0 {
+			return nil
+		}
+		return tx.Omit("Series", "Audiobook").Create(&volumes).Error
+	})
+}
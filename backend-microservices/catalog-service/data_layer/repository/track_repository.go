@@ -4,19 +4,38 @@ import (
 	"catalog-service/data_layer/entity"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// trackSort lists the sort keys of track lists; position is play order, audiobook by audiobook
+var trackSort = listSort{
+	table:      "tracks",
+	defaultKey: "position",
+	keys: map[string][]sortColumn{
+		"id":         nil,
+		"position":   {{expression: "tracks.audiobook_id", kind: sortInteger}, {expression: "tracks.position", kind: sortInteger}},
+		"title":      {{expression: "tracks.title", kind: sortText}},
+		"created_at": {{expression: "tracks.created_at", kind: sortTimestamp}},
+		"duration":   {{expression: "tracks.duration_seconds", kind: sortInteger}},
+	},
+}
+
 // TrackRepositoryInterface defines the contract for track repository
 type TrackRepositoryInterface interface {
 	Create(track *entity.Track) error
+	CreateAt(track *entity.Track, position int) error
 	GetByID(id uint) (*entity.Track, error)
 	GetByIDWithRelations(id uint) (*entity.Track, error)
-	GetAll(offset, limit int) ([]entity.Track, int64, error)
+	GetAll(filter DurationFilter, page ListPage) ([]entity.Track, PageResult, error)
 	Update(track *entity.Track) error
-	Delete(id uint) error
+	Delete(id, version uint) error
 	GetByAudiobookID(audiobookID uint) ([]entity.Track, error)
+	GetPageByAudiobookID(audiobookID uint, page ListPage) ([]entity.Track, PageResult, error)
 	SearchByTitle(query string, offset, limit int) ([]entity.Track, int64, error)
 	DeleteByAudiobookID(audiobookID uint) error
+	SumDurationByAudiobookID(audiobookID uint) (int, int64, error)
+	UpdatePositions(audiobookID uint, trackIDs []uint) error
+	LockPlayOrder(audiobookID uint) error
 }
 
 // TrackRepository implements TrackRepositoryInterface
@@ -29,9 +48,38 @@ func NewTrackRepository(db *gorm.DB) TrackRepositoryInterface {
 	return &TrackRepository{db: db}
 }
 
-// Create creates a new track
+// Create creates a new track at the end of its audiobook
 func (r *TrackRepository) Create(track *entity.Track) error {
-	return r.db.Create(track).Error
+	return r.CreateAt(track, 0)
+}
+
+// CreateAt creates a new track at a 1-based position of its audiobook, shifting later tracks back.
+// Positions outside the current range append the track.
+func (r *TrackRepository) CreateAt(track *entity.Track, position int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockPlayOrder(tx, track.AudiobookID); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&entity.Track{}).Where("audiobook_id = ?", track.AudiobookID).Count(&count).Error; err != nil {
+			return err
+		}
+
+		if position < 1 || position > int(count)+1 {
+			position = int(count) + 1
+		}
+
+		err := tx.Model(&entity.Track{}).
+			Where("audiobook_id = ? AND position >= ?", track.AudiobookID, position).
+			Update("position", gorm.Expr("position + 1")).Error
+		if err != nil {
+			return err
+		}
+
+		track.Position = position
+		return tx.Create(track).Error
+	})
 }
 
 // GetByID retrieves a track by ID
@@ -54,41 +102,81 @@ func (r *TrackRepository) GetByIDWithRelations(id uint) (*entity.Track, error) {
 	return &track, nil
 }
 
-// GetAll retrieves all tracks with pagination
-func (r *TrackRepository) GetAll(offset, limit int) ([]entity.Track, int64, error) {
-	var tracks []entity.Track
-	var total int64
-
-	// Count total records
-	if err := r.db.Model(&entity.Track{}).Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// Get paginated results
-	if err := r.db.Offset(offset).Limit(limit).Find(&tracks).Error; err != nil {
-		return nil, 0, err
-	}
-
-	return tracks, total, nil
+// GetAll retrieves one page of all tracks within a duration range
+func (r *TrackRepository) GetAll(filter DurationFilter, page ListPage) ([]entity.Track, PageResult, error) {
+	return r.getPage(filter.apply(r.db.Model(&entity.Track{}), "tracks.duration_seconds"), page)
 }
 
-// Update updates an existing track
+// Update updates an existing track if it is still at its version, bumping the version.
+// The position is left alone; the play order is changed with UpdatePositions.
 func (r *TrackRepository) Update(track *entity.Track) error {
-	return r.db.Save(track).Error
+	return updateVersioned(r.db, track, &track.Version, "position")
 }
 
-// Delete deletes a track by ID
-func (r *TrackRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Track{}, id).Error
+// Delete moves a track at version to the trash and closes the gap it leaves in the play order;
+// version 0 deletes any version. The trashed track keeps its old position, which a restore puts it back at.
+func (r *TrackRepository) Delete(id, version uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var track entity.Track
+		if err := tx.First(&track, id).Error; err != nil {
+			return err
+		}
+		if err := lockPlayOrder(tx, track.AudiobookID); err != nil {
+			return err
+		}
+
+		if err := deleteVersioned(tx, &entity.Track{}, id, version); err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.Track{}).
+			Where("audiobook_id = ? AND position > ?", track.AudiobookID, track.Position).
+			Update("position", gorm.Expr("position - 1")).Error
+	})
 }
 
-// GetByAudiobookID retrieves all tracks for a specific audiobook
+// GetByAudiobookID retrieves all tracks for a specific audiobook in play order
 func (r *TrackRepository) GetByAudiobookID(audiobookID uint) ([]entity.Track, error) {
 	var tracks []entity.Track
-	err := r.db.Where("audiobook_id = ?", audiobookID).Order("id ASC").Find(&tracks).Error
+	err := r.db.Where("audiobook_id = ?", audiobookID).Order("position ASC").Order("id ASC").Find(&tracks).Error
 	return tracks, err
 }
 
+// GetPageByAudiobookID retrieves one page of the tracks of an audiobook, in play order by default
+func (r *TrackRepository) GetPageByAudiobookID(audiobookID uint, page ListPage) ([]entity.Track, PageResult, error) {
+	return r.getPage(r.db.Model(&entity.Track{}).Where("tracks.audiobook_id = ?", audiobookID), page)
+}
+
+// getPage counts the tracks matched by query and fetches the requested page of them
+func (r *TrackRepository) getPage(query *gorm.DB, page ListPage) ([]entity.Track, PageResult, error) {
+	var tracks []entity.Track
+	var result PageResult
+
+	order, err := trackSort.resolve(page)
+	if err != nil {
+		return nil, result, err
+	}
+
+	// Count total records
+	if err := query.Count(&result.Total).Error; err != nil {
+		return nil, result, err
+	}
+
+	// Get the page
+	if err := order.apply(query).Find(&tracks).Error; err != nil {
+		return nil, result, err
+	}
+
+	if order.more(len(tracks)) {
+		tracks = tracks[:page.Limit]
+		if result.NextCursor, err = order.cursorAfter(r.db, tracks[len(tracks)-1].ID); err != nil {
+			return nil, result, err
+		}
+	}
+
+	return tracks, result, nil
+}
+
 // SearchByTitle searches tracks by title
 func (r *TrackRepository) SearchByTitle(query string, offset, limit int) ([]entity.Track, int64, error) {
 	var tracks []entity.Track
@@ -105,7 +193,7 @@ func (r *TrackRepository) SearchByTitle(query string, offset, limit int) ([]enti
 	}
 
 	// Get paginated results
-	if err := dbQuery.Offset(offset).Limit(limit).Find(&tracks).Error; err != nil {
+	if err := dbQuery.Order("audiobook_id ASC").Order("position ASC").Offset(offset).Limit(limit).Find(&tracks).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -116,3 +204,51 @@ func (r *TrackRepository) SearchByTitle(query string, offset, limit int) ([]enti
 func (r *TrackRepository) DeleteByAudiobookID(audiobookID uint) error {
 	return r.db.Where("audiobook_id = ?", audiobookID).Delete(&entity.Track{}).Error
 }
+
+// SumDurationByAudiobookID adds up the track durations of an audiobook, also returning how many tracks it has
+func (r *TrackRepository) SumDurationByAudiobookID(audiobookID uint) (int, int64, error) {
+	var result struct {
+		Seconds int
+		Tracks  int64
+	}
+	err := r.db.Model(&entity.Track{}).
+		Select("COALESCE(SUM(duration_seconds), 0) AS seconds, COUNT(*) AS tracks").
+		Where("audiobook_id = ?", audiobookID).
+		Scan(&result).Error
+	return result.Seconds, result.Tracks, err
+}
+
+// UpdatePositions numbers the tracks of an audiobook 1..n in the given order in one transaction
+func (r *TrackRepository) UpdatePositions(audiobookID uint, trackIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockPlayOrder(tx, audiobookID); err != nil {
+			return err
+		}
+		for i, trackID := range trackIDs {
+			err := tx.Model(&entity.Track{}).
+				Where("id = ? AND audiobook_id = ?", trackID, audiobookID).
+				Update("position", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// LockPlayOrder holds the play order of an audiobook until the surrounding transaction ends, so
+// tracks read afterwards cannot be renumbered by another write before they are saved
+func (r *TrackRepository) LockPlayOrder(audiobookID uint) error {
+	return lockPlayOrder(r.db, audiobookID)
+}
+
+// lockPlayOrder locks the audiobook row, trashed or not, until the transaction ends, so writes that
+// renumber the tracks of an audiobook take turns. Track positions are unique among the tracks not in
+// the trash; see migration.EnsureTrackPositions.
+func lockPlayOrder(tx *gorm.DB, audiobookID uint) error {
+	var ids []uint
+	return tx.Unscoped().Model(&entity.Audiobook{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", audiobookID).
+		Pluck("id", &ids).Error
+}
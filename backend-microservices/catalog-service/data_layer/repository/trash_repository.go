@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"catalog-service/data_layer/entity"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Trash types, one per soft-deletable catalog entity
+const (
+	TrashAudiobook = "audiobook"
+	TrashAuthor    = "author"
+	TrashReader    = "reader"
+	TrashGenre     = "genre"
+	TrashTrack     = "track"
+)
+
+// ErrInvalidTrashType is returned for trash types other than the ones above
+var ErrInvalidTrashType = errors.New("invalid trash type")
+
+// TrashedItem is a soft-deleted catalog row as listed in the trash. AudiobookID is only set for tracks.
+type TrashedItem struct {
+	ID          uint
+	Name        string
+	AudiobookID uint
+	DeletedAt   time.Time
+}
+
+// trashTable describes the table of a trash type: how its lists sort, the expressions selected
+// as an item's name and audiobook, and the model of its rows
+type trashTable struct {
+	sort        listSort
+	name        string
+	audiobookID string
+	model       func() interface{}
+}
+
+// trashTables lists every trash type; trash lists show the most recently deleted rows first by default
+var trashTables = map[string]trashTable{
+	TrashAudiobook: newTrashTable("audiobooks", "title", "0", func() interface{} { return &entity.Audiobook{} }),
+	TrashAuthor:    newTrashTable("authors", "name", "0", func() interface{} { return &entity.Author{} }),
+	TrashReader:    newTrashTable("readers", "name", "0", func() interface{} { return &entity.Reader{} }),
+	TrashGenre:     newTrashTable("genres", "name", "0", func() interface{} { return &entity.Genre{} }),
+	TrashTrack:     newTrashTable("tracks", "title", "tracks.audiobook_id", func() interface{} { return &entity.Track{} }),
+}
+
+func newTrashTable(table, nameColumn, audiobookID string, model func() interface{}) trashTable {
+	return trashTable{
+		sort: listSort{
+			table:      table,
+			defaultKey: "-deleted_at",
+			keys: map[string][]sortColumn{
+				"id":         nil,
+				"name":       {{expression: table + "." + nameColumn, kind: sortText}},
+				"deleted_at": {{expression: table + ".deleted_at", kind: sortTimestamp}},
+			},
+		},
+		name:        table + "." + nameColumn,
+		audiobookID: audiobookID,
+		model:       model,
+	}
+}
+
+// TrashRepositoryInterface defines the contract for listing, restoring and purging soft-deleted catalog rows
+type TrashRepositoryInterface interface {
+	List(trashType string, page ListPage) ([]TrashedItem, PageResult, error)
+	GetByID(trashType string, id uint) (*TrashedItem, error)
+	Restore(trashType string, id uint) error
+	Purge(trashType string, deletedBefore time.Time) (int64, error)
+}
+
+// TrashRepository implements TrashRepositoryInterface
+type TrashRepository struct {
+	db *gorm.DB
+}
+
+// NewTrashRepository creates a new trash repository
+func NewTrashRepository(db *gorm.DB) TrashRepositoryInterface {
+	return &TrashRepository{db: db}
+}
+
+// List retrieves one page of the trashed rows of a trash type
+func (r *TrashRepository) List(trashType string, page ListPage) ([]TrashedItem, PageResult, error) {
+	var items []TrashedItem
+	var result PageResult
+
+	table, ok := trashTables[trashType]
+	if !ok {
+		return nil, result, ErrInvalidTrashType
+	}
+
+	order, err := table.sort.resolve(page)
+	if err != nil {
+		return nil, result, err
+	}
+
+	query := r.trashed(table)
+
+	// Count total records
+	if err := query.Count(&result.Total).Error; err != nil {
+		return nil, result, err
+	}
+
+	// Get the page
+	err = order.apply(query).
+		Select(table.columns()).
+		Scan(&items).Error
+	if err != nil {
+		return nil, result, err
+	}
+
+	if order.more(len(items)) {
+		items = items[:page.Limit]
+		if result.NextCursor, err = order.cursorAfter(r.db, items[len(items)-1].ID); err != nil {
+			return nil, result, err
+		}
+	}
+
+	return items, result, nil
+}
+
+// GetByID retrieves a trashed row; rows that are not in the trash are not found
+func (r *TrashRepository) GetByID(trashType string, id uint) (*TrashedItem, error) {
+	table, ok := trashTables[trashType]
+	if !ok {
+		return nil, ErrInvalidTrashType
+	}
+
+	var items []TrashedItem
+	err := r.trashed(table).
+		Select(table.columns()).
+		Where(table.sort.table+".id = ?", id).
+		Limit(1).
+		Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &items[0], nil
+}
+
+// Restore takes a row out of the trash. A restored audiobook brings back the tracks trashed along
+// with it and its primary author and reader if they were trashed since; a restored track goes back
+// to its former position, shifting later tracks back.
+func (r *TrashRepository) Restore(trashType string, id uint) error {
+	switch trashType {
+	case TrashAudiobook:
+		return r.restoreAudiobook(id)
+	case TrashTrack:
+		return r.restoreTrack(id)
+	}
+
+	table, ok := trashTables[trashType]
+	if !ok {
+		return ErrInvalidTrashType
+	}
+
+	result := r.db.Unscoped().Model(table.model()).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *TrashRepository) restoreAudiobook(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Tracks deleted separately before the audiobook keep their own, earlier deletion time
+		err := tx.Unscoped().Model(&entity.Track{}).
+			Where("audiobook_id = ? AND deleted_at = (SELECT deleted_at FROM audiobooks WHERE id = ?)", id, id).
+			Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Model(&entity.Author{}).
+			Where("id = (SELECT author_id FROM audiobooks WHERE id = ?) AND deleted_at IS NOT NULL", id).
+			Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Unscoped().Model(&entity.Reader{}).
+			Where("id = (SELECT reader_id FROM audiobooks WHERE id = ?) AND deleted_at IS NOT NULL", id).
+			Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Model(&entity.Audiobook{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *TrashRepository) restoreTrack(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var track entity.Track
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&track, id).Error; err != nil {
+			return err
+		}
+		if err := lockPlayOrder(tx, track.AudiobookID); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&entity.Track{}).Where("audiobook_id = ?", track.AudiobookID).Count(&count).Error; err != nil {
+			return err
+		}
+
+		position := track.Position
+		if position < 1 || position > int(count)+1 {
+			position = int(count) + 1
+		}
+
+		err := tx.Model(&entity.Track{}).
+			Where("audiobook_id = ? AND position >= ?", track.AudiobookID, position).
+			Update("position", gorm.Expr("position + 1")).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&entity.Track{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"position":   position,
+			"deleted_at": nil,
+		}).Error
+	})
+}
+
+// Purge permanently deletes the rows of a trash type that were trashed before a point in time,
+// with everything that references them, and returns how many were deleted. Purged audiobooks take
+// their tracks, bookmarks, analytics and play sessions with them; authors and readers that are
+// still the primary author or reader of an audiobook, trashed or not, are kept.
+func (r *TrashRepository) Purge(trashType string, deletedBefore time.Time) (int64, error) {
+	table, ok := trashTables[trashType]
+	if !ok {
+		return 0, ErrInvalidTrashType
+	}
+
+	query := r.db.Unscoped().Model(table.model()).Where("deleted_at < ?", deletedBefore)
+	switch trashType {
+	case TrashAuthor:
+		query = query.Where("id NOT IN (SELECT author_id FROM audiobooks)")
+	case TrashReader:
+		query = query.Where("id NOT IN (SELECT reader_id FROM audiobooks)")
+	}
+
+	var ids []uint
+	if err := query.Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var references []string
+		switch trashType {
+		case TrashAudiobook:
+			references = []string{
+				"DELETE FROM audiobook_genres WHERE audiobook_id IN ?",
+				"DELETE FROM audiobook_authors WHERE audiobook_id IN ?",
+				"DELETE FROM audiobook_readers WHERE audiobook_id IN ?",
+				"DELETE FROM series_volumes WHERE audiobook_id IN ?",
+				"DELETE FROM analytics WHERE audiobook_id IN ?",
+				"DELETE FROM play_sessions WHERE audiobook_id IN ?",
+				"DELETE FROM queue_entries WHERE audiobook_id IN ?",
+				// Bookmarks go with their tracks
+				"DELETE FROM tracks WHERE audiobook_id IN ?",
+			}
+		case TrashTrack:
+			references = []string{"DELETE FROM queue_entries WHERE track_id IN ?"}
+		case TrashAuthor:
+			references = []string{"DELETE FROM audiobook_authors WHERE author_id IN ?"}
+		case TrashReader:
+			references = []string{"DELETE FROM audiobook_readers WHERE reader_id IN ?"}
+		case TrashGenre:
+			references = []string{"DELETE FROM audiobook_genres WHERE genre_id IN ?"}
+		}
+
+		for _, statement := range references {
+			if err := tx.Exec(statement, ids).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Where("id IN ?", ids).Delete(table.model()).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
+// columns selects the fields of a TrashedItem
+func (t trashTable) columns() string {
+	return t.sort.table + ".id AS id, " + t.name + " AS name, " + t.audiobookID + " AS audiobook_id, " + t.sort.table + ".deleted_at AS deleted_at"
+}
+
+// trashed selects the trashed rows of a table
+func (r *TrashRepository) trashed(table trashTable) *gorm.DB {
+	return r.db.Table(table.sort.table).Where(table.sort.table + ".deleted_at IS NOT NULL").Session(&gorm.Session{})
+}
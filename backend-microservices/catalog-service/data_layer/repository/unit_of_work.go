@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// Repositories are the catalog repositories, all bound to the same database handle
+type Repositories struct {
+	Audiobooks   AudiobookRepositoryInterface
+	Authors      AuthorRepositoryInterface
+	Readers      ReaderRepositoryInterface
+	Genres       GenreRepositoryInterface
+	Tracks       TrackRepositoryInterface
+	Queue        QueueRepositoryInterface
+	SearchIndex  SearchIndexRepositoryInterface
+	Replication  ReplicationRepositoryInterface
+	PlaySessions PlaySessionRepositoryInterface
+	Analytics    AnalyticsRepositoryInterface
+}
+
+// UnitOfWorkInterface defines the contract for running several repository calls atomically
+type UnitOfWorkInterface interface {
+	Do(fn func(repos *Repositories) error) error
+}
+
+// UnitOfWork implements UnitOfWorkInterface with database transactions
+type UnitOfWork struct {
+	db *gorm.DB
+}
+
+// NewUnitOfWork creates a new unit of work
+func NewUnitOfWork(db *gorm.DB) UnitOfWorkInterface {
+	return &UnitOfWork{db: db}
+}
+
+// Do calls fn with repositories bound to one transaction. The transaction is committed when fn
+// returns nil and rolled back when it returns an error, which Do returns unchanged, or panics.
+// Repository methods that run their own transaction become savepoints of this one.
+func (u *UnitOfWork) Do(fn func(repos *Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&Repositories{
+			Audiobooks:   NewAudiobookRepository(tx),
+			Authors:      NewAuthorRepository(tx),
+			Readers:      NewReaderRepository(tx),
+			Genres:       NewGenreRepository(tx),
+			Tracks:       NewTrackRepository(tx),
+			Queue:        NewQueueRepository(tx),
+			SearchIndex:  NewSearchIndexRepository(tx),
+			Replication:  NewReplicationRepository(tx),
+			PlaySessions: NewPlaySessionRepository(tx),
+			Analytics:    NewAnalyticsRepository(tx),
+		})
+	})
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrVersionConflict is returned when a row changed since the version a write expected
+var ErrVersionConflict = errors.New("version conflict")
+
+// updateVersioned saves every field of a model but the omitted ones if its row is still at *version,
+// and bumps the version. The row is left alone and ErrVersionConflict returned when it has changed
+// or gone since.
+func updateVersioned(db *gorm.DB, model interface{}, version *uint, omit ...string) error {
+	expected := *version
+	*version = expected + 1
+
+	result := db.Model(model).
+		Where("version = ?", expected).
+		Select("*").
+		Omit(append([]string{"id", "created_at", "deleted_at", clause.Associations}, omit...)...).
+		Updates(model)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = expected
+	}
+	return result.Error
+}
+
+// deleteVersioned moves a row to the trash if it is still at version; version 0 deletes any version
+func deleteVersioned(db *gorm.DB, model interface{}, id, version uint) error {
+	query := db.Where("id = ?", id)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+
+	result := query.Delete(model)
+	if result.Error == nil && result.RowsAffected == 0 && version != 0 {
+		result.Error = ErrVersionConflict
+	}
+	return result.Error
+}
+
+// bumpVersion bumps the version of a row whose associations change if it is still at version;
+// version 0 bumps any version
+func bumpVersion(db *gorm.DB, model interface{}, id, version uint) error {
+	query := db.Model(model).Where("id = ?", id)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+
+	result := query.Update("version", gorm.Expr("version + 1"))
+	if result.Error == nil && result.RowsAffected == 0 && version != 0 {
+		result.Error = ErrVersionConflict
+	}
+	return result.Error
+}
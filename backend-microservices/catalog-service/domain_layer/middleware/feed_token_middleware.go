@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"catalog-service/domain_layer/service"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireFeedTokenMiddleware authorizes requests from podcast apps by the private feed token in the
+// :token route parameter, since those apps cannot send an Authorization header
+func RequireFeedTokenMiddleware(feedService *service.FeedService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := feedService.Authenticate(c.Param("token"))
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, service.ErrInvalidFeedToken) {
+				status = http.StatusForbidden
+			}
+			log.Printf("Feed Token Middleware: Rejected request: %v", err)
+			c.AbortWithStatusJSON(status, gin.H{
+				"error":   http.StatusText(status),
+				"details": err.Error(),
+				"source":  "feed_token_middleware",
+			})
+			return
+		}
+
+		// Store information in context for later use
+		c.Set("user_id", userID)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireReplicationAPIKeyMiddleware authorizes content-management-service by the replication API key
+// in the X-API-Key header. No key is accepted when none is configured.
+func RequireReplicationAPIKeyMiddleware(apiKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := c.GetHeader("X-API-Key")
+		if apiKey == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(apiKey)) != 1 {
+			log.Printf("Replication Middleware: Rejected request from %s", c.ClientIP())
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"details": "Invalid API key",
+				"source":  "replication_middleware",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"catalog-service/domain_layer/service"
+	"catalog-service/helpers/signing"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireSignedStreamURLMiddleware rejects stream requests whose URL signature is missing, tampered or expired
+// The track ID is taken from the :id route parameter
+func RequireSignedStreamURLMiddleware(streamURLService *service.StreamURLService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		trackID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid track ID"})
+			return
+		}
+
+		userID, err := streamURLService.Verify(uint(trackID), c.Request.URL.Query())
+		if err != nil {
+			log.Printf("Signed URL Middleware: Rejected stream of track %d: %v", trackID, err)
+
+			status := http.StatusForbidden
+			if errors.Is(err, signing.ErrSignatureMissing) {
+				status = http.StatusUnauthorized
+			}
+			c.AbortWithStatusJSON(status, gin.H{
+				"error":   http.StatusText(status),
+				"details": err.Error(),
+				"source":  "signed_url_middleware",
+			})
+			return
+		}
+
+		// Store information in context for later use
+		c.Set("user_id", userID)
+
+		c.Next()
+	}
+}
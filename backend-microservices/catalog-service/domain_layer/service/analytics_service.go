@@ -60,12 +60,9 @@ func (s *AnalyticsService) GetAnalyticsByID(id uint) (*dto.AnalyticsResponse, er
 }
 
 // GetAllAnalytics retrieves all analytics with pagination
-func (s *AnalyticsService) GetAllAnalytics(req dto.PaginationRequest) (*dto.ListResponse, error) {
-	// Calculate offset
-	offset := (req.Page - 1) * req.Limit
-
+func (s *AnalyticsService) GetAllAnalytics(req dto.ListRequest) (*dto.ListResponse, error) {
 	// Get paginated results
-	analytics, total, err := s.analyticsRepo.GetAll(offset, req.Limit)
+	analytics, result, err := s.analyticsRepo.GetAll(listPage(req))
 	if err != nil {
 		return nil, err
 	}
@@ -82,20 +79,9 @@ func (s *AnalyticsService) GetAllAnalytics(req dto.PaginationRequest) (*dto.List
 		})
 	}
 
-	// Calculate total pages
-	totalPages := int(total) / req.Limit
-	if int(total)%req.Limit > 0 {
-		totalPages++
-	}
-
 	return &dto.ListResponse{
-		Items: analyticsResponses,
-		Pagination: dto.PaginationResponse{
-			Page:       req.Page,
-			Limit:      req.Limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
+		Items:      analyticsResponses,
+		Pagination: listPagination(req, result),
 	}, nil
 }
 
@@ -113,12 +99,9 @@ func (s *AnalyticsService) DeleteAnalytics(id uint) error {
 }
 
 // GetAnalyticsByUserID retrieves analytics by user ID
-func (s *AnalyticsService) GetAnalyticsByUserID(userID string, req dto.PaginationRequest) (*dto.ListResponse, error) {
-	// Calculate offset
-	offset := (req.Page - 1) * req.Limit
-
+func (s *AnalyticsService) GetAnalyticsByUserID(userID string, req dto.ListRequest) (*dto.ListResponse, error) {
 	// Get paginated results
-	analytics, total, err := s.analyticsRepo.GetByUserID(userID, offset, req.Limit)
+	analytics, result, err := s.analyticsRepo.GetByUserID(userID, listPage(req))
 	if err != nil {
 		return nil, err
 	}
@@ -135,30 +118,16 @@ func (s *AnalyticsService) GetAnalyticsByUserID(userID string, req dto.Paginatio
 		})
 	}
 
-	// Calculate total pages
-	totalPages := int(total) / req.Limit
-	if int(total)%req.Limit > 0 {
-		totalPages++
-	}
-
 	return &dto.ListResponse{
-		Items: analyticsResponses,
-		Pagination: dto.PaginationResponse{
-			Page:       req.Page,
-			Limit:      req.Limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
+		Items:      analyticsResponses,
+		Pagination: listPagination(req, result),
 	}, nil
 }
 
 // GetAnalyticsByAudiobookID retrieves analytics by audiobook ID
-func (s *AnalyticsService) GetAnalyticsByAudiobookID(audiobookID uint, req dto.PaginationRequest) (*dto.ListResponse, error) {
-	// Calculate offset
-	offset := (req.Page - 1) * req.Limit
-
+func (s *AnalyticsService) GetAnalyticsByAudiobookID(audiobookID uint, req dto.ListRequest) (*dto.ListResponse, error) {
 	// Get paginated results
-	analytics, total, err := s.analyticsRepo.GetByAudiobookID(audiobookID, offset, req.Limit)
+	analytics, result, err := s.analyticsRepo.GetByAudiobookID(audiobookID, listPage(req))
 	if err != nil {
 		return nil, err
 	}
@@ -175,20 +144,9 @@ func (s *AnalyticsService) GetAnalyticsByAudiobookID(audiobookID uint, req dto.P
 		})
 	}
 
-	// Calculate total pages
-	totalPages := int(total) / req.Limit
-	if int(total)%req.Limit > 0 {
-		totalPages++
-	}
-
 	return &dto.ListResponse{
-		Items: analyticsResponses,
-		Pagination: dto.PaginationResponse{
-			Page:       req.Page,
-			Limit:      req.Limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
+		Items:      analyticsResponses,
+		Pagination: listPagination(req, result),
 	}, nil
 }
 
@@ -198,12 +156,9 @@ func (s *AnalyticsService) GetAnalyticsSummary(audiobookID uint) (map[string]int
 }
 
 // GetAnalyticsByDateRange retrieves analytics within a date range
-func (s *AnalyticsService) GetAnalyticsByDateRange(startDate, endDate time.Time, req dto.PaginationRequest) (*dto.ListResponse, error) {
-	// Calculate offset
-	offset := (req.Page - 1) * req.Limit
-
+func (s *AnalyticsService) GetAnalyticsByDateRange(startDate, endDate time.Time, req dto.ListRequest) (*dto.ListResponse, error) {
 	// Get paginated results
-	analytics, total, err := s.analyticsRepo.GetByDateRange(startDate, endDate, offset, req.Limit)
+	analytics, result, err := s.analyticsRepo.GetByDateRange(startDate, endDate, listPage(req))
 	if err != nil {
 		return nil, err
 	}
@@ -220,20 +175,9 @@ func (s *AnalyticsService) GetAnalyticsByDateRange(startDate, endDate time.Time,
 		})
 	}
 
-	// Calculate total pages
-	totalPages := int(total) / req.Limit
-	if int(total)%req.Limit > 0 {
-		totalPages++
-	}
-
 	return &dto.ListResponse{
-		Items: analyticsResponses,
-		Pagination: dto.PaginationResponse{
-			Page:       req.Page,
-			Limit:      req.Limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
+		Items:      analyticsResponses,
+		Pagination: listPagination(req, result),
 	}, nil
 }
 
@@ -243,22 +187,19 @@ func (s *AnalyticsService) CreateAnalyticsEvent(userID string, req dto.CreateAna
 }
 
 // GetAnalyticsByUser retrieves analytics by user ID (alias for GetAnalyticsByUserID)
-func (s *AnalyticsService) GetAnalyticsByUser(userID string, req dto.PaginationRequest) (*dto.ListResponse, error) {
+func (s *AnalyticsService) GetAnalyticsByUser(userID string, req dto.ListRequest) (*dto.ListResponse, error) {
 	return s.GetAnalyticsByUserID(userID, req)
 }
 
 // GetAnalyticsByAudiobook retrieves analytics by audiobook ID (alias for GetAnalyticsByAudiobookID)
-func (s *AnalyticsService) GetAnalyticsByAudiobook(audiobookID uint, req dto.PaginationRequest) (*dto.ListResponse, error) {
+func (s *AnalyticsService) GetAnalyticsByAudiobook(audiobookID uint, req dto.ListRequest) (*dto.ListResponse, error) {
 	return s.GetAnalyticsByAudiobookID(audiobookID, req)
 }
 
 // GetAnalyticsByEventType retrieves analytics by event type
-func (s *AnalyticsService) GetAnalyticsByEventType(eventType string, req dto.PaginationRequest) (*dto.ListResponse, error) {
-	// Calculate offset
-	offset := (req.Page - 1) * req.Limit
-
+func (s *AnalyticsService) GetAnalyticsByEventType(eventType string, req dto.ListRequest) (*dto.ListResponse, error) {
 	// Get paginated results
-	analytics, total, err := s.analyticsRepo.GetByEventType(eventType, offset, req.Limit)
+	analytics, result, err := s.analyticsRepo.GetByEventType(eventType, listPage(req))
 	if err != nil {
 		return nil, err
 	}
@@ -275,19 +216,8 @@ func (s *AnalyticsService) GetAnalyticsByEventType(eventType string, req dto.Pag
 		})
 	}
 
-	// Calculate total pages
-	totalPages := int(total) / req.Limit
-	if int(total)%req.Limit > 0 {
-		totalPages++
-	}
-
 	return &dto.ListResponse{
-		Items: analyticsResponses,
-		Pagination: dto.PaginationResponse{
-			Page:       req.Page,
-			Limit:      req.Limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
+		Items:      analyticsResponses,
+		Pagination: listPagination(req, result),
 	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"catalog-service/data_layer/dto"
 	"catalog-service/data_layer/entity"
 	"catalog-service/data_layer/repository"
+	"catalog-service/helpers/duration"
 	"errors"
 	"fmt"
 
@@ -14,70 +15,78 @@ type AudiobookService struct {
 	audiobookRepo repository.AudiobookRepositoryInterface
 	authorRepo    repository.AuthorRepositoryInterface
 	readerRepo    repository.ReaderRepositoryInterface
-	genreRepo     repository.GenreRepositoryInterface
-	trackRepo     repository.TrackRepositoryInterface
-	analyticsRepo repository.AnalyticsRepositoryInterface
+
+	defaultSearchLanguage string
+
+	unitOfWork       repository.UnitOfWorkInterface
+	streamURLService *StreamURLService
 }
 
 func NewAudiobookService(
 	audiobookRepo repository.AudiobookRepositoryInterface,
 	authorRepo repository.AuthorRepositoryInterface,
 	readerRepo repository.ReaderRepositoryInterface,
-	genreRepo repository.GenreRepositoryInterface,
-	trackRepo repository.TrackRepositoryInterface,
-	analyticsRepo repository.AnalyticsRepositoryInterface,
+	defaultSearchLanguage string,
+	unitOfWork repository.UnitOfWorkInterface,
+	streamURLService *StreamURLService,
 ) *AudiobookService {
 	return &AudiobookService{
 		audiobookRepo: audiobookRepo,
 		authorRepo:    authorRepo,
 		readerRepo:    readerRepo,
-		genreRepo:     genreRepo,
-		trackRepo:     trackRepo,
-		analyticsRepo: analyticsRepo,
+
+		defaultSearchLanguage: defaultSearchLanguage,
+
+		unitOfWork:       unitOfWork,
+		streamURLService: streamURLService,
 	}
 }
 
-// CreateAudiobook creates a new audiobook
-func (s *AudiobookService) CreateAudiobook(req dto.CreateAudiobookRequest) (*dto.AudiobookResponse, error) {
-	// Validate author exists
-	_, err := s.authorRepo.GetByID(req.AuthorID)
+// CreateAudiobook creates a new audiobook with its primary credits and genres in one transaction
+func (s *AudiobookService) CreateAudiobook(userID string, req dto.CreateAudiobookRequest) (*dto.AudiobookResponse, error) {
+	// Until tracks are added the hand-entered total is kept
+	totalSeconds, err := duration.Parse(req.TotalDuration)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("author not found")
-		}
-		return nil, err
+		return nil, errors.New("invalid total_duration")
 	}
 
-	// Validate reader exists
-	_, err = s.readerRepo.GetByID(req.ReaderID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("reader not found")
+	audiobook := entity.Audiobook{
+		Title:                req.Title,
+		AuthorID:             req.AuthorID,
+		ReaderID:             req.ReaderID,
+		Description:          req.Description,
+		ImageURL:             req.ImageURL,
+		Language:             req.Language,
+		YearOfPublishing:     req.YearOfPublishing,
+		TotalDuration:        formatDuration(totalSeconds),
+		TotalDurationSeconds: totalSeconds,
+	}
+
+	err = s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		if err := validateAuthorAndReader(repos, req.AuthorID, req.ReaderID); err != nil {
+			return err
 		}
-		return nil, err
-	}
 
-	// Create audiobook
-	audiobook := entity.Audiobook{
-		Title:            req.Title,
-		AuthorID:         req.AuthorID,
-		ReaderID:         req.ReaderID,
-		Description:      req.Description,
-		ImageURL:         req.ImageURL,
-		Language:         req.Language,
-		YearOfPublishing: req.YearOfPublishing,
-		TotalDuration:    req.TotalDuration,
-	}
+		if err := repos.Audiobooks.Create(&audiobook); err != nil {
+			return err
+		}
 
-	if err := s.audiobookRepo.Create(&audiobook); err != nil {
-		return nil, err
-	}
+		// Credit the author and reader as the primary author and narrator
+		if err := repos.Audiobooks.SyncPrimaryCredits(audiobook.ID, audiobook.AuthorID, audiobook.ReaderID); err != nil {
+			return err
+		}
 
-	// Associate genres if provided
-	if len(req.GenreIDs) > 0 {
-		if err := s.audiobookRepo.AssignGenres(audiobook.ID, req.GenreIDs); err != nil {
-			return nil, err
+		// Associate genres if provided
+		if len(req.GenreIDs) > 0 {
+			if err := repos.Audiobooks.ReplaceGenres(audiobook.ID, req.GenreIDs); err != nil {
+				return err
+			}
 		}
+
+		return repos.SearchIndex.RefreshAudiobooks([]uint{audiobook.ID})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Get audiobook with relations for response
@@ -86,11 +95,11 @@ func (s *AudiobookService) CreateAudiobook(req dto.CreateAudiobookRequest) (*dto
 		return nil, err
 	}
 
-	return s.convertToAudiobookResponse(audiobookWithRelations), nil
+	return s.convertToAudiobookResponse(audiobookWithRelations, userID), nil
 }
 
 // GetAudiobookByID retrieves an audiobook by ID with all relationships
-func (s *AudiobookService) GetAudiobookByID(id uint) (*dto.AudiobookResponse, error) {
+func (s *AudiobookService) GetAudiobookByID(userID string, id uint) (*dto.AudiobookResponse, error) {
 	audiobook, err := s.audiobookRepo.GetByIDWithRelations(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -99,7 +108,7 @@ func (s *AudiobookService) GetAudiobookByID(id uint) (*dto.AudiobookResponse, er
 		return nil, err
 	}
 
-	return s.convertToAudiobookResponse(audiobook), nil
+	return s.convertToAudiobookResponse(audiobook, userID), nil
 }
 
 // GetAllAudiobooks retrieves all audiobooks with pagination
@@ -136,121 +145,174 @@ func (s *AudiobookService) GetAllAudiobooks(req dto.PaginationRequest) (*dto.Lis
 	}, nil
 }
 
-// UpdateAudiobook updates an existing audiobook
-func (s *AudiobookService) UpdateAudiobook(id uint, req dto.UpdateAudiobookRequest) (*dto.AudiobookResponse, error) {
-	// Get existing audiobook
-	audiobook, err := s.audiobookRepo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("audiobook not found")
-		}
-		return nil, err
+// UpdateAudiobook updates every field of an audiobook at version, its primary credits and genres
+// in one transaction; genres are only replaced when genre IDs are given
+func (s *AudiobookService) UpdateAudiobook(userID string, id, version uint, req dto.UpdateAudiobookRequest) (*dto.AudiobookResponse, error) {
+	patch := dto.PatchAudiobookRequest{
+		Title:            &req.Title,
+		AuthorID:         &req.AuthorID,
+		ReaderID:         &req.ReaderID,
+		Description:      &req.Description,
+		ImageURL:         &req.ImageURL,
+		Language:         &req.Language,
+		YearOfPublishing: &req.YearOfPublishing,
+		TotalDuration:    &req.TotalDuration,
+	}
+	if len(req.GenreIDs) > 0 {
+		patch.GenreIDs = &req.GenreIDs
 	}
 
-	// Validate author exists
-	_, err = s.authorRepo.GetByID(req.AuthorID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("author not found")
+	return s.PatchAudiobook(userID, id, version, patch)
+}
+
+// PatchAudiobook updates the given fields of an audiobook at version, its primary credits and
+// genres in one transaction
+func (s *AudiobookService) PatchAudiobook(userID string, id, version uint, req dto.PatchAudiobookRequest) (*dto.AudiobookResponse, error) {
+	err := s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		// Get existing audiobook
+		audiobook, err := repos.Audiobooks.GetByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("audiobook not found")
+			}
+			return err
 		}
-		return nil, err
-	}
 
-	// Validate reader exists
-	_, err = s.readerRepo.GetByID(req.ReaderID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("reader not found")
+		if err := checkVersion(audiobook.Version, version); err != nil {
+			return err
+		}
+
+		// Update audiobook fields
+		if req.Title != nil {
+			audiobook.Title = *req.Title
+		}
+		if req.AuthorID != nil {
+			audiobook.AuthorID = *req.AuthorID
+		}
+		if req.ReaderID != nil {
+			audiobook.ReaderID = *req.ReaderID
+		}
+		if req.Description != nil {
+			audiobook.Description = *req.Description
+		}
+		if req.ImageURL != nil {
+			audiobook.ImageURL = *req.ImageURL
+		}
+		if req.Language != nil {
+			audiobook.Language = *req.Language
+		}
+		if req.YearOfPublishing != nil {
+			audiobook.YearOfPublishing = *req.YearOfPublishing
 		}
-		return nil, err
-	}
 
-	// Update audiobook fields
-	audiobook.Title = req.Title
-	audiobook.AuthorID = req.AuthorID
-	audiobook.ReaderID = req.ReaderID
-	audiobook.Description = req.Description
-	audiobook.ImageURL = req.ImageURL
-	audiobook.Language = req.Language
-	audiobook.YearOfPublishing = req.YearOfPublishing
-	audiobook.TotalDuration = req.TotalDuration
+		if err := validateAuthorAndReader(repos, audiobook.AuthorID, audiobook.ReaderID); err != nil {
+			return err
+		}
 
-	if err := s.audiobookRepo.Update(audiobook); err != nil {
-		return nil, err
-	}
+		// The total is derived from the tracks once there are any
+		totalSeconds, trackCount, err := repos.Tracks.SumDurationByAudiobookID(audiobook.ID)
+		if err != nil {
+			return err
+		}
+		if trackCount == 0 {
+			totalSeconds = audiobook.TotalDurationSeconds
+			if req.TotalDuration != nil {
+				totalSeconds, err = duration.Parse(*req.TotalDuration)
+				if err != nil {
+					return errors.New("invalid total_duration")
+				}
+			}
+		}
+		audiobook.TotalDuration = formatDuration(totalSeconds)
+		audiobook.TotalDurationSeconds = totalSeconds
 
-	// Update genres if provided
-	if len(req.GenreIDs) > 0 {
-		// Remove all existing genres first
-		if err := s.audiobookRepo.RemoveGenres(audiobook.ID, []uint{}); err != nil {
-			return nil, err
+		if err := repos.Audiobooks.Update(audiobook); err != nil {
+			return err
 		}
-		// Add new genres
-		if err := s.audiobookRepo.AssignGenres(audiobook.ID, req.GenreIDs); err != nil {
-			return nil, err
+
+		// A changed author or reader replaces the primary credit, keeping co-credits
+		if err := repos.Audiobooks.SyncPrimaryCredits(audiobook.ID, audiobook.AuthorID, audiobook.ReaderID); err != nil {
+			return err
+		}
+
+		// Replace the genres if provided
+		if req.GenreIDs != nil {
+			if err := repos.Audiobooks.ReplaceGenres(audiobook.ID, *req.GenreIDs); err != nil {
+				return err
+			}
 		}
+
+		return repos.SearchIndex.RefreshAudiobooks([]uint{audiobook.ID})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Get updated audiobook with relations
-	updatedAudiobook, err := s.audiobookRepo.GetByIDWithRelations(audiobook.ID)
+	updatedAudiobook, err := s.audiobookRepo.GetByIDWithRelations(id)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.convertToAudiobookResponse(updatedAudiobook), nil
+	return s.convertToAudiobookResponse(updatedAudiobook, userID), nil
 }
 
-// DeleteAudiobook deletes an audiobook
-func (s *AudiobookService) DeleteAudiobook(id uint) error {
-	// Check if audiobook exists
-	_, err := s.audiobookRepo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("audiobook not found")
+// DeleteAudiobook moves an audiobook at version and its tracks to the trash
+func (s *AudiobookService) DeleteAudiobook(id, version uint) error {
+	return s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		// Check if audiobook exists
+		audiobook, err := repos.Audiobooks.GetByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("audiobook not found")
+			}
+			return err
 		}
-		return err
-	}
-
-	// ✅ Clean up all related data before deleting audiobook
 
-	// 1. Remove all genre associations
-	if err := s.audiobookRepo.RemoveAllGenres(id); err != nil {
-		return fmt.Errorf("failed to remove genre associations: %v", err)
-	}
-
-	// 2. Delete all tracks for this audiobook
-	if err := s.trackRepo.DeleteByAudiobookID(id); err != nil {
-		return fmt.Errorf("failed to delete tracks: %v", err)
-	}
-
-	// 3. Delete all analytics records for this audiobook
-	if err := s.analyticsRepo.DeleteByAudiobookID(id); err != nil {
-		return fmt.Errorf("failed to delete analytics: %v", err)
-	}
+		if err := checkVersion(audiobook.Version, version); err != nil {
+			return err
+		}
 
-	// 4. Finally delete the audiobook itself
-	if err := s.audiobookRepo.Delete(id); err != nil {
-		return fmt.Errorf("failed to delete audiobook: %v", err)
-	}
+		// Move the audiobook and its tracks to the trash; genres, credits, the series volume and
+		// analytics stay attached so that a restore brings everything back
+		if err := repos.Audiobooks.Delete(id, version); err != nil {
+			if errors.Is(err, repository.ErrVersionConflict) {
+				return err
+			}
+			return fmt.Errorf("failed to delete audiobook: %v", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
-// SearchAudiobooks searches audiobooks by title
-func (s *AudiobookService) SearchAudiobooks(req dto.SearchRequest) (*dto.ListResponse, error) {
+// SearchAudiobooks runs a full-text search over titles, descriptions, authors, readers and genres
+func (s *AudiobookService) SearchAudiobooks(req dto.AudiobookSearchRequest) (*dto.ListResponse, error) {
 	// Calculate offset
 	offset := (req.Page - 1) * req.Limit
 
+	language := req.Language
+	if language == "" {
+		language = s.defaultSearchLanguage
+	}
+
 	// Get paginated results
-	audiobooks, total, err := s.audiobookRepo.SearchByTitle(req.Query, offset, req.Limit)
+	hits, total, err := s.audiobookRepo.Search(req.Query, language, offset, req.Limit)
 	if err != nil {
 		return nil, err
 	}
 
 	// Convert to response format
-	var audiobookResponses []dto.AudiobookListResponse
-	for _, audiobook := range audiobooks {
-		audiobookResponses = append(audiobookResponses, s.convertToAudiobookListResponse(&audiobook))
+	searchResults := []dto.AudiobookSearchResult{}
+	for _, hit := range hits {
+		searchResults = append(searchResults, dto.AudiobookSearchResult{
+			AudiobookListResponse: s.convertToAudiobookListResponse(&hit.Audiobook),
+			Rank:                  hit.Rank,
+			Highlights: dto.AudiobookHighlights{
+				Title:       hit.TitleHighlight,
+				Description: hit.DescriptionHighlight,
+			},
+		})
 	}
 
 	// Calculate total pages
@@ -260,7 +322,7 @@ func (s *AudiobookService) SearchAudiobooks(req dto.SearchRequest) (*dto.ListRes
 	}
 
 	return &dto.ListResponse{
-		Items: audiobookResponses,
+		Items: searchResults,
 		Pagination: dto.PaginationResponse{
 			Page:       req.Page,
 			Limit:      req.Limit,
@@ -305,15 +367,17 @@ func (s *AudiobookService) GetAudiobooksByAuthorID(authorID uint, req dto.Pagina
 }
 
 // Helper methods
-func (s *AudiobookService) convertToAudiobookResponse(audiobook *entity.Audiobook) *dto.AudiobookResponse {
+func (s *AudiobookService) convertToAudiobookResponse(audiobook *entity.Audiobook, userID string) *dto.AudiobookResponse {
 	response := &dto.AudiobookResponse{
-		ID:               audiobook.ID,
-		Title:            audiobook.Title,
-		Description:      audiobook.Description,
-		ImageURL:         audiobook.ImageURL,
-		Language:         audiobook.Language,
-		YearOfPublishing: audiobook.YearOfPublishing,
-		TotalDuration:    audiobook.TotalDuration,
+		ID:                   audiobook.ID,
+		Title:                audiobook.Title,
+		Description:          audiobook.Description,
+		ImageURL:             audiobook.ImageURL,
+		Language:             audiobook.Language,
+		YearOfPublishing:     audiobook.YearOfPublishing,
+		TotalDuration:        audiobook.TotalDuration,
+		TotalDurationSeconds: audiobook.TotalDurationSeconds,
+		Version:              audiobook.Version,
 		Author: dto.AuthorResponse{
 			ID:   audiobook.Author.ID,
 			Name: audiobook.Author.Name,
@@ -332,14 +396,33 @@ func (s *AudiobookService) convertToAudiobookResponse(audiobook *entity.Audioboo
 		})
 	}
 
+	// Convert credits
+	response.Authors = []dto.CreditResponse{}
+	for _, credit := range audiobook.Authors {
+		if credit.Author != nil {
+			response.Authors = append(response.Authors, dto.CreditResponse{ID: credit.AuthorID, Name: credit.Author.Name, Role: credit.Role})
+		}
+	}
+	response.Readers = []dto.CreditResponse{}
+	for _, credit := range audiobook.Readers {
+		if credit.Reader != nil {
+			response.Readers = append(response.Readers, dto.CreditResponse{ID: credit.ReaderID, Name: credit.Reader.Name, Role: credit.Role})
+		}
+	}
+
+	// Convert series volume
+	if volume := audiobook.SeriesVolume; volume != nil && volume.Series != nil {
+		response.Series = &dto.AudiobookSeriesResponse{
+			ID:     volume.SeriesID,
+			Name:   volume.Series.Name,
+			Volume: volume.Position,
+			Label:  volume.Label,
+		}
+	}
+
 	// Convert tracks
 	for _, track := range audiobook.Tracks {
-		response.Tracks = append(response.Tracks, dto.TrackResponse{
-			ID:       track.ID,
-			Title:    track.Title,
-			URL:      track.URL,
-			Duration: track.Duration,
-		})
+		response.Tracks = append(response.Tracks, convertToTrackResponse(&track, s.streamURLService, userID))
 	}
 
 	return response
@@ -374,38 +457,29 @@ func (s *AudiobookService) convertToAudiobookListResponse(audiobook *entity.Audi
 	}
 
 	return dto.AudiobookListResponse{
-		ID:                audiobook.ID,
-		Title:             audiobook.Title,
-		Author:            author,
-		Reader:            reader,
-		ImageURL:          audiobook.ImageURL,
-		Language:          audiobook.Language,
-		YearOfPublishing:  audiobook.YearOfPublishing,  // Tambahkan ini
-		TotalDuration:     audiobook.TotalDuration,
-		Genres:            genres,
+		ID:                   audiobook.ID,
+		Title:                audiobook.Title,
+		Author:               author,
+		Reader:               reader,
+		ImageURL:             audiobook.ImageURL,
+		Language:             audiobook.Language,
+		YearOfPublishing:     audiobook.YearOfPublishing, // Tambahkan ini
+		TotalDuration:        audiobook.TotalDuration,
+		TotalDurationSeconds: audiobook.TotalDurationSeconds,
+		Genres:               genres,
 	}
 }
 
-// GetAudiobooks retrieves audiobooks with filtering options
-func (s *AudiobookService) GetAudiobooks(filter dto.AudiobookFilter, page, limit int) (*dto.ListResponse, error) {
-	// Calculate offset
-	offset := (page - 1) * limit
-
-	var audiobooks []entity.Audiobook
-	var total int64
-	var err error
+// GetAudiobooks retrieves audiobooks matching every filter option, with facet counts
+func (s *AudiobookService) GetAudiobooks(filter dto.AudiobookFilter, req dto.ListRequest) (*dto.AudiobookFacetedListResponse, error) {
+	listFilter := toAudiobookListFilter(filter)
 
-	// Apply filters based on the provided filter criteria
-	if filter.AuthorID > 0 {
-		audiobooks, total, err = s.audiobookRepo.GetByAuthorID(filter.AuthorID, offset, limit)
-	} else if filter.ReaderID > 0 {
-		audiobooks, total, err = s.audiobookRepo.GetByReaderID(filter.ReaderID, offset, limit)
-	} else if filter.GenreID > 0 {
-		audiobooks, total, err = s.audiobookRepo.GetByGenreID(filter.GenreID, offset, limit)
-	} else {
-		audiobooks, total, err = s.audiobookRepo.GetAllWithRelations(offset, limit)
+	audiobooks, result, err := s.audiobookRepo.GetFiltered(listFilter, listPage(req))
+	if err != nil {
+		return nil, err
 	}
 
+	facets, err := s.audiobookRepo.GetFacets(listFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -416,58 +490,226 @@ func (s *AudiobookService) GetAudiobooks(filter dto.AudiobookFilter, page, limit
 		audiobookResponses = append(audiobookResponses, s.convertToAudiobookListResponse(&audiobook))
 	}
 
-	// Calculate total pages
-	totalPages := total / int64(limit)
-	if total%int64(limit) > 0 {
-		totalPages++
-	}
-
-	return &dto.ListResponse{
-		Items: audiobookResponses,
-		Pagination: dto.PaginationResponse{
-			Page:       page,
-			Limit:      limit,
-			Total:      total,
-			TotalPages: int(totalPages),
+	return &dto.AudiobookFacetedListResponse{
+		ListResponse: dto.ListResponse{
+			Items:      audiobookResponses,
+			Pagination: listPagination(req, result),
 		},
+		Facets: convertToAudiobookFacets(facets),
 	}, nil
 }
 
-// AddGenresToAudiobook adds genres to an audiobook
-func (s *AudiobookService) AddGenresToAudiobook(audiobookID uint, genreIDs []uint) error {
+// toAudiobookListFilter converts the filter options of a list request to a repository filter
+func toAudiobookListFilter(filter dto.AudiobookFilter) repository.AudiobookListFilter {
+	return repository.AudiobookListFilter{
+		AuthorID:   filter.AuthorID,
+		ReaderID:   filter.ReaderID,
+		GenreIDs:   filter.GenreIDs,
+		GenreMatch: filter.GenreMatch,
+		Language:   filter.Language,
+		YearFrom:   filter.YearFrom,
+		YearTo:     filter.YearTo,
+		HasTracks:  filter.HasTracks,
+		Duration:   repository.DurationFilter(filter.Duration),
+	}
+}
+
+// convertToAudiobookFacets converts repository facet counts, keeping empty facets as empty lists
+func convertToAudiobookFacets(facets *repository.AudiobookFacets) dto.AudiobookFacets {
+	response := dto.AudiobookFacets{
+		Genres:    []dto.GenreFacet{},
+		Languages: []dto.LanguageFacet{},
+		Decades:   []dto.DecadeFacet{},
+	}
+	for _, genre := range facets.Genres {
+		response.Genres = append(response.Genres, dto.GenreFacet{ID: genre.GenreID, Name: genre.Name, Count: genre.Count})
+	}
+	for _, language := range facets.Languages {
+		response.Languages = append(response.Languages, dto.LanguageFacet{Language: language.Language, Count: language.Count})
+	}
+	for _, decade := range facets.Decades {
+		response.Decades = append(response.Decades, dto.DecadeFacet{Decade: decade.Decade, Count: decade.Count})
+	}
+	return response
+}
+
+// SetCredits replaces the author and reader credits of an audiobook at version
+func (s *AudiobookService) SetCredits(userID string, audiobookID, version uint, req dto.SetCreditsRequest) (*dto.AudiobookResponse, error) {
 	// Check if audiobook exists
-	_, err := s.audiobookRepo.GetByID(audiobookID)
+	audiobook, err := s.audiobookRepo.GetByID(audiobookID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("audiobook not found")
+			return nil, errors.New("audiobook not found")
 		}
-		return err
+		return nil, err
 	}
 
-	// Validate that all genres exist
-	for _, genreID := range genreIDs {
-		_, err := s.genreRepo.GetByID(genreID)
-		if err != nil {
+	if err := checkVersion(audiobook.Version, version); err != nil {
+		return nil, err
+	}
+
+	hasAuthor := false
+	seen := make(map[string]bool)
+	authors := make([]entity.AudiobookAuthor, 0, len(req.Authors))
+	for _, credit := range req.Authors {
+		role := credit.Role
+		if role == "" {
+			role = entity.RoleAuthor
+		}
+		key := fmt.Sprintf("author:%d:%s", credit.AuthorID, role)
+		if seen[key] {
+			return nil, errors.New("duplicate credit")
+		}
+		seen[key] = true
+		hasAuthor = hasAuthor || role == entity.RoleAuthor
+
+		if _, err := s.authorRepo.GetByID(credit.AuthorID); err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("one or more genres not found")
+				return nil, errors.New("author not found")
 			}
+			return nil, err
+		}
+		authors = append(authors, entity.AudiobookAuthor{AuthorID: credit.AuthorID, Role: role})
+	}
+	if !hasAuthor {
+		return nil, errors.New("at least one author credit is required")
+	}
+
+	readers := make([]entity.AudiobookReader, 0, len(req.Readers))
+	for _, credit := range req.Readers {
+		role := credit.Role
+		if role == "" {
+			role = entity.RoleNarrator
+		}
+		key := fmt.Sprintf("reader:%d:%s", credit.ReaderID, role)
+		if seen[key] {
+			return nil, errors.New("duplicate credit")
+		}
+		seen[key] = true
+
+		if _, err := s.readerRepo.GetByID(credit.ReaderID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("reader not found")
+			}
+			return nil, err
+		}
+		readers = append(readers, entity.AudiobookReader{ReaderID: credit.ReaderID, Role: role})
+	}
+
+	err = s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		if err := repos.Audiobooks.SetCredits(audiobookID, version, authors, readers); err != nil {
 			return err
 		}
+
+		// Audiobooks are found by the names of everyone credited
+		return repos.SearchIndex.RefreshAudiobooks([]uint{audiobookID})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.audiobookRepo.AssignGenres(audiobookID, genreIDs)
+	updatedAudiobook, err := s.audiobookRepo.GetByIDWithRelations(audiobookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToAudiobookResponse(updatedAudiobook, userID), nil
 }
 
-// RemoveGenresFromAudiobook removes genres from an audiobook
-func (s *AudiobookService) RemoveGenresFromAudiobook(audiobookID uint, genreIDs []uint) error {
-	// Check if audiobook exists
-	_, err := s.audiobookRepo.GetByID(audiobookID)
+// AddGenresToAudiobook adds genres to an audiobook at version, returning its new version
+func (s *AudiobookService) AddGenresToAudiobook(audiobookID, version uint, genreIDs []uint) (uint, error) {
+	var newVersion uint
+	err := s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		// Check if audiobook exists
+		audiobook, err := repos.Audiobooks.GetByID(audiobookID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("audiobook not found")
+			}
+			return err
+		}
+
+		if err := checkVersion(audiobook.Version, version); err != nil {
+			return err
+		}
+
+		// Validate that all genres exist
+		for _, genreID := range genreIDs {
+			if _, err := repos.Genres.GetByID(genreID); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return errors.New("one or more genres not found")
+				}
+				return err
+			}
+		}
+
+		if err := repos.Audiobooks.AssignGenres(audiobookID, version, genreIDs); err != nil {
+			return err
+		}
+
+		newVersion, err = currentVersion(repos, audiobookID)
+		if err != nil {
+			return err
+		}
+		return repos.SearchIndex.RefreshAudiobooks([]uint{audiobookID})
+	})
+	return newVersion, err
+}
+
+// RemoveGenresFromAudiobook removes genres from an audiobook at version, returning its new version
+func (s *AudiobookService) RemoveGenresFromAudiobook(audiobookID, version uint, genreIDs []uint) (uint, error) {
+	var newVersion uint
+	err := s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		// Check if audiobook exists
+		audiobook, err := repos.Audiobooks.GetByID(audiobookID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("audiobook not found")
+			}
+			return err
+		}
+
+		if err := checkVersion(audiobook.Version, version); err != nil {
+			return err
+		}
+
+		if err := repos.Audiobooks.RemoveGenres(audiobookID, version, genreIDs); err != nil {
+			return err
+		}
+
+		newVersion, err = currentVersion(repos, audiobookID)
+		if err != nil {
+			return err
+		}
+		return repos.SearchIndex.RefreshAudiobooks([]uint{audiobookID})
+	})
+	return newVersion, err
+}
+
+// currentVersion retrieves the version of an audiobook after a write
+func currentVersion(repos *repository.Repositories, audiobookID uint) (uint, error) {
+	audiobook, err := repos.Audiobooks.GetByID(audiobookID)
 	if err != nil {
+		return 0, err
+	}
+	return audiobook.Version, nil
+}
+
+// validateAuthorAndReader checks that the primary author and reader of an audiobook exist
+func validateAuthorAndReader(repos *repository.Repositories, authorID, readerID uint) error {
+	if _, err := repos.Authors.GetByID(authorID); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("audiobook not found")
+			return errors.New("author not found")
 		}
 		return err
 	}
 
-	return s.audiobookRepo.RemoveGenres(audiobookID, genreIDs)
+	if _, err := repos.Readers.GetByID(readerID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("reader not found")
+		}
+		return err
+	}
+
+	return nil
 }
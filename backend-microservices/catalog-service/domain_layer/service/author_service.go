@@ -10,11 +10,15 @@ import (
 )
 
 type AuthorService struct {
-	authorRepo repository.AuthorRepositoryInterface
+	authorRepo      repository.AuthorRepositoryInterface
+	searchIndexRepo repository.SearchIndexRepositoryInterface
 }
 
-func NewAuthorService(authorRepo repository.AuthorRepositoryInterface) *AuthorService {
-	return &AuthorService{authorRepo: authorRepo}
+func NewAuthorService(authorRepo repository.AuthorRepositoryInterface, searchIndexRepo repository.SearchIndexRepositoryInterface) *AuthorService {
+	return &AuthorService{
+		authorRepo:      authorRepo,
+		searchIndexRepo: searchIndexRepo,
+	}
 }
 
 // CreateAuthor creates a new author
@@ -28,8 +32,9 @@ func (s *AuthorService) CreateAuthor(req dto.CreateAuthorRequest) (*dto.AuthorRe
 	}
 
 	return &dto.AuthorResponse{
-		ID:   author.ID,
-		Name: author.Name,
+		ID:      author.ID,
+		Name:    author.Name,
+		Version: author.Version,
 	}, nil
 }
 
@@ -44,18 +49,16 @@ func (s *AuthorService) GetAuthorByID(id uint) (*dto.AuthorResponse, error) {
 	}
 
 	return &dto.AuthorResponse{
-		ID:   author.ID,
-		Name: author.Name,
+		ID:      author.ID,
+		Name:    author.Name,
+		Version: author.Version,
 	}, nil
 }
 
 // GetAllAuthors retrieves all authors with pagination
-func (s *AuthorService) GetAllAuthors(req dto.PaginationRequest) (*dto.ListResponse, error) {
-	// Calculate offset
-	offset := (req.Page - 1) * req.Limit
-
+func (s *AuthorService) GetAllAuthors(req dto.ListRequest) (*dto.ListResponse, error) {
 	// Get paginated results
-	authors, total, err := s.authorRepo.GetAll(offset, req.Limit)
+	authors, result, err := s.authorRepo.GetAll(listPage(req))
 	if err != nil {
 		return nil, err
 	}
@@ -64,30 +67,20 @@ func (s *AuthorService) GetAllAuthors(req dto.PaginationRequest) (*dto.ListRespo
 	var authorResponses []dto.AuthorResponse
 	for _, author := range authors {
 		authorResponses = append(authorResponses, dto.AuthorResponse{
-			ID:   author.ID,
-			Name: author.Name,
+			ID:      author.ID,
+			Name:    author.Name,
+			Version: author.Version,
 		})
 	}
 
-	// Calculate total pages
-	totalPages := int(total) / req.Limit
-	if int(total)%req.Limit > 0 {
-		totalPages++
-	}
-
 	return &dto.ListResponse{
-		Items: authorResponses,
-		Pagination: dto.PaginationResponse{
-			Page:       req.Page,
-			Limit:      req.Limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
+		Items:      authorResponses,
+		Pagination: listPagination(req, result),
 	}, nil
 }
 
 // UpdateAuthor updates an existing author
-func (s *AuthorService) UpdateAuthor(id uint, req dto.UpdateAuthorRequest) (*dto.AuthorResponse, error) {
+func (s *AuthorService) UpdateAuthor(id, version uint, req dto.UpdateAuthorRequest) (*dto.AuthorResponse, error) {
 	author, err := s.authorRepo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -96,20 +89,31 @@ func (s *AuthorService) UpdateAuthor(id uint, req dto.UpdateAuthorRequest) (*dto
 		return nil, err
 	}
 
+	if err := checkVersion(author.Version, version); err != nil {
+		return nil, err
+	}
+
 	author.Name = req.Name
 	if err := s.authorRepo.Update(author); err != nil {
 		return nil, err
 	}
 
+	// Audiobooks are found by the names of their authors
+	if err := s.searchIndexRepo.RefreshByAuthorID(author.ID); err != nil {
+		return nil, err
+	}
+
 	return &dto.AuthorResponse{
-		ID:   author.ID,
-		Name: author.Name,
+		ID:      author.ID,
+		Name:    author.Name,
+		Version: author.Version,
 	}, nil
 }
 
-// DeleteAuthor deletes an author
-func (s *AuthorService) DeleteAuthor(id uint) error {
-	_, err := s.authorRepo.GetByID(id)
+// DeleteAuthor moves an author to the trash. Authors that are still the primary author of an
+// audiobook cannot be deleted; other credits of the author are hidden until a restore.
+func (s *AuthorService) DeleteAuthor(id, version uint) error {
+	author, err := s.authorRepo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("author not found")
@@ -117,7 +121,24 @@ func (s *AuthorService) DeleteAuthor(id uint) error {
 		return err
 	}
 
-	return s.authorRepo.Delete(id)
+	if err := checkVersion(author.Version, version); err != nil {
+		return err
+	}
+
+	isPrimary, err := s.authorRepo.IsPrimaryOfAudiobooks(id)
+	if err != nil {
+		return err
+	}
+	if isPrimary {
+		return errors.New("cannot delete author with audiobooks")
+	}
+
+	if err := s.authorRepo.Delete(id, version); err != nil {
+		return err
+	}
+
+	// Trashed authors no longer find the audiobooks they are credited on
+	return s.searchIndexRepo.RefreshByAuthorID(id)
 }
 
 // SearchAuthors searches authors by name
@@ -135,8 +156,9 @@ func (s *AuthorService) SearchAuthors(req dto.SearchRequest) (*dto.ListResponse,
 	var authorResponses []dto.AuthorResponse
 	for _, author := range authors {
 		authorResponses = append(authorResponses, dto.AuthorResponse{
-			ID:   author.ID,
-			Name: author.Name,
+			ID:      author.ID,
+			Name:    author.Name,
+			Version: author.Version,
 		})
 	}
 
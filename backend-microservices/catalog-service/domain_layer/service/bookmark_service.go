@@ -0,0 +1,289 @@
+package service
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/data_layer/entity"
+	"catalog-service/data_layer/repository"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BookmarkService struct {
+	bookmarkRepo  repository.BookmarkRepositoryInterface
+	trackRepo     repository.TrackRepositoryInterface
+	audiobookRepo repository.AudiobookRepositoryInterface
+}
+
+func NewBookmarkService(
+	bookmarkRepo repository.BookmarkRepositoryInterface,
+	trackRepo repository.TrackRepositoryInterface,
+	audiobookRepo repository.AudiobookRepositoryInterface,
+) *BookmarkService {
+	return &BookmarkService{
+		bookmarkRepo:  bookmarkRepo,
+		trackRepo:     trackRepo,
+		audiobookRepo: audiobookRepo,
+	}
+}
+
+// CreateBookmark creates a new bookmark for a user
+func (s *BookmarkService) CreateBookmark(userID string, req dto.CreateBookmarkRequest) (*dto.BookmarkResponse, error) {
+	track, err := s.trackRepo.GetByID(req.TrackID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("track not found")
+		}
+		return nil, err
+	}
+
+	if err := s.ensureOffsetFree(userID, track.ID, req.OffsetSeconds, 0); err != nil {
+		return nil, err
+	}
+
+	bookmark := entity.Bookmark{
+		UserID:        userID,
+		TrackID:       track.ID,
+		OffsetSeconds: req.OffsetSeconds,
+		Label:         req.Label,
+		Note:          req.Note,
+	}
+
+	if err := s.bookmarkRepo.Create(&bookmark); err != nil {
+		return nil, err
+	}
+
+	bookmark.Track = *track
+	return s.convertToBookmarkResponse(&bookmark), nil
+}
+
+// GetBookmarkByID retrieves a bookmark of a user by ID
+func (s *BookmarkService) GetBookmarkByID(userID string, id uint) (*dto.BookmarkResponse, error) {
+	bookmark, err := s.getOwnBookmark(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToBookmarkResponse(bookmark), nil
+}
+
+// UpdateBookmark updates the offset, label and note of a bookmark
+func (s *BookmarkService) UpdateBookmark(userID string, id uint, req dto.UpdateBookmarkRequest) (*dto.BookmarkResponse, error) {
+	bookmark, err := s.getOwnBookmark(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureOffsetFree(userID, bookmark.TrackID, req.OffsetSeconds, bookmark.ID); err != nil {
+		return nil, err
+	}
+
+	bookmark.OffsetSeconds = req.OffsetSeconds
+	bookmark.Label = req.Label
+	bookmark.Note = req.Note
+
+	if err := s.bookmarkRepo.Update(bookmark); err != nil {
+		return nil, err
+	}
+
+	return s.convertToBookmarkResponse(bookmark), nil
+}
+
+// DeleteBookmark deletes a bookmark of a user
+func (s *BookmarkService) DeleteBookmark(userID string, id uint) error {
+	if _, err := s.getOwnBookmark(userID, id); err != nil {
+		return err
+	}
+
+	return s.bookmarkRepo.Delete(id)
+}
+
+// GetBookmarksByTrack retrieves the bookmarks of a user in a track ordered by offset
+func (s *BookmarkService) GetBookmarksByTrack(userID string, trackID uint) ([]dto.BookmarkResponse, error) {
+	if _, err := s.trackRepo.GetByID(trackID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("track not found")
+		}
+		return nil, err
+	}
+
+	bookmarks, err := s.bookmarkRepo.GetByUserAndTrack(userID, trackID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarkResponses := []dto.BookmarkResponse{}
+	for _, bookmark := range bookmarks {
+		bookmarkResponses = append(bookmarkResponses, *s.convertToBookmarkResponse(&bookmark))
+	}
+
+	return bookmarkResponses, nil
+}
+
+// GetBookmarksByAudiobook retrieves the bookmarks of a user in an audiobook in track order with pagination
+func (s *BookmarkService) GetBookmarksByAudiobook(userID string, audiobookID uint, req dto.PaginationRequest) (*dto.ListResponse, error) {
+	if _, err := s.audiobookRepo.GetByID(audiobookID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("audiobook not found")
+		}
+		return nil, err
+	}
+
+	// Calculate offset
+	offset := (req.Page - 1) * req.Limit
+
+	// Get paginated results
+	bookmarks, total, err := s.bookmarkRepo.GetByUserAndAudiobook(userID, audiobookID, offset, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response format
+	bookmarkResponses := []dto.BookmarkResponse{}
+	for _, bookmark := range bookmarks {
+		bookmarkResponses = append(bookmarkResponses, *s.convertToBookmarkResponse(&bookmark))
+	}
+
+	// Calculate total pages
+	totalPages := int(total) / req.Limit
+	if int(total)%req.Limit > 0 {
+		totalPages++
+	}
+
+	return &dto.ListResponse{
+		Items: bookmarkResponses,
+		Pagination: dto.PaginationResponse{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// ExportBookmarks collects every bookmark of a user in an audiobook grouped by track
+func (s *BookmarkService) ExportBookmarks(userID string, audiobookID uint) (*dto.BookmarkExportResponse, error) {
+	audiobook, err := s.audiobookRepo.GetByIDWithRelations(audiobookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("audiobook not found")
+		}
+		return nil, err
+	}
+
+	bookmarks, err := s.bookmarkRepo.GetAllByUserAndAudiobook(userID, audiobookID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &dto.BookmarkExportResponse{
+		AudiobookID:    audiobook.ID,
+		AudiobookTitle: audiobook.Title,
+		ExportedAt:     time.Now(),
+		Tracks:         []dto.BookmarkExportTrack{},
+	}
+	if audiobook.Author != nil {
+		export.Author = audiobook.Author.Name
+	}
+
+	// Bookmarks arrive in track order, so a new group starts whenever the track changes
+	for _, bookmark := range bookmarks {
+		last := len(export.Tracks) - 1
+		if last < 0 || export.Tracks[last].TrackID != bookmark.TrackID {
+			export.Tracks = append(export.Tracks, dto.BookmarkExportTrack{
+				TrackID:    bookmark.TrackID,
+				TrackTitle: bookmark.Track.Title,
+			})
+			last++
+		}
+		export.Tracks[last].Bookmarks = append(export.Tracks[last].Bookmarks, *s.convertToBookmarkResponse(&bookmark))
+	}
+
+	return export, nil
+}
+
+// ExportBookmarksMarkdown renders every bookmark of a user in an audiobook as a Markdown document
+func (s *BookmarkService) ExportBookmarksMarkdown(userID string, audiobookID uint) (string, error) {
+	export, err := s.ExportBookmarks(userID, audiobookID)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", export.AudiobookTitle)
+	if export.Author != "" {
+		fmt.Fprintf(&b, "_by %s_\n\n", export.Author)
+	}
+	fmt.Fprintf(&b, "Exported %s\n", export.ExportedAt.UTC().Format(time.RFC3339))
+
+	for _, track := range export.Tracks {
+		fmt.Fprintf(&b, "\n## %s\n", track.TrackTitle)
+		for _, bookmark := range track.Bookmarks {
+			heading := formatOffset(bookmark.OffsetSeconds)
+			if bookmark.Label != "" {
+				heading += " " + bookmark.Label
+			}
+			fmt.Fprintf(&b, "\n### %s\n", heading)
+			if note := strings.TrimSpace(bookmark.Note); note != "" {
+				fmt.Fprintf(&b, "\n%s\n", note)
+			}
+		}
+	}
+
+	return b.String(), nil
+}
+
+// Helper methods
+func (s *BookmarkService) getOwnBookmark(userID string, id uint) (*entity.Bookmark, error) {
+	bookmark, err := s.bookmarkRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("bookmark not found")
+		}
+		return nil, err
+	}
+
+	// Notes are private, so other users' bookmarks are reported as missing
+	if bookmark.UserID != userID {
+		return nil, errors.New("bookmark not found")
+	}
+
+	return bookmark, nil
+}
+
+func (s *BookmarkService) ensureOffsetFree(userID string, trackID uint, offsetSeconds int, exceptID uint) error {
+	existing, err := s.bookmarkRepo.GetByUserTrackOffset(userID, trackID, offsetSeconds)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != exceptID {
+		return errors.New("bookmark already exists at this offset")
+	}
+	return nil
+}
+
+func (s *BookmarkService) convertToBookmarkResponse(bookmark *entity.Bookmark) *dto.BookmarkResponse {
+	return &dto.BookmarkResponse{
+		ID:            bookmark.ID,
+		TrackID:       bookmark.TrackID,
+		TrackTitle:    bookmark.Track.Title,
+		AudiobookID:   bookmark.Track.AudiobookID,
+		OffsetSeconds: bookmark.OffsetSeconds,
+		Label:         bookmark.Label,
+		Note:          bookmark.Note,
+		CreatedAt:     bookmark.CreatedAt,
+		UpdatedAt:     bookmark.UpdatedAt,
+	}
+}
+
+// formatOffset formats an offset in seconds as [hh:mm:ss]
+func formatOffset(seconds int) string {
+	return fmt.Sprintf("[%02d:%02d:%02d]", seconds/3600, seconds%3600/60, seconds%60)
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"catalog-service/data_layer/dto"
+	"catalog-service/data_layer/entity"
+	"catalog-service/data_layer/repository"
+	"catalog-service/helpers/cache"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bundleFormat is part of every bundle cache key; changing it retires bundles built by older code
+const bundleFormat = "2"
+
+// bundleEntryTime is the fixed modification time of every ZIP entry, so a rebuilt
+// bundle is byte-identical and ranges requested before an eviction stay valid
+var bundleEntryTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// AudiobookBundle is a seekable ZIP archive of an audiobook ready to be served
+type AudiobookBundle struct {
+	File    *os.File
+	Name    string
+	ETag    string
+	ModTime time.Time
+}
+
+type DownloadService struct {
+	audiobookService *AudiobookService
+	trackRepo        repository.TrackRepositoryInterface
+	analyticsRepo    repository.AnalyticsRepositoryInterface
+	streamService    *StreamService
+	fetcher          AudioFetcher
+	cache            *cache.DiskLRUCache
+	buildTimeout     time.Duration
+}
+
+func NewDownloadService(
+	audiobookService *AudiobookService,
+	trackRepo repository.TrackRepositoryInterface,
+	analyticsRepo repository.AnalyticsRepositoryInterface,
+	streamService *StreamService,
+	fetcher AudioFetcher,
+	diskCache *cache.DiskLRUCache,
+) *DownloadService {
+	return &DownloadService{
+		audiobookService: audiobookService,
+		trackRepo:        trackRepo,
+		analyticsRepo:    analyticsRepo,
+		streamService:    streamService,
+		fetcher:          fetcher,
+		cache:            diskCache,
+		buildTimeout:     30 * time.Minute,
+	}
+}
+
+// OpenBundle returns the ZIP bundle of an audiobook, building it into the disk cache on a miss.
+// The bundle holds the ordered tracks, the cover image, metadata.json and an M3U playlist.
+// The caller must close the returned file.
+func (s *DownloadService) OpenBundle(ctx context.Context, audiobookID uint) (*AudiobookBundle, error) {
+	audiobook, err := s.audiobookService.GetAudiobookByID("", audiobookID)
+	if err != nil {
+		return nil, err
+	}
+
+	tracks, err := s.trackRepo.GetByAudiobookID(audiobookID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tracks) == 0 {
+		return nil, errors.New("audiobook has no tracks")
+	}
+
+	// metadata.json points every track at its file inside the bundle instead of a stream URL
+	fileNames := bundleTrackFileNames(tracks)
+	metadata := *audiobook
+	metadata.Tracks = nil
+	var modTime time.Time
+	for i, track := range tracks {
+		metadata.Tracks = append(metadata.Tracks, dto.TrackResponse{
+			ID:       track.ID,
+			Title:    track.Title,
+			URL:      fileNames[i],
+			Duration: track.Duration,
+		})
+		if track.UpdatedAt.After(modTime) {
+			modTime = track.UpdatedAt
+		}
+	}
+
+	metadataJSON, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	// Any change to the metadata, the track list or an upstream location produces a new bundle
+	fingerprint := bundleFormat + "\n" + string(metadataJSON) + "\n" + audiobook.ImageURL
+	for _, track := range tracks {
+		fingerprint += "\n" + track.URL
+	}
+	key := cacheKey(fingerprint)
+
+	file, err := s.cache.Fill(key, func(w io.Writer) error {
+		// The build fills a shared cache entry, so it must outlive the request that started it
+		buildCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.buildTimeout)
+		defer cancel()
+
+		return s.writeBundle(buildCtx, w, audiobook, tracks, fileNames, metadataJSON)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUpstreamUnavailable, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &AudiobookBundle{
+		File:    file,
+		Name:    sanitizeFileName(audiobook.Title) + ".zip",
+		ETag:    fmt.Sprintf("\"%s-%d\"", key[:16], info.Size()),
+		ModTime: modTime,
+	}, nil
+}
+
+// RecordDownload stores a DOWNLOAD analytics event for an audiobook
+func (s *DownloadService) RecordDownload(userID string, audiobookID uint) error {
+	analytics := entity.Analytics{
+		AudiobookID:    audiobookID,
+		UserID:         userID,
+		EventType:      "DOWNLOAD",
+		EventTimestamp: time.Now(),
+	}
+
+	return s.analyticsRepo.Create(&analytics)
+}
+
+func (s *DownloadService) writeBundle(
+	ctx context.Context,
+	w io.Writer,
+	audiobook *dto.AudiobookResponse,
+	tracks []entity.Track,
+	fileNames []string,
+	metadataJSON []byte,
+) error {
+	archive := zip.NewWriter(w)
+
+	// Audio is already compressed, so entries are stored to keep the build cheap
+	for i, track := range tracks {
+		stream, err := s.streamService.OpenTrack(ctx, track.ID)
+		if err != nil {
+			return fmt.Errorf("track %d: %v", track.ID, err)
+		}
+
+		err = writeBundleEntry(archive, fileNames[i], zip.Store, stream.File)
+		stream.File.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if audiobook.ImageURL != "" {
+		if err := s.writeCover(ctx, archive, audiobook.ImageURL); err != nil {
+			// A missing cover should not make the whole audiobook undownloadable
+			log.Printf("Download bundle: skipping cover of audiobook %d: %v", audiobook.ID, err)
+		}
+	}
+
+	if err := writeBundleEntry(archive, "metadata.json", zip.Deflate, bytes.NewReader(metadataJSON)); err != nil {
+		return err
+	}
+
+	playlist := bundlePlaylist(tracks, fileNames)
+	if err := writeBundleEntry(archive, "playlist.m3u", zip.Deflate, strings.NewReader(playlist)); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+func (s *DownloadService) writeCover(ctx context.Context, archive *zip.Writer, imageURL string) error {
+	upstream, err := s.fetcher.Fetch(ctx, imageURL)
+	if err != nil {
+		return err
+	}
+	defer upstream.Body.Close()
+
+	// Buffer first so a failed download does not leave a truncated entry in the archive
+	cover, err := io.ReadAll(upstream.Body)
+	if err != nil {
+		return err
+	}
+
+	ext := strings.ToLower(path.Ext(path.Base(strings.SplitN(imageURL, "?", 2)[0])))
+	if ext == "" || len(ext) > 5 {
+		ext = ".jpg"
+	}
+
+	return writeBundleEntry(archive, "cover"+ext, zip.Store, bytes.NewReader(cover))
+}
+
+func writeBundleEntry(archive *zip.Writer, name string, method uint16, r io.Reader) error {
+	entry, err := archive.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   method,
+		Modified: bundleEntryTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(entry, r)
+	return err
+}
+
+// bundleTrackFileNames names track files "01 - Title.mp3" so they sort in playback order
+func bundleTrackFileNames(tracks []entity.Track) []string {
+	width := len(strconv.Itoa(len(tracks)))
+	if width < 2 {
+		width = 2
+	}
+
+	names := make([]string, len(tracks))
+	for i, track := range tracks {
+		ext := strings.ToLower(path.Ext(path.Base(track.URL)))
+		if ext == "" || len(ext) > 5 {
+			ext = ".mp3"
+		}
+		names[i] = fmt.Sprintf("%0*d - %s%s", width, i+1, sanitizeFileName(track.Title), ext)
+	}
+	return names
+}
+
+// bundlePlaylist renders an extended M3U playlist of the bundled track files; a track of unknown
+// length is listed with -1 seconds
+func bundlePlaylist(tracks []entity.Track, fileNames []string) string {
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n")
+	for i, track := range tracks {
+		seconds := track.DurationSeconds
+		if seconds <= 0 {
+			seconds = -1
+		}
+		fmt.Fprintf(&b, "#EXTINF:%d,%s\n%s\n", seconds, track.Title, fileNames[i])
+	}
+	return b.String()
+}
+
+// sanitizeFileName strips characters that are not allowed in file names on common platforms
+func sanitizeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r < 0x20, strings.ContainsRune(`/\:*?"<>|`, r):
+			return '_'
+		}
+		return r
+	}, name)
+
+	name = strings.Trim(strings.TrimSpace(name), ".")
+	if name == "" {
+		return "untitled"
+	}
+	return name
+}
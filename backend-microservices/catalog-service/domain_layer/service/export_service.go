@@ -0,0 +1,314 @@
+package service
+
+import (
+	"bufio"
+	"catalog-service/data_layer/dto"
+	"catalog-service/data_layer/entity"
+	"catalog-service/data_layer/repository"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Export formats and CSV row modes
+const (
+	ExportFormatJSON    = "json"
+	ExportFormatNDJSON  = "ndjson"
+	ExportFormatCSV     = "csv"
+	ExportRowsAudiobook = "audiobook"
+	ExportRowsTrack     = "track"
+)
+
+// exportBatchSize bounds how many audiobooks, with their tracks, an export holds in memory at once
+const exportBatchSize = 200
+
+// AudiobookExportColumns is the CSV schema of an export with one row per audiobook.
+// Genres are joined with "; " in name order.
+var AudiobookExportColumns = []string{
+	"audiobook_id", "external_id", "title", "author_id", "author", "reader_id", "reader", "genres",
+	"language", "year_of_publishing", "total_duration", "total_duration_seconds", "track_count",
+	"image_url", "description", "created_at", "updated_at",
+}
+
+// TrackExportColumns is the CSV schema of an export with one row per track
+var TrackExportColumns = []string{
+	"audiobook_id", "audiobook_external_id", "audiobook_title", "author", "reader", "genres", "language",
+	"track_id", "track_external_id", "position", "title", "url", "duration", "duration_seconds",
+}
+
+type ExportService struct {
+	audiobookRepo repository.AudiobookRepositoryInterface
+}
+
+func NewExportService(audiobookRepo repository.AudiobookRepositoryInterface) *ExportService {
+	return &ExportService{audiobookRepo: audiobookRepo}
+}
+
+// NormalizeExportRequest fills in the defaults of an export request (json, one row per audiobook)
+// and rejects unknown formats and row modes
+func NormalizeExportRequest(req dto.ExportRequest) (dto.ExportRequest, error) {
+	if req.Format == "" {
+		req.Format = ExportFormatJSON
+	}
+	if req.Rows == "" {
+		req.Rows = ExportRowsAudiobook
+	}
+
+	switch req.Format {
+	case ExportFormatJSON, ExportFormatNDJSON, ExportFormatCSV:
+	default:
+		return req, errors.New("invalid format")
+	}
+	switch req.Rows {
+	case ExportRowsAudiobook, ExportRowsTrack:
+	default:
+		return req, errors.New("invalid rows")
+	}
+
+	return req, nil
+}
+
+// ExportContentType returns the media type of an export format
+func ExportContentType(format string) string {
+	switch format {
+	case ExportFormatNDJSON:
+		return "application/x-ndjson"
+	case ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	default:
+		return "application/json"
+	}
+}
+
+// ExportFileName returns the file name of a catalog export in a format
+func ExportFileName(req dto.ExportRequest) string {
+	name := "catalog"
+	if req.Format == ExportFormatCSV && req.Rows == ExportRowsTrack {
+		name = "catalog-tracks"
+	}
+	return name + "." + req.Format
+}
+
+// Export writes every audiobook matching the filter to w in ID order, batch by batch, so the catalog
+// is never loaded at once. When w can be flushed, such as an HTTP response, every batch is sent right away.
+// req must have been normalized with NormalizeExportRequest.
+func (s *ExportService) Export(w io.Writer, req dto.ExportRequest, filter dto.AudiobookFilter) error {
+	buffered := bufio.NewWriter(w)
+	flush := func() error {
+		if err := buffered.Flush(); err != nil {
+			return err
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		return nil
+	}
+
+	var writeBatch func(audiobooks []entity.Audiobook) error
+	var finish func() error
+
+	switch req.Format {
+	case ExportFormatCSV:
+		csvWriter := csv.NewWriter(buffered)
+		columns := AudiobookExportColumns
+		if req.Rows == ExportRowsTrack {
+			columns = TrackExportColumns
+		}
+		if err := csvWriter.Write(columns); err != nil {
+			return err
+		}
+
+		writeBatch = func(audiobooks []entity.Audiobook) error {
+			for i := range audiobooks {
+				var rows [][]string
+				if req.Rows == ExportRowsTrack {
+					rows = trackExportRows(&audiobooks[i])
+				} else {
+					rows = [][]string{audiobookExportRow(&audiobooks[i])}
+				}
+				if err := csvWriter.WriteAll(rows); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		finish = func() error {
+			csvWriter.Flush()
+			return csvWriter.Error()
+		}
+
+	case ExportFormatNDJSON:
+		encoder := json.NewEncoder(buffered)
+		writeBatch = func(audiobooks []entity.Audiobook) error {
+			for i := range audiobooks {
+				// Encode ends every document with a newline
+				if err := encoder.Encode(toExportAudiobook(&audiobooks[i])); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		finish = func() error { return nil }
+
+	default:
+		first := true
+		if _, err := buffered.WriteString("["); err != nil {
+			return err
+		}
+		writeBatch = func(audiobooks []entity.Audiobook) error {
+			for i := range audiobooks {
+				document, err := json.Marshal(toExportAudiobook(&audiobooks[i]))
+				if err != nil {
+					return err
+				}
+				if !first {
+					if _, err := buffered.WriteString(",\n"); err != nil {
+						return err
+					}
+				}
+				first = false
+				if _, err := buffered.Write(document); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		finish = func() error {
+			_, err := buffered.WriteString("]\n")
+			return err
+		}
+	}
+
+	err := s.audiobookRepo.ForEachBatch(toAudiobookListFilter(filter), exportBatchSize, func(audiobooks []entity.Audiobook) error {
+		if err := writeBatch(audiobooks); err != nil {
+			return err
+		}
+		return flush()
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := finish(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// toExportAudiobook converts an audiobook with its relations to a nested export document
+func toExportAudiobook(audiobook *entity.Audiobook) dto.ExportAudiobook {
+	document := dto.ExportAudiobook{
+		ID:                   audiobook.ID,
+		ExternalID:           audiobook.ExternalID,
+		Title:                audiobook.Title,
+		Genres:               []dto.GenreResponse{},
+		Language:             audiobook.Language,
+		YearOfPublishing:     audiobook.YearOfPublishing,
+		TotalDuration:        audiobook.TotalDuration,
+		TotalDurationSeconds: audiobook.TotalDurationSeconds,
+		ImageURL:             audiobook.ImageURL,
+		Description:          audiobook.Description,
+		CreatedAt:            audiobook.CreatedAt,
+		UpdatedAt:            audiobook.UpdatedAt,
+		Tracks:               []dto.ExportTrack{},
+	}
+	if audiobook.Author != nil {
+		document.Author = dto.AuthorResponse{ID: audiobook.Author.ID, Name: audiobook.Author.Name}
+	}
+	if audiobook.Reader != nil {
+		document.Reader = dto.ReaderResponse{ID: audiobook.Reader.ID, Name: audiobook.Reader.Name}
+	}
+	for _, genre := range audiobook.Genres {
+		document.Genres = append(document.Genres, dto.GenreResponse{ID: genre.ID, Name: genre.Name})
+	}
+	for _, track := range audiobook.Tracks {
+		document.Tracks = append(document.Tracks, dto.ExportTrack{
+			ID:              track.ID,
+			ExternalID:      track.ExternalID,
+			Position:        track.Position,
+			Title:           track.Title,
+			URL:             track.URL,
+			Duration:        track.Duration,
+			DurationSeconds: track.DurationSeconds,
+		})
+	}
+	return document
+}
+
+// audiobookExportRow lays an audiobook out in the columns of AudiobookExportColumns
+func audiobookExportRow(audiobook *entity.Audiobook) []string {
+	author, reader := exportPeople(audiobook)
+	return []string{
+		formatUint(audiobook.ID),
+		audiobook.ExternalID,
+		audiobook.Title,
+		formatUint(audiobook.AuthorID),
+		author,
+		formatUint(audiobook.ReaderID),
+		reader,
+		exportGenres(audiobook),
+		audiobook.Language,
+		strconv.Itoa(audiobook.YearOfPublishing),
+		audiobook.TotalDuration,
+		strconv.Itoa(audiobook.TotalDurationSeconds),
+		strconv.Itoa(len(audiobook.Tracks)),
+		audiobook.ImageURL,
+		audiobook.Description,
+		audiobook.CreatedAt.UTC().Format(time.RFC3339),
+		audiobook.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// trackExportRows lays the tracks of an audiobook out in the columns of TrackExportColumns
+func trackExportRows(audiobook *entity.Audiobook) [][]string {
+	author, reader := exportPeople(audiobook)
+	genres := exportGenres(audiobook)
+
+	rows := make([][]string, 0, len(audiobook.Tracks))
+	for _, track := range audiobook.Tracks {
+		rows = append(rows, []string{
+			formatUint(audiobook.ID),
+			audiobook.ExternalID,
+			audiobook.Title,
+			author,
+			reader,
+			genres,
+			audiobook.Language,
+			formatUint(track.ID),
+			track.ExternalID,
+			strconv.Itoa(track.Position),
+			track.Title,
+			track.URL,
+			track.Duration,
+			strconv.Itoa(track.DurationSeconds),
+		})
+	}
+	return rows
+}
+
+func exportPeople(audiobook *entity.Audiobook) (author, reader string) {
+	if audiobook.Author != nil {
+		author = audiobook.Author.Name
+	}
+	if audiobook.Reader != nil {
+		reader = audiobook.Reader.Name
+	}
+	return author, reader
+}
+
+func exportGenres(audiobook *entity.Audiobook) string {
+	names := make([]string, len(audiobook.Genres))
+	for i, genre := range audiobook.Genres {
+		names[i] = genre.Name
+	}
+	return strings.Join(names, "; ")
+}
+
+func formatUint(n uint) string {
+	return strconv.FormatUint(uint64(n), 10)
+}
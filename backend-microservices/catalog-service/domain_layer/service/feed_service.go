@@ -0,0 +1,302 @@
+package service
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/data_layer/entity"
+	"catalog-service/data_layer/repository"
+	"catalog-service/helpers/duration"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// feedTokenBytes is the amount of randomness in a private feed token
+const feedTokenBytes = 32
+
+// ErrInvalidFeedToken is returned when a feed token is unknown or revoked
+var ErrInvalidFeedToken = errors.New("invalid feed token")
+
+// rssFeed is an RSS 2.0 document with the iTunes podcast extensions
+type rssFeed struct {
+	XMLName     xml.Name   `xml:"rss"`
+	Version     string     `xml:"version,attr"`
+	ITunesSpace string     `xml:"xmlns:itunes,attr"`
+	Channel     rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title          string        `xml:"title"`
+	Link           string        `xml:"link"`
+	Description    string        `xml:"description"`
+	Categories     []string      `xml:"category"`
+	Generator      string        `xml:"generator"`
+	LastBuildDate  string        `xml:"lastBuildDate"`
+	ITunesAuthor   string        `xml:"itunes:author,omitempty"`
+	ITunesSummary  string        `xml:"itunes:summary,omitempty"`
+	ITunesImage    *itunesImage  `xml:"itunes:image"`
+	ITunesType     string        `xml:"itunes:type"`
+	ITunesExplicit string        `xml:"itunes:explicit"`
+	ITunesBlock    string        `xml:"itunes:block,omitempty"`
+	Image          *rssImage     `xml:"image"`
+	Items          []rssFeedItem `xml:"item"`
+}
+
+type rssImage struct {
+	URL   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+type itunesImage struct {
+	Href string `xml:"href,attr"`
+}
+
+type rssFeedItem struct {
+	Title             string       `xml:"title"`
+	Description       string       `xml:"description,omitempty"`
+	GUID              rssGUID      `xml:"guid"`
+	PubDate           string       `xml:"pubDate"`
+	Enclosure         rssEnclosure `xml:"enclosure"`
+	ITunesAuthor      string       `xml:"itunes:author,omitempty"`
+	ITunesDuration    string       `xml:"itunes:duration,omitempty"`
+	ITunesEpisode     int          `xml:"itunes:episode"`
+	ITunesEpisodeType string       `xml:"itunes:episodeType"`
+	ITunesExplicit    string       `xml:"itunes:explicit"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// FeedService renders podcast feeds of audiobooks and manages the tokens of private feeds
+type FeedService struct {
+	audiobookRepo repository.AudiobookRepositoryInterface
+	feedTokenRepo repository.FeedTokenRepositoryInterface
+	baseURL       string
+}
+
+func NewFeedService(
+	audiobookRepo repository.AudiobookRepositoryInterface,
+	feedTokenRepo repository.FeedTokenRepositoryInterface,
+	baseURL string,
+) *FeedService {
+	return &FeedService{
+		audiobookRepo: audiobookRepo,
+		feedTokenRepo: feedTokenRepo,
+		baseURL:       strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// RenderFeed renders the RSS feed of an audiobook with one item per track in play order.
+// Without a token the enclosures link to the source audio of the tracks. With a private feed token
+// they link to the service's own stream endpoint, authorized by the token, so revoking the token
+// closes the feed and its audio.
+func (s *FeedService) RenderFeed(audiobookID uint, token string) ([]byte, error) {
+	private := token != ""
+	if private {
+		if _, err := s.Authenticate(token); err != nil {
+			return nil, err
+		}
+	}
+
+	audiobook, err := s.audiobookRepo.GetByIDWithRelations(audiobookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("audiobook not found")
+		}
+		return nil, err
+	}
+
+	link := fmt.Sprintf("%s/api/v1/audiobooks/%d", s.baseURL, audiobook.ID)
+	author := ""
+	if audiobook.Author != nil {
+		author = audiobook.Author.Name
+	}
+
+	// The reader is credited in the description; RSS has no element of its own for a narrator
+	description := audiobook.Description
+	if audiobook.Reader != nil {
+		description = strings.TrimSpace(description + "\n\nRead by " + audiobook.Reader.Name + ".")
+	}
+
+	channel := rssChannel{
+		Title:          audiobook.Title,
+		Link:           link,
+		Description:    description,
+		Generator:      "catalog-service",
+		LastBuildDate:  audiobook.UpdatedAt.UTC().Format(time.RFC1123Z),
+		ITunesAuthor:   author,
+		ITunesSummary:  description,
+		ITunesType:     "serial",
+		ITunesExplicit: "false",
+		Items:          []rssFeedItem{},
+	}
+	for _, genre := range audiobook.Genres {
+		channel.Categories = append(channel.Categories, genre.Name)
+	}
+	if audiobook.ImageURL != "" {
+		channel.ITunesImage = &itunesImage{Href: audiobook.ImageURL}
+		channel.Image = &rssImage{URL: audiobook.ImageURL, Title: audiobook.Title, Link: link}
+	}
+	if private {
+		// Keep private feeds out of podcast directories
+		channel.ITunesBlock = "Yes"
+	}
+
+	for _, track := range audiobook.Tracks {
+		channel.Items = append(channel.Items, s.feedItem(audiobook, &track, author, token))
+	}
+
+	feed := rssFeed{
+		Version:     "2.0",
+		ITunesSpace: "http://www.itunes.com/dtds/podcast-1.0.dtd",
+		Channel:     channel,
+	}
+
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// feedItem renders a track as a feed item. Podcast apps order episodes by date, so tracks are
+// published one minute apart from the audiobook's creation in play order.
+func (s *FeedService) feedItem(audiobook *entity.Audiobook, track *entity.Track, author, token string) rssFeedItem {
+	enclosureURL := track.URL
+	if token != "" {
+		enclosureURL = fmt.Sprintf("%s/api/v1/feeds/%s/tracks/%d/stream", s.baseURL, url.PathEscape(token), track.ID)
+	}
+
+	// The GUID must not change with the token, or apps would list every episode again
+	guid := fmt.Sprintf("%s/api/v1/tracks/%d", s.baseURL, track.ID)
+
+	item := rssFeedItem{
+		Title:             track.Title,
+		Description:       fmt.Sprintf("%s, part %d", audiobook.Title, track.Position),
+		GUID:              rssGUID{IsPermaLink: "false", Value: guid},
+		PubDate:           audiobook.CreatedAt.Add(time.Duration(track.Position) * time.Minute).UTC().Format(time.RFC1123Z),
+		Enclosure:         rssEnclosure{URL: enclosureURL, Type: enclosureType(track.URL)},
+		ITunesAuthor:      author,
+		ITunesEpisode:     track.Position,
+		ITunesEpisodeType: "full",
+		ITunesExplicit:    "false",
+	}
+	if track.DurationSeconds > 0 {
+		item.ITunesDuration = duration.Format(track.DurationSeconds)
+	}
+	return item
+}
+
+// enclosureType guesses the media type of a track's audio from its URL, defaulting to MP3
+func enclosureType(trackURL string) string {
+	ext := ""
+	if parsed, err := url.Parse(trackURL); err == nil {
+		ext = path.Ext(parsed.Path)
+	}
+	if contentType := mime.TypeByExtension(strings.ToLower(ext)); strings.HasPrefix(contentType, "audio/") {
+		return contentType
+	}
+	return "audio/mpeg"
+}
+
+// CreateToken creates a private feed token for a user; the token is only returned this once
+func (s *FeedService) CreateToken(userID string, req dto.CreateFeedTokenRequest) (*dto.FeedTokenResponse, error) {
+	secret := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(secret)
+
+	feedToken := &entity.FeedToken{
+		UserID:    userID,
+		Name:      strings.TrimSpace(req.Name),
+		TokenHash: hashFeedToken(token),
+	}
+	if err := s.feedTokenRepo.Create(feedToken); err != nil {
+		return nil, err
+	}
+
+	response := convertToFeedTokenResponse(feedToken)
+	response.Token = token
+	return &response, nil
+}
+
+// GetTokens retrieves the feed tokens of a user, without their secrets
+func (s *FeedService) GetTokens(userID string) (*dto.FeedTokenListResponse, error) {
+	tokens, err := s.feedTokenRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.FeedTokenListResponse{Tokens: make([]dto.FeedTokenResponse, len(tokens))}
+	for i := range tokens {
+		response.Tokens[i] = convertToFeedTokenResponse(&tokens[i])
+	}
+	return response, nil
+}
+
+// RevokeToken revokes a feed token of a user; feeds and audio links using it stop working at once
+func (s *FeedService) RevokeToken(userID string, id uint) error {
+	if err := s.feedTokenRepo.Revoke(userID, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("feed token not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// Authenticate returns the user a feed token belongs to, unless the token is unknown or revoked
+func (s *FeedService) Authenticate(token string) (string, error) {
+	feedToken, err := s.feedTokenRepo.GetActiveByHash(hashFeedToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrInvalidFeedToken
+		}
+		return "", err
+	}
+
+	// Podcast apps poll feeds, so only record the use when it moved on by a minute or more
+	now := time.Now()
+	if feedToken.LastUsedAt == nil || now.Sub(*feedToken.LastUsedAt) >= time.Minute {
+		if err := s.feedTokenRepo.TouchLastUsed(feedToken.ID, now); err != nil {
+			log.Printf("Failed to record use of feed token %d: %v", feedToken.ID, err)
+		}
+	}
+
+	return feedToken.UserID, nil
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func convertToFeedTokenResponse(token *entity.FeedToken) dto.FeedTokenResponse {
+	return dto.FeedTokenResponse{
+		ID:         token.ID,
+		Name:       token.Name,
+		LastUsedAt: token.LastUsedAt,
+		RevokedAt:  token.RevokedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
@@ -10,11 +10,15 @@ import (
 )
 
 type GenreService struct {
-	genreRepo repository.GenreRepositoryInterface
+	genreRepo       repository.GenreRepositoryInterface
+	searchIndexRepo repository.SearchIndexRepositoryInterface
 }
 
-func NewGenreService(genreRepo repository.GenreRepositoryInterface) *GenreService {
-	return &GenreService{genreRepo: genreRepo}
+func NewGenreService(genreRepo repository.GenreRepositoryInterface, searchIndexRepo repository.SearchIndexRepositoryInterface) *GenreService {
+	return &GenreService{
+		genreRepo:       genreRepo,
+		searchIndexRepo: searchIndexRepo,
+	}
 }
 
 // CreateGenre creates a new genre
@@ -28,8 +32,9 @@ func (s *GenreService) CreateGenre(req dto.CreateGenreRequest) (*dto.GenreRespon
 	}
 
 	return &dto.GenreResponse{
-		ID:   genre.ID,
-		Name: genre.Name,
+		ID:      genre.ID,
+		Name:    genre.Name,
+		Version: genre.Version,
 	}, nil
 }
 
@@ -44,18 +49,16 @@ func (s *GenreService) GetGenreByID(id uint) (*dto.GenreResponse, error) {
 	}
 
 	return &dto.GenreResponse{
-		ID:   genre.ID,
-		Name: genre.Name,
+		ID:      genre.ID,
+		Name:    genre.Name,
+		Version: genre.Version,
 	}, nil
 }
 
 // GetAllGenres retrieves all genres with pagination
-func (s *GenreService) GetAllGenres(req dto.PaginationRequest) (*dto.ListResponse, error) {
-	// Calculate offset
-	offset := (req.Page - 1) * req.Limit
-
+func (s *GenreService) GetAllGenres(req dto.ListRequest) (*dto.ListResponse, error) {
 	// Get paginated results
-	genres, total, err := s.genreRepo.GetAll(offset, req.Limit)
+	genres, result, err := s.genreRepo.GetAll(listPage(req))
 	if err != nil {
 		return nil, err
 	}
@@ -64,30 +67,20 @@ func (s *GenreService) GetAllGenres(req dto.PaginationRequest) (*dto.ListRespons
 	var genreResponses []dto.GenreResponse
 	for _, genre := range genres {
 		genreResponses = append(genreResponses, dto.GenreResponse{
-			ID:   genre.ID,
-			Name: genre.Name,
+			ID:      genre.ID,
+			Name:    genre.Name,
+			Version: genre.Version,
 		})
 	}
 
-	// Calculate total pages
-	totalPages := int(total) / req.Limit
-	if int(total)%req.Limit > 0 {
-		totalPages++
-	}
-
 	return &dto.ListResponse{
-		Items: genreResponses,
-		Pagination: dto.PaginationResponse{
-			Page:       req.Page,
-			Limit:      req.Limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
+		Items:      genreResponses,
+		Pagination: listPagination(req, result),
 	}, nil
 }
 
 // UpdateGenre updates an existing genre
-func (s *GenreService) UpdateGenre(id uint, req dto.UpdateGenreRequest) (*dto.GenreResponse, error) {
+func (s *GenreService) UpdateGenre(id, version uint, req dto.UpdateGenreRequest) (*dto.GenreResponse, error) {
 	genre, err := s.genreRepo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -96,20 +89,30 @@ func (s *GenreService) UpdateGenre(id uint, req dto.UpdateGenreRequest) (*dto.Ge
 		return nil, err
 	}
 
+	if err := checkVersion(genre.Version, version); err != nil {
+		return nil, err
+	}
+
 	genre.Name = req.Name
 	if err := s.genreRepo.Update(genre); err != nil {
 		return nil, err
 	}
 
+	// Audiobooks are found by the names of their genres
+	if err := s.searchIndexRepo.RefreshByGenreID(genre.ID); err != nil {
+		return nil, err
+	}
+
 	return &dto.GenreResponse{
-		ID:   genre.ID,
-		Name: genre.Name,
+		ID:      genre.ID,
+		Name:    genre.Name,
+		Version: genre.Version,
 	}, nil
 }
 
-// DeleteGenre deletes a genre
-func (s *GenreService) DeleteGenre(id uint) error {
-	_, err := s.genreRepo.GetByID(id)
+// DeleteGenre moves a genre to the trash; its audiobooks keep the link until the genre is purged
+func (s *GenreService) DeleteGenre(id, version uint) error {
+	genre, err := s.genreRepo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("genre not found")
@@ -117,7 +120,16 @@ func (s *GenreService) DeleteGenre(id uint) error {
 		return err
 	}
 
-	return s.genreRepo.Delete(id)
+	if err := checkVersion(genre.Version, version); err != nil {
+		return err
+	}
+
+	if err := s.genreRepo.Delete(id, version); err != nil {
+		return err
+	}
+
+	// Trashed genres no longer find their audiobooks
+	return s.searchIndexRepo.RefreshByGenreID(id)
 }
 
 // SearchGenres searches genres by name
@@ -135,8 +147,9 @@ func (s *GenreService) SearchGenres(req dto.SearchRequest) (*dto.ListResponse, e
 	var genreResponses []dto.GenreResponse
 	for _, genre := range genres {
 		genreResponses = append(genreResponses, dto.GenreResponse{
-			ID:   genre.ID,
-			Name: genre.Name,
+			ID:      genre.ID,
+			Name:    genre.Name,
+			Version: genre.Version,
 		})
 	}
 
@@ -167,8 +180,9 @@ func (s *GenreService) GetGenresByIDs(ids []uint) ([]dto.GenreResponse, error) {
 	var genreResponses []dto.GenreResponse
 	for _, genre := range genres {
 		genreResponses = append(genreResponses, dto.GenreResponse{
-			ID:   genre.ID,
-			Name: genre.Name,
+			ID:      genre.ID,
+			Name:    genre.Name,
+			Version: genre.Version,
 		})
 	}
 
@@ -0,0 +1,390 @@
+package service
+
+import (
+	"catalog-service/data_layer/entity"
+	"catalog-service/data_layer/repository"
+	"catalog-service/helpers/cache"
+	"catalog-service/helpers/hls"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const segmentedTrackManifest = "segments.json"
+
+// maxConcurrentSegmentations bounds how many tracks are segmented at the same time
+const maxConcurrentSegmentations = 2
+
+// failedSegmentationTTL is how long a failed segmentation is reported before it is tried again
+const failedSegmentationTTL = time.Minute
+
+var segmentNamePattern = regexp.MustCompile(`^segment_\d{5}\.mp3$`)
+
+// errPlaylistPreparing is returned while the segments of a playlist are being prepared in the background
+var errPlaylistPreparing = errors.New("playlist is being prepared")
+
+type HLSService struct {
+	trackRepo      repository.TrackRepositoryInterface
+	audiobookRepo  repository.AudiobookRepositoryInterface
+	streamService  *StreamService
+	streamURLs     *StreamURLService
+	cache          *cache.DiskLRUCache
+	targetDuration time.Duration
+	slots          chan struct{}
+
+	mu   sync.Mutex
+	jobs map[string]*segmentationJob
+}
+
+// segmentationJob is a segmentation running in the background, or one that failed recently.
+// Finished jobs are removed, so only failures stay in the map until they expire.
+type segmentationJob struct {
+	done     bool
+	err      error
+	finished time.Time
+}
+
+func NewHLSService(
+	trackRepo repository.TrackRepositoryInterface,
+	audiobookRepo repository.AudiobookRepositoryInterface,
+	streamService *StreamService,
+	streamURLs *StreamURLService,
+	diskCache *cache.DiskLRUCache,
+	targetDuration time.Duration,
+) *HLSService {
+	return &HLSService{
+		trackRepo:      trackRepo,
+		audiobookRepo:  audiobookRepo,
+		streamService:  streamService,
+		streamURLs:     streamURLs,
+		cache:          diskCache,
+		targetDuration: targetDuration,
+		slots:          make(chan struct{}, maxConcurrentSegmentations),
+		jobs:           make(map[string]*segmentationJob),
+	}
+}
+
+// GetTrackPlaylist returns the media playlist of a track with segment URIs signed for userID
+func (s *HLSService) GetTrackPlaylist(ctx context.Context, userID string, trackID uint) ([]byte, error) {
+	track, err := s.getTrack(trackID)
+	if err != nil {
+		return nil, err
+	}
+
+	segmented, err := s.segmentTrack(track)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.streamURLs.SignedPlaylistQuery(userID, track.ID, playlistDuration(segmented))
+
+	var segments []hls.PlaylistSegment
+	for _, segment := range segmented.Segments {
+		segments = append(segments, hls.PlaylistSegment{
+			URI:      segment.Name + "?" + query,
+			Duration: segment.Duration,
+		})
+	}
+
+	return hls.MediaPlaylist(segments), nil
+}
+
+// OpenTrackSegment returns a media segment of a track from the cache. The caller must close the
+// returned file.
+func (s *HLSService) OpenTrackSegment(ctx context.Context, trackID uint, name string) (*TrackStream, error) {
+	if !segmentNamePattern.MatchString(name) {
+		return nil, errors.New("segment not found")
+	}
+
+	track, err := s.getTrack(trackID)
+	if err != nil {
+		return nil, err
+	}
+
+	key := segmentedTrackKey(track)
+	file, ok := s.cache.Open(segmentKey(key, name))
+	if !ok {
+		// The segment was evicted, or the track was never segmented; segmentTrack starts it over
+		if _, err := s.segmentTrack(track); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("segment not found")
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &TrackStream{
+		File:        file,
+		Name:        name,
+		ContentType: "audio/mpeg",
+		ModTime:     info.ModTime(),
+	}, nil
+}
+
+// GetAudiobookPlaylist returns a media playlist playing every track of an audiobook in order,
+// with segment URIs signed for userID
+func (s *HLSService) GetAudiobookPlaylist(ctx context.Context, userID string, audiobookID uint) ([]byte, error) {
+	tracks, err := s.segmentAudiobook(audiobookID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every segment stays playable until the whole audiobook could have been played
+	var total time.Duration
+	for _, track := range tracks {
+		total += playlistDuration(track.segmented)
+	}
+
+	var segments []hls.PlaylistSegment
+	for i, track := range tracks {
+		query := s.streamURLs.SignedPlaylistQuery(userID, track.trackID, total)
+		for j, segment := range track.segmented.Segments {
+			segments = append(segments, hls.PlaylistSegment{
+				// Relative to /api/v1/audiobooks/:id/hls/
+				URI:           fmt.Sprintf("../../../tracks/%d/hls/%s?%s", track.trackID, segment.Name, query),
+				Duration:      segment.Duration,
+				Discontinuity: i > 0 && j == 0,
+			})
+		}
+	}
+
+	return hls.MediaPlaylist(segments), nil
+}
+
+// GetAudiobookMasterPlaylist returns the master playlist of an audiobook
+func (s *HLSService) GetAudiobookMasterPlaylist(ctx context.Context, audiobookID uint) ([]byte, error) {
+	tracks, err := s.segmentAudiobook(audiobookID)
+	if err != nil {
+		return nil, err
+	}
+
+	var peak, totalBits, totalDuration float64
+	for _, track := range tracks {
+		for _, segment := range track.segmented.Segments {
+			if segment.Duration <= 0 {
+				continue
+			}
+			bits := float64(segment.Size * 8)
+			if rate := bits / segment.Duration; rate > peak {
+				peak = rate
+			}
+			totalBits += bits
+			totalDuration += segment.Duration
+		}
+	}
+
+	average := 0.0
+	if totalDuration > 0 {
+		average = totalBits / totalDuration
+	}
+
+	return hls.MasterPlaylist("index.m3u8", int(peak), int(average)), nil
+}
+
+type segmentedAudiobookTrack struct {
+	trackID   uint
+	segmented *hls.SegmentedTrack
+}
+
+// segmentAudiobook returns the segments of every track of an audiobook in track order, starting the
+// segmentation of every track that is not in the cache
+func (s *HLSService) segmentAudiobook(audiobookID uint) ([]segmentedAudiobookTrack, error) {
+	if _, err := s.audiobookRepo.GetByID(audiobookID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("audiobook not found")
+		}
+		return nil, err
+	}
+
+	tracks, err := s.trackRepo.GetByAudiobookID(audiobookID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tracks) == 0 {
+		return nil, errors.New("audiobook has no tracks")
+	}
+
+	var result []segmentedAudiobookTrack
+	preparing := false
+	for i := range tracks {
+		segmented, err := s.segmentTrack(&tracks[i])
+		if errors.Is(err, errPlaylistPreparing) {
+			// Keep going, so every missing track is queued at once
+			preparing = true
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, segmentedAudiobookTrack{trackID: tracks[i].ID, segmented: segmented})
+	}
+	if preparing {
+		return nil, errPlaylistPreparing
+	}
+
+	return result, nil
+}
+
+// segmentTrack returns the cached segments of a track. On a miss it starts segmenting the track in the
+// background and returns errPlaylistPreparing, or the error of a segmentation that failed recently.
+func (s *HLSService) segmentTrack(track *entity.Track) (*hls.SegmentedTrack, error) {
+	key := segmentedTrackKey(track)
+
+	if segmented, ok := s.readSegmentedTrack(key); ok {
+		return segmented, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for jobKey, job := range s.jobs {
+		if job.done && now.Sub(job.finished) >= failedSegmentationTTL {
+			delete(s.jobs, jobKey)
+		}
+	}
+
+	if job, ok := s.jobs[key]; ok {
+		if job.done {
+			return nil, job.err
+		}
+		return nil, errPlaylistPreparing
+	}
+
+	job := &segmentationJob{}
+	s.jobs[key] = job
+	go s.runSegmentation(track, key, job)
+
+	return nil, errPlaylistPreparing
+}
+
+// runSegmentation segments a track into the cache, keeping the job only when it failed
+func (s *HLSService) runSegmentation(track *entity.Track, key string, job *segmentationJob) {
+	s.slots <- struct{}{}
+	defer func() { <-s.slots }()
+
+	var err error
+	// Another job may have finished the same segments while this one was being queued
+	if _, ok := s.readSegmentedTrack(key); !ok {
+		err = s.segmentIntoCache(track, key)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err == nil {
+		delete(s.jobs, key)
+		return
+	}
+
+	log.Printf("HLS: failed to segment track %d: %v", track.ID, err)
+	job.done = true
+	job.err = err
+	job.finished = time.Now()
+}
+
+// segmentIntoCache segments the audio of a track and adds the segments and their manifest to the cache.
+// The manifest goes in last, so a cached manifest means its segments were added too.
+func (s *HLSService) segmentIntoCache(track *entity.Track, key string) error {
+	// The segments fill a shared cache entry, so they do not depend on the request that asked for them
+	stream, err := s.streamService.OpenTrack(context.Background(), track.ID)
+	if err != nil {
+		return err
+	}
+	defer stream.File.Close()
+
+	tmpDir, err := s.cache.TempDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	segmented, err := hls.SegmentMP3(stream.File, tmpDir, s.targetDuration)
+	if err != nil {
+		if errors.Is(err, hls.ErrNoFrames) {
+			return errors.New("track audio is not a valid MP3")
+		}
+		return err
+	}
+
+	for _, segment := range segmented.Segments {
+		if err := s.cache.Put(segmentKey(key, segment.Name), filepath.Join(tmpDir, segment.Name)); err != nil {
+			return err
+		}
+	}
+
+	manifest, err := json.Marshal(segmented)
+	if err != nil {
+		return err
+	}
+	manifestPath := filepath.Join(tmpDir, segmentedTrackManifest)
+	if err := os.WriteFile(manifestPath, manifest, 0o644); err != nil {
+		return err
+	}
+	return s.cache.Put(segmentKey(key, segmentedTrackManifest), manifestPath)
+}
+
+// readSegmentedTrack returns the cached manifest of a track, as long as none of its segments were evicted
+func (s *HLSService) readSegmentedTrack(key string) (*hls.SegmentedTrack, bool) {
+	file, ok := s.cache.Open(segmentKey(key, segmentedTrackManifest))
+	if !ok {
+		return nil, false
+	}
+	defer file.Close()
+
+	var segmented hls.SegmentedTrack
+	if err := json.NewDecoder(file).Decode(&segmented); err != nil {
+		return nil, false
+	}
+
+	for _, segment := range segmented.Segments {
+		if !s.cache.Has(segmentKey(key, segment.Name)) {
+			return nil, false
+		}
+	}
+	return &segmented, true
+}
+
+func (s *HLSService) getTrack(trackID uint) (*entity.Track, error) {
+	track, err := s.trackRepo.GetByID(trackID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("track not found")
+		}
+		return nil, err
+	}
+	return track, nil
+}
+
+// segmentedTrackKey returns the cache key prefix of the segments of a track. It includes the URL, so
+// replacing the audio of a track invalidates its segments.
+func segmentedTrackKey(track *entity.Track) string {
+	return fmt.Sprintf("track-%d-%s", track.ID, cacheKey(track.URL)[:16])
+}
+
+// segmentKey returns the cache key of a media segment or the manifest of a track
+func segmentKey(trackKey, name string) string {
+	return trackKey + "-" + name
+}
+
+// playlistDuration returns how long the segments of a track play
+func playlistDuration(segmented *hls.SegmentedTrack) time.Duration {
+	var seconds float64
+	for _, segment := range segmented.Segments {
+		seconds += segment.Duration
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
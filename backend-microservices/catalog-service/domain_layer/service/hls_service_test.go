@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bytes"
+	"catalog-service/data_layer/entity"
+	"catalog-service/data_layer/repository"
+	"catalog-service/helpers/cache"
+	"catalog-service/helpers/signing"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stubTrackRepository serves tracks from a map; other methods are not used by these tests
+type stubTrackRepository struct {
+	repository.TrackRepositoryInterface
+	tracks map[uint]*entity.Track
+}
+
+func (r *stubTrackRepository) GetByID(id uint) (*entity.Track, error) {
+	track, ok := r.tracks[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	return track, nil
+}
+
+// stubAudioFetcher serves the same body and validator for every URL and counts the fetches; it fails
+// every fetch with err when set
+type stubAudioFetcher struct {
+	body      []byte
+	validator string
+	err       error
+	fetches   atomic.Int32
+}
+
+func (f *stubAudioFetcher) Fetch(ctx context.Context, url string) (*UpstreamFile, error) {
+	f.fetches.Add(1)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &UpstreamFile{Body: io.NopCloser(bytes.NewReader(f.body)), Validator: f.validator}, nil
+}
+
+// mp3Frames returns n silent MPEG-1 Layer III frames at 128 kbps and 44.1 kHz, 417 bytes each
+func mp3Frames(n int) []byte {
+	frame := make([]byte, 417)
+	copy(frame, []byte{0xFF, 0xFB, 0x90, 0x00})
+	return bytes.Repeat(frame, n)
+}
+
+func newTestHLSService(t *testing.T, fetcher AudioFetcher) *HLSService {
+	t.Helper()
+
+	trackRepo := &stubTrackRepository{tracks: map[uint]*entity.Track{
+		1: {ID: 1, AudiobookID: 1, Title: "Chapter 1", URL: "https://example.com/chapter1.mp3"},
+	}}
+
+	streamCache, err := cache.NewDiskLRUCache(t.TempDir(), 1<<30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hlsCache, err := cache.NewDiskLRUCache(t.TempDir(), 1<<30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signing.NewURLSigner([]signing.Key{{ID: "k1", Secret: []byte("secret")}}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	streamService := NewStreamService(trackRepo, fetcher, streamCache)
+	streamURLs := NewStreamURLService(signer, "http://localhost")
+	return NewHLSService(trackRepo, nil, streamService, streamURLs, hlsCache, time.Second)
+}
+
+// waitForPlaylist asks for the playlist of a track until its background segmentation is done
+func waitForPlaylist(t *testing.T, s *HLSService, trackID uint) []byte {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		playlist, err := s.GetTrackPlaylist(context.Background(), "user-1", trackID)
+		if err == nil {
+			return playlist
+		}
+		if !errors.Is(err, errPlaylistPreparing) {
+			t.Fatalf("GetTrackPlaylist: %v", err)
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("playlist still being prepared")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHLSServiceSegmentsTrackAndReadsPlaylistBack(t *testing.T) {
+	// 100 frames of 1152 samples at 44.1 kHz: 2.61 seconds, three segments of at most a second
+	fetcher := &stubAudioFetcher{body: mp3Frames(100)}
+	s := newTestHLSService(t, fetcher)
+
+	if _, err := s.GetTrackPlaylist(context.Background(), "user-1", 1); !errors.Is(err, errPlaylistPreparing) {
+		t.Fatalf("first request: got %v, want %v", err, errPlaylistPreparing)
+	}
+
+	playlist := string(waitForPlaylist(t, s, 1))
+	for _, name := range []string{"segment_00000.mp3", "segment_00001.mp3", "segment_00002.mp3"} {
+		if !strings.Contains(playlist, name+"?") {
+			t.Errorf("playlist does not list %s:\n%s", name, playlist)
+		}
+	}
+	if strings.Contains(playlist, "segment_00003.mp3") {
+		t.Errorf("playlist lists more segments than the audio has:\n%s", playlist)
+	}
+
+	// The cached manifest answers every later request without segmenting again
+	for i := 0; i < 3; i++ {
+		if _, err := s.GetTrackPlaylist(context.Background(), "user-1", 1); err != nil {
+			t.Fatalf("cached request %d: %v", i, err)
+		}
+	}
+	if fetches := fetcher.fetches.Load(); fetches != 1 {
+		t.Errorf("audio fetched %d times, want 1", fetches)
+	}
+
+	segment, err := s.OpenTrackSegment(context.Background(), 1, "segment_00001.mp3")
+	if err != nil {
+		t.Fatalf("OpenTrackSegment: %v", err)
+	}
+	defer segment.File.Close()
+
+	data, err := io.ReadAll(segment.File)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("ID3")) {
+		t.Errorf("segment does not start with its timestamp tag")
+	}
+}
+
+func TestHLSServiceRejectsUnknownSegmentNames(t *testing.T) {
+	s := newTestHLSService(t, &stubAudioFetcher{body: mp3Frames(10)})
+
+	for _, name := range []string{"segments.json", "../segment_00000.mp3", "segment_0.mp3"} {
+		if _, err := s.OpenTrackSegment(context.Background(), 1, name); err == nil || err.Error() != "segment not found" {
+			t.Errorf("OpenTrackSegment(%q): got %v, want segment not found", name, err)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/data_layer/repository"
+)
+
+// listPage converts the sort and pagination parameters of a list request to a repository page
+func listPage(req dto.ListRequest) repository.ListPage {
+	return repository.ListPage{
+		Sort:   req.Sort,
+		Offset: (req.Page - 1) * req.Limit,
+		Limit:  req.Limit,
+		Cursor: req.Cursor,
+	}
+}
+
+// listPagination builds the pagination metadata of a page fetched with listPage
+func listPagination(req dto.ListRequest, result repository.PageResult) dto.PaginationResponse {
+	totalPages := int(result.Total) / req.Limit
+	if int(result.Total)%req.Limit > 0 {
+		totalPages++
+	}
+
+	pagination := dto.PaginationResponse{
+		Page:       req.Page,
+		Limit:      req.Limit,
+		Total:      result.Total,
+		TotalPages: totalPages,
+		NextCursor: result.NextCursor,
+	}
+	if req.Cursor != "" {
+		// Pages reached by cursor have no page number
+		pagination.Page = 0
+	}
+	return pagination
+}
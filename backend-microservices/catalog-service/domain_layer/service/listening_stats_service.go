@@ -0,0 +1,220 @@
+package service
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/data_layer/repository"
+	"errors"
+	"time"
+)
+
+// Listening periods, named after the PostgreSQL date_trunc units they group by
+const (
+	ListeningPeriodDay   = "day"
+	ListeningPeriodWeek  = "week"
+	ListeningPeriodMonth = "month"
+)
+
+// streakMinSeconds is how long a user must listen on a day for it to count towards a streak
+const streakMinSeconds = 60
+
+// defaultTopGenresDays is the window top genres are ranked over when no range is given
+const defaultTopGenresDays = 30
+
+// maxListeningRangeDays is the longest range, counting both ends, that stats are computed over
+const maxListeningRangeDays = 366
+
+type ListeningStatsService struct {
+	ledgerRepo repository.ListeningLedgerRepositoryInterface
+}
+
+func NewListeningStatsService(ledgerRepo repository.ListeningLedgerRepositoryInterface) *ListeningStatsService {
+	return &ListeningStatsService{ledgerRepo: ledgerRepo}
+}
+
+// GetTotals retrieves the listening time of a user per day, week or month.
+// Zero times select a default range ending today; periods without listening are included as zero.
+func (s *ListeningStatsService) GetTotals(userID string, period string, from, to time.Time) (*dto.ListeningTotalsResponse, error) {
+	from, to, err := s.resolveRange(period, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.ledgerRepo.GetTotals(userID, period, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	byPeriod := make(map[time.Time]repository.ListeningTotal, len(totals))
+	for _, total := range totals {
+		byPeriod[listeningDay(total.Period)] = total
+	}
+
+	response := &dto.ListeningTotalsResponse{
+		Period: period,
+		From:   from,
+		To:     to,
+		Totals: []dto.ListeningTotalResponse{},
+	}
+	for start := periodStart(period, from); !start.After(to); start = nextPeriod(period, start) {
+		total := byPeriod[start]
+		response.Totals = append(response.Totals, dto.ListeningTotalResponse{
+			PeriodStart:     start,
+			ListenedSeconds: total.ListenedSeconds,
+			ContentSeconds:  total.ContentSeconds,
+		})
+		response.ListenedSeconds += total.ListenedSeconds
+		response.ContentSeconds += total.ContentSeconds
+	}
+
+	return response, nil
+}
+
+// GetStreak retrieves the current and longest runs of consecutive listening days of a user.
+// The current streak stays alive until a full day passes without listening.
+func (s *ListeningStatsService) GetStreak(userID string) (*dto.ListeningStreakResponse, error) {
+	days, err := s.ledgerRepo.GetListeningDays(userID, streakMinSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ListeningStreakResponse{MinSecondsPerDay: streakMinSeconds}
+	if len(days) == 0 {
+		return response, nil
+	}
+
+	// Days arrive most recent first
+	lastListenedOn := listeningDay(days[0])
+	response.LastListenedOn = &lastListenedOn
+
+	run := 0
+	currentEnded := false
+	var previous time.Time
+	for i, day := range days {
+		day = listeningDay(day)
+		if i > 0 && day.AddDate(0, 0, 1).Equal(previous) {
+			run++
+		} else {
+			currentEnded = i > 0
+			run = 1
+		}
+		if run > response.LongestStreakDays {
+			response.LongestStreakDays = run
+		}
+		if !currentEnded {
+			response.CurrentStreakDays = run
+		}
+		previous = day
+	}
+
+	if lastListenedOn.Before(listeningDay(time.Now()).AddDate(0, 0, -1)) {
+		response.CurrentStreakDays = 0
+	}
+
+	return response, nil
+}
+
+// GetTopGenres ranks the genres a user listened to by listening time.
+// An audiobook in several genres counts fully towards each of them.
+func (s *ListeningStatsService) GetTopGenres(userID string, from, to time.Time, limit int) (*dto.TopGenresResponse, error) {
+	if to.IsZero() {
+		to = listeningDay(time.Now())
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -(defaultTopGenresDays - 1))
+	}
+	if err := checkListeningRange(from, to); err != nil {
+		return nil, err
+	}
+
+	totals, err := s.ledgerRepo.GetTopGenres(userID, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.TopGenresResponse{
+		From:   from,
+		To:     to,
+		Genres: make([]dto.GenreListeningResponse, 0, len(totals)),
+	}
+	for _, total := range totals {
+		response.Genres = append(response.Genres, dto.GenreListeningResponse{
+			GenreID:         total.GenreID,
+			GenreName:       total.GenreName,
+			ListenedSeconds: total.ListenedSeconds,
+		})
+	}
+
+	return response, nil
+}
+
+// Helper methods
+func (s *ListeningStatsService) resolveRange(period string, from, to time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = listeningDay(time.Now())
+	}
+
+	if from.IsZero() {
+		switch period {
+		case ListeningPeriodDay:
+			from = to.AddDate(0, 0, -29)
+		case ListeningPeriodWeek:
+			from = periodStart(period, to).AddDate(0, 0, -7*11)
+		case ListeningPeriodMonth:
+			from = periodStart(period, to).AddDate(0, -11, 0)
+		}
+	}
+
+	switch period {
+	case ListeningPeriodDay, ListeningPeriodWeek, ListeningPeriodMonth:
+	default:
+		return from, to, errors.New("invalid listening period")
+	}
+
+	if err := checkListeningRange(from, to); err != nil {
+		return from, to, err
+	}
+
+	return from, to, nil
+}
+
+// checkListeningRange rejects a range that ends before it starts or spans more than
+// maxListeningRangeDays days
+func checkListeningRange(from, to time.Time) error {
+	if from.After(to) {
+		return errors.New("from must not be after to")
+	}
+	if listeningDay(to).Sub(listeningDay(from)) >= maxListeningRangeDays*24*time.Hour {
+		return errors.New("range must not exceed 366 days")
+	}
+	return nil
+}
+
+// listeningDay truncates a time to the UTC calendar day the ledger books it on
+func listeningDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// periodStart returns the first day of the period containing day, with weeks starting on Monday like date_trunc
+func periodStart(period string, day time.Time) time.Time {
+	day = listeningDay(day)
+	switch period {
+	case ListeningPeriodWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case ListeningPeriodMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+func nextPeriod(period string, start time.Time) time.Time {
+	switch period {
+	case ListeningPeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case ListeningPeriodMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
@@ -0,0 +1,390 @@
+package service
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/data_layer/entity"
+	"catalog-service/data_layer/repository"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// playFinishThreshold is the share of the final track that counts as finishing the audiobook
+const playFinishThreshold = 0.95
+
+// staleSessionBatchSize bounds how many stale sessions one sweep closes
+const staleSessionBatchSize = 500
+
+type PlaySessionService struct {
+	sessionRepo       repository.PlaySessionRepositoryInterface
+	trackRepo         repository.TrackRepositoryInterface
+	analyticsRepo     repository.AnalyticsRepositoryInterface
+	ledgerRepo        repository.ListeningLedgerRepositoryInterface
+	unitOfWork        repository.UnitOfWorkInterface
+	heartbeatInterval time.Duration
+	staleAfter        time.Duration
+}
+
+func NewPlaySessionService(
+	sessionRepo repository.PlaySessionRepositoryInterface,
+	trackRepo repository.TrackRepositoryInterface,
+	analyticsRepo repository.AnalyticsRepositoryInterface,
+	ledgerRepo repository.ListeningLedgerRepositoryInterface,
+	unitOfWork repository.UnitOfWorkInterface,
+	heartbeatInterval time.Duration,
+	staleAfter time.Duration,
+) *PlaySessionService {
+	return &PlaySessionService{
+		sessionRepo:       sessionRepo,
+		trackRepo:         trackRepo,
+		analyticsRepo:     analyticsRepo,
+		ledgerRepo:        ledgerRepo,
+		unitOfWork:        unitOfWork,
+		heartbeatInterval: heartbeatInterval,
+		staleAfter:        staleAfter,
+	}
+}
+
+// StartSession opens a playback session and records a PLAY_START event in one transaction.
+// Sessions the user still has open elsewhere are stopped first.
+func (s *PlaySessionService) StartSession(userID string, req dto.StartPlaySessionRequest) (*dto.PlaySessionResponse, error) {
+	if _, err := s.getAudiobookTrack(req.AudiobookID, req.TrackID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := entity.PlaySession{
+		UserID:          userID,
+		AudiobookID:     req.AudiobookID,
+		TrackID:         req.TrackID,
+		PositionSeconds: req.PositionSeconds,
+		DurationSeconds: req.DurationSeconds,
+		Speed:           normalizeSpeed(req.Speed),
+		Status:          entity.PlaySessionActive,
+		StartedAt:       now,
+		LastHeartbeatAt: now,
+	}
+
+	err := s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		// Starts of the same user take turns, so only the last one leaves a session active
+		if err := repos.PlaySessions.LockUser(userID); err != nil {
+			return err
+		}
+
+		active, err := repos.PlaySessions.GetActiveByUserID(userID)
+		if err != nil {
+			return err
+		}
+		for _, other := range active {
+			if _, err := repos.PlaySessions.CloseIfActive(other.ID, entity.PlaySessionStopped, now); err != nil {
+				return err
+			}
+		}
+
+		if err := repos.PlaySessions.Create(&session); err != nil {
+			return err
+		}
+
+		return recordEvent(repos.Analytics, &session, "PLAY_START", now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToPlaySessionResponse(&session), nil
+}
+
+// Heartbeat records the playback position of an active session.
+// Reaching the end of the final track finishes the session and records a PLAY_FINISH event.
+func (s *PlaySessionService) Heartbeat(userID string, id uint, req dto.PlaySessionHeartbeatRequest) (*dto.PlaySessionResponse, error) {
+	session, err := s.applyHeartbeat(userID, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToPlaySessionResponse(session), nil
+}
+
+// StopSession records the final position of a session and closes it
+func (s *PlaySessionService) StopSession(userID string, id uint, req dto.PlaySessionHeartbeatRequest) (*dto.PlaySessionResponse, error) {
+	session, err := s.applyHeartbeat(userID, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Status == entity.PlaySessionActive {
+		now := time.Now()
+		closed, err := s.sessionRepo.CloseIfActive(session.ID, entity.PlaySessionStopped, now)
+		if err != nil {
+			return nil, err
+		}
+		if !closed {
+			return nil, errors.New("play session is not active")
+		}
+		session.Status = entity.PlaySessionStopped
+		session.EndedAt = &now
+	}
+
+	return s.convertToPlaySessionResponse(session), nil
+}
+
+// GetSessionByID retrieves a playback session of a user
+func (s *PlaySessionService) GetSessionByID(userID string, id uint) (*dto.PlaySessionResponse, error) {
+	session, err := s.getOwnSession(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToPlaySessionResponse(session), nil
+}
+
+// CloseStaleSessions marks sessions without a recent heartbeat as abandoned and records PLAY_ABANDON
+// events, each session in its own transaction
+func (s *PlaySessionService) CloseStaleSessions() (int, error) {
+	now := time.Now()
+
+	sessions, err := s.sessionRepo.GetStale(now.Add(-s.staleAfter), staleSessionBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	closedCount := 0
+	for _, session := range sessions {
+		// The session ended when it was last heard from, not when the sweeper noticed
+		endedAt := session.LastHeartbeatAt
+
+		closed := false
+		err := s.unitOfWork.Do(func(repos *repository.Repositories) error {
+			if err := repos.PlaySessions.LockUser(session.UserID); err != nil {
+				return err
+			}
+
+			var err error
+			closed, err = repos.PlaySessions.CloseIfActive(session.ID, entity.PlaySessionAbandoned, endedAt)
+			if err != nil || !closed {
+				// When not closed, a heartbeat, stop or new start won the race
+				return err
+			}
+
+			return recordEvent(repos.Analytics, &session, "PLAY_ABANDON", endedAt)
+		})
+		if err != nil {
+			return closedCount, err
+		}
+		if closed {
+			closedCount++
+		}
+	}
+
+	return closedCount, nil
+}
+
+// RunSweeper closes stale sessions every interval until ctx is cancelled
+func (s *PlaySessionService) RunSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			closed, err := s.CloseStaleSessions()
+			if err != nil {
+				log.Printf("Play session sweeper: %v", err)
+			}
+			if closed > 0 {
+				log.Printf("Play session sweeper: closed %d stale sessions", closed)
+			}
+		}
+	}
+}
+
+// Helper methods
+func (s *PlaySessionService) applyHeartbeat(userID string, id uint, req dto.PlaySessionHeartbeatRequest) (*entity.PlaySession, error) {
+	session, err := s.getOwnSession(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != entity.PlaySessionActive {
+		return nil, errors.New("play session is not active")
+	}
+
+	if req.TrackID != session.TrackID {
+		if _, err := s.getAudiobookTrack(session.AudiobookID, req.TrackID); err != nil {
+			return nil, err
+		}
+		session.DurationSeconds = 0
+	}
+
+	now := time.Now()
+	previous := *session
+	session.TrackID = req.TrackID
+	session.PositionSeconds = req.PositionSeconds
+	if req.DurationSeconds > 0 {
+		session.DurationSeconds = req.DurationSeconds
+	}
+	if req.Speed > 0 {
+		session.Speed = req.Speed
+	}
+	session.LastHeartbeatAt = now
+
+	updated, err := s.sessionRepo.UpdateIfActive(session)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, errors.New("play session is not active")
+	}
+
+	if err := s.recordListening(&previous, session, now); err != nil {
+		return nil, err
+	}
+
+	finished, err := s.reachedEnd(session)
+	if err != nil {
+		return nil, err
+	}
+	if !finished {
+		return session, nil
+	}
+
+	closed, err := s.sessionRepo.CloseIfActive(session.ID, entity.PlaySessionFinished, now)
+	if err != nil {
+		return nil, err
+	}
+	if closed {
+		if err := recordEvent(s.analyticsRepo, session, "PLAY_FINISH", now); err != nil {
+			return nil, err
+		}
+		session.Status = entity.PlaySessionFinished
+		session.EndedAt = &now
+	}
+
+	return session, nil
+}
+
+// recordListening credits the ledger with the time listened between two heartbeats of a session.
+// The content covered is capped by the wall-clock time at the previous speed, so seeking forward
+// is not counted as listening, and seeking backward credits nothing for that interval.
+func (s *PlaySessionService) recordListening(previous, current *entity.PlaySession, now time.Time) error {
+	elapsed := now.Sub(previous.LastHeartbeatAt).Seconds()
+	if elapsed <= 0 {
+		return nil
+	}
+	if stale := s.staleAfter.Seconds(); elapsed > stale {
+		elapsed = stale
+	}
+
+	// Moving to another track is assumed to start it from the beginning
+	covered := float64(current.PositionSeconds)
+	if current.TrackID == previous.TrackID {
+		covered -= float64(previous.PositionSeconds)
+	}
+	if covered <= 0 {
+		return nil
+	}
+
+	speed := normalizeSpeed(previous.Speed)
+	listened := covered / speed
+	if listened > elapsed {
+		listened = elapsed
+	}
+
+	return s.ledgerRepo.AddListening(&entity.ListeningLedger{
+		UserID:          current.UserID,
+		Day:             listeningDay(now),
+		TrackID:         current.TrackID,
+		AudiobookID:     current.AudiobookID,
+		ListenedSeconds: listened,
+		ContentSeconds:  listened * speed,
+	})
+}
+
+// reachedEnd reports whether a session is past the finish threshold of the audiobook's final track
+func (s *PlaySessionService) reachedEnd(session *entity.PlaySession) (bool, error) {
+	if session.DurationSeconds <= 0 {
+		return false, nil
+	}
+	if float64(session.PositionSeconds) < playFinishThreshold*float64(session.DurationSeconds) {
+		return false, nil
+	}
+
+	tracks, err := s.trackRepo.GetByAudiobookID(session.AudiobookID)
+	if err != nil {
+		return false, err
+	}
+
+	return len(tracks) > 0 && tracks[len(tracks)-1].ID == session.TrackID, nil
+}
+
+func (s *PlaySessionService) getOwnSession(userID string, id uint) (*entity.PlaySession, error) {
+	session, err := s.sessionRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("play session not found")
+		}
+		return nil, err
+	}
+
+	if session.UserID != userID {
+		return nil, errors.New("play session not found")
+	}
+
+	return session, nil
+}
+
+func (s *PlaySessionService) getAudiobookTrack(audiobookID, trackID uint) (*entity.Track, error) {
+	track, err := s.trackRepo.GetByID(trackID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("track not found")
+		}
+		return nil, err
+	}
+
+	if track.AudiobookID != audiobookID {
+		return nil, errors.New("track does not belong to audiobook")
+	}
+
+	return track, nil
+}
+
+// recordEvent stores a playback analytics event of a session
+func recordEvent(analyticsRepo repository.AnalyticsRepositoryInterface, session *entity.PlaySession, eventType string, at time.Time) error {
+	analytics := entity.Analytics{
+		AudiobookID:    session.AudiobookID,
+		UserID:         session.UserID,
+		EventType:      eventType,
+		EventTimestamp: at,
+	}
+
+	return analyticsRepo.Create(&analytics)
+}
+
+func (s *PlaySessionService) convertToPlaySessionResponse(session *entity.PlaySession) *dto.PlaySessionResponse {
+	return &dto.PlaySessionResponse{
+		ID:                       session.ID,
+		AudiobookID:              session.AudiobookID,
+		TrackID:                  session.TrackID,
+		PositionSeconds:          session.PositionSeconds,
+		DurationSeconds:          session.DurationSeconds,
+		Speed:                    session.Speed,
+		Status:                   session.Status,
+		StartedAt:                session.StartedAt,
+		LastHeartbeatAt:          session.LastHeartbeatAt,
+		EndedAt:                  session.EndedAt,
+		HeartbeatIntervalSeconds: int(s.heartbeatInterval / time.Second),
+	}
+}
+
+// normalizeSpeed defaults an unset playback speed to 1x
+func normalizeSpeed(speed float64) float64 {
+	if speed <= 0 {
+		return 1
+	}
+	return speed
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/data_layer/entity"
+	"catalog-service/data_layer/repository"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// maxQueueEntries bounds the length of a user's play queue
+const maxQueueEntries = 1000
+
+type QueueService struct {
+	queueRepo     repository.QueueRepositoryInterface
+	trackRepo     repository.TrackRepositoryInterface
+	audiobookRepo repository.AudiobookRepositoryInterface
+	unitOfWork    repository.UnitOfWorkInterface
+}
+
+func NewQueueService(
+	queueRepo repository.QueueRepositoryInterface,
+	trackRepo repository.TrackRepositoryInterface,
+	audiobookRepo repository.AudiobookRepositoryInterface,
+	unitOfWork repository.UnitOfWorkInterface,
+) *QueueService {
+	return &QueueService{
+		queueRepo:     queueRepo,
+		trackRepo:     trackRepo,
+		audiobookRepo: audiobookRepo,
+		unitOfWork:    unitOfWork,
+	}
+}
+
+// GetQueue retrieves the play queue of a user
+func (s *QueueService) GetQueue(userID string) (*dto.QueueResponse, error) {
+	entries, err := loadQueue(s.queueRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToQueueResponse(entries), nil
+}
+
+// Enqueue adds a track, or every track of an audiobook in order, to the play queue.
+// Entries go to the end of the queue unless a position is given.
+func (s *QueueService) Enqueue(userID string, req dto.EnqueueRequest) (*dto.QueueResponse, error) {
+	if (req.TrackID == 0) == (req.AudiobookID == 0) {
+		return nil, errors.New("either track_id or audiobook_id is required")
+	}
+
+	tracks, err := s.resolveTracks(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The queue is locked while it is shifted, so concurrent writes cannot claim the same positions
+	var queue []entity.QueueEntry
+	err = s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		if err := repos.Queue.LockUser(userID); err != nil {
+			return err
+		}
+
+		entries, err := loadQueue(repos.Queue, userID)
+		if err != nil {
+			return err
+		}
+		if len(entries)+len(tracks) > maxQueueEntries {
+			return errors.New("play queue is full")
+		}
+
+		at := len(entries)
+		if req.Position != nil && *req.Position < at {
+			at = *req.Position
+		}
+
+		added := make([]entity.QueueEntry, 0, len(tracks))
+		for _, track := range tracks {
+			added = append(added, entity.QueueEntry{
+				UserID:      userID,
+				TrackID:     track.ID,
+				AudiobookID: track.AudiobookID,
+				Track:       track,
+			})
+		}
+
+		queue = make([]entity.QueueEntry, 0, len(entries)+len(added))
+		queue = append(queue, entries[:at]...)
+		queue = append(queue, added...)
+		queue = append(queue, entries[at:]...)
+
+		// Existing entries after the insertion point shift back; new ones are created in place
+		var moved []entity.QueueEntry
+		for i := range queue {
+			if queue[i].ID != 0 && queue[i].Position != i {
+				queue[i].Position = i
+				moved = append(moved, queue[i])
+			}
+			queue[i].Position = i
+		}
+		if err := repos.Queue.UpdatePositions(moved); err != nil {
+			return err
+		}
+
+		return repos.Queue.CreateMany(queue[at : at+len(added)])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToQueueResponse(queue), nil
+}
+
+// ReorderQueue puts the queue into the given order, which must list every entry exactly once
+func (s *QueueService) ReorderQueue(userID string, req dto.ReorderQueueRequest) (*dto.QueueResponse, error) {
+	var queue []entity.QueueEntry
+	err := s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		if err := repos.Queue.LockUser(userID); err != nil {
+			return err
+		}
+
+		entries, err := loadQueue(repos.Queue, userID)
+		if err != nil {
+			return err
+		}
+		if len(req.EntryIDs) != len(entries) {
+			return errors.New("entry_ids must list every queue entry exactly once")
+		}
+
+		byID := make(map[uint]entity.QueueEntry, len(entries))
+		for _, entry := range entries {
+			byID[entry.ID] = entry
+		}
+
+		queue = make([]entity.QueueEntry, 0, len(entries))
+		for i, id := range req.EntryIDs {
+			entry, ok := byID[id]
+			if !ok {
+				return errors.New("entry_ids must list every queue entry exactly once")
+			}
+			delete(byID, id)
+			entry.Position = i
+			queue = append(queue, entry)
+		}
+
+		return repos.Queue.UpdatePositions(queue)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToQueueResponse(queue), nil
+}
+
+// RemoveEntry removes one entry from the play queue of a user, moving the entries after it up
+func (s *QueueService) RemoveEntry(userID string, id uint) (*dto.QueueResponse, error) {
+	var queue []entity.QueueEntry
+	err := s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		if err := repos.Queue.LockUser(userID); err != nil {
+			return err
+		}
+
+		entries, err := loadQueue(repos.Queue, userID)
+		if err != nil {
+			return err
+		}
+
+		for i, entry := range entries {
+			if entry.ID != id {
+				continue
+			}
+			if err := repos.Queue.DeleteByIDs(userID, []uint{id}); err != nil {
+				return err
+			}
+			queue = append(entries[:i:i], entries[i+1:]...)
+			return renumberQueue(repos.Queue, queue)
+		}
+
+		return errors.New("queue entry not found")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToQueueResponse(queue), nil
+}
+
+// ClearQueue removes every entry from the play queue of a user
+func (s *QueueService) ClearQueue(userID string) error {
+	return s.queueRepo.DeleteByUserID(userID)
+}
+
+// AdvanceQueue takes the next track off the head of the queue so playback can continue with it.
+// NowPlaying is nil once the queue is exhausted.
+func (s *QueueService) AdvanceQueue(userID string) (*dto.AdvanceQueueResponse, error) {
+	response := &dto.AdvanceQueueResponse{UpNext: []dto.QueueEntryResponse{}}
+
+	// Under the lock, concurrent advances each take a different head
+	err := s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		if err := repos.Queue.LockUser(userID); err != nil {
+			return err
+		}
+
+		entries, err := loadQueue(repos.Queue, userID)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		head := entries[0]
+		if err := repos.Queue.DeleteByIDs(userID, []uint{head.ID}); err != nil {
+			return err
+		}
+		if err := renumberQueue(repos.Queue, entries[1:]); err != nil {
+			return err
+		}
+
+		response.NowPlaying = s.convertToQueueEntryResponse(&head, 0)
+		response.UpNext = s.convertToQueueResponse(entries[1:]).Entries
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Helper methods
+
+// loadQueue retrieves the queue of a user and drops entries whose track is no longer in the catalog
+func loadQueue(queueRepo repository.QueueRepositoryInterface, userID string) ([]entity.QueueEntry, error) {
+	entries, err := queueRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	valid := make([]entity.QueueEntry, 0, len(entries))
+	var stale []uint
+	for _, entry := range entries {
+		if entry.Track.ID == 0 {
+			stale = append(stale, entry.ID)
+			continue
+		}
+		valid = append(valid, entry)
+	}
+
+	if err := queueRepo.DeleteByIDs(userID, stale); err != nil {
+		return nil, err
+	}
+
+	return valid, nil
+}
+
+// renumberQueue numbers the entries of a queue from 0 in their order, saving the positions that changed
+func renumberQueue(queueRepo repository.QueueRepositoryInterface, entries []entity.QueueEntry) error {
+	var moved []entity.QueueEntry
+	for i := range entries {
+		if entries[i].Position != i {
+			entries[i].Position = i
+			moved = append(moved, entries[i])
+		}
+	}
+	return queueRepo.UpdatePositions(moved)
+}
+
+func (s *QueueService) resolveTracks(req dto.EnqueueRequest) ([]entity.Track, error) {
+	if req.TrackID != 0 {
+		track, err := s.trackRepo.GetByID(req.TrackID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("track not found")
+			}
+			return nil, err
+		}
+		return []entity.Track{*track}, nil
+	}
+
+	if _, err := s.audiobookRepo.GetByID(req.AudiobookID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("audiobook not found")
+		}
+		return nil, err
+	}
+
+	tracks, err := s.trackRepo.GetByAudiobookID(req.AudiobookID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tracks) == 0 {
+		return nil, errors.New("audiobook has no tracks")
+	}
+
+	return tracks, nil
+}
+
+func (s *QueueService) convertToQueueResponse(entries []entity.QueueEntry) *dto.QueueResponse {
+	response := &dto.QueueResponse{
+		Entries: make([]dto.QueueEntryResponse, 0, len(entries)),
+		Total:   len(entries),
+	}
+	for i := range entries {
+		response.Entries = append(response.Entries, *s.convertToQueueEntryResponse(&entries[i], i))
+	}
+	return response
+}
+
+func (s *QueueService) convertToQueueEntryResponse(entry *entity.QueueEntry, position int) *dto.QueueEntryResponse {
+	return &dto.QueueEntryResponse{
+		ID:          entry.ID,
+		Position:    position,
+		TrackID:     entry.TrackID,
+		TrackTitle:  entry.Track.Title,
+		Duration:    entry.Track.Duration,
+		AudiobookID: entry.AudiobookID,
+		CreatedAt:   entry.CreatedAt,
+	}
+}
@@ -10,11 +10,15 @@ import (
 )
 
 type ReaderService struct {
-	readerRepo repository.ReaderRepositoryInterface
+	readerRepo      repository.ReaderRepositoryInterface
+	searchIndexRepo repository.SearchIndexRepositoryInterface
 }
 
-func NewReaderService(readerRepo repository.ReaderRepositoryInterface) *ReaderService {
-	return &ReaderService{readerRepo: readerRepo}
+func NewReaderService(readerRepo repository.ReaderRepositoryInterface, searchIndexRepo repository.SearchIndexRepositoryInterface) *ReaderService {
+	return &ReaderService{
+		readerRepo:      readerRepo,
+		searchIndexRepo: searchIndexRepo,
+	}
 }
 
 // CreateReader creates a new reader
@@ -28,8 +32,9 @@ func (s *ReaderService) CreateReader(req dto.CreateReaderRequest) (*dto.ReaderRe
 	}
 
 	return &dto.ReaderResponse{
-		ID:   reader.ID,
-		Name: reader.Name,
+		ID:      reader.ID,
+		Name:    reader.Name,
+		Version: reader.Version,
 	}, nil
 }
 
@@ -44,18 +49,16 @@ func (s *ReaderService) GetReaderByID(id uint) (*dto.ReaderResponse, error) {
 	}
 
 	return &dto.ReaderResponse{
-		ID:   reader.ID,
-		Name: reader.Name,
+		ID:      reader.ID,
+		Name:    reader.Name,
+		Version: reader.Version,
 	}, nil
 }
 
 // GetAllReaders retrieves all readers with pagination
-func (s *ReaderService) GetAllReaders(req dto.PaginationRequest) (*dto.ListResponse, error) {
-	// Calculate offset
-	offset := (req.Page - 1) * req.Limit
-
+func (s *ReaderService) GetAllReaders(req dto.ListRequest) (*dto.ListResponse, error) {
 	// Get paginated results
-	readers, total, err := s.readerRepo.GetAll(offset, req.Limit)
+	readers, result, err := s.readerRepo.GetAll(listPage(req))
 	if err != nil {
 		return nil, err
 	}
@@ -64,30 +67,20 @@ func (s *ReaderService) GetAllReaders(req dto.PaginationRequest) (*dto.ListRespo
 	var readerResponses []dto.ReaderResponse
 	for _, reader := range readers {
 		readerResponses = append(readerResponses, dto.ReaderResponse{
-			ID:   reader.ID,
-			Name: reader.Name,
+			ID:      reader.ID,
+			Name:    reader.Name,
+			Version: reader.Version,
 		})
 	}
 
-	// Calculate total pages
-	totalPages := int(total) / req.Limit
-	if int(total)%req.Limit > 0 {
-		totalPages++
-	}
-
 	return &dto.ListResponse{
-		Items: readerResponses,
-		Pagination: dto.PaginationResponse{
-			Page:       req.Page,
-			Limit:      req.Limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
+		Items:      readerResponses,
+		Pagination: listPagination(req, result),
 	}, nil
 }
 
 // UpdateReader updates an existing reader
-func (s *ReaderService) UpdateReader(id uint, req dto.UpdateReaderRequest) (*dto.ReaderResponse, error) {
+func (s *ReaderService) UpdateReader(id, version uint, req dto.UpdateReaderRequest) (*dto.ReaderResponse, error) {
 	reader, err := s.readerRepo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -96,20 +89,31 @@ func (s *ReaderService) UpdateReader(id uint, req dto.UpdateReaderRequest) (*dto
 		return nil, err
 	}
 
+	if err := checkVersion(reader.Version, version); err != nil {
+		return nil, err
+	}
+
 	reader.Name = req.Name
 	if err := s.readerRepo.Update(reader); err != nil {
 		return nil, err
 	}
 
+	// Audiobooks are found by the names of their readers
+	if err := s.searchIndexRepo.RefreshByReaderID(reader.ID); err != nil {
+		return nil, err
+	}
+
 	return &dto.ReaderResponse{
-		ID:   reader.ID,
-		Name: reader.Name,
+		ID:      reader.ID,
+		Name:    reader.Name,
+		Version: reader.Version,
 	}, nil
 }
 
-// DeleteReader deletes a reader
-func (s *ReaderService) DeleteReader(id uint) error {
-	_, err := s.readerRepo.GetByID(id)
+// DeleteReader moves a reader to the trash. Readers that are still the primary reader of an
+// audiobook cannot be deleted; other credits of the reader are hidden until a restore.
+func (s *ReaderService) DeleteReader(id, version uint) error {
+	reader, err := s.readerRepo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("reader not found")
@@ -117,7 +121,24 @@ func (s *ReaderService) DeleteReader(id uint) error {
 		return err
 	}
 
-	return s.readerRepo.Delete(id)
+	if err := checkVersion(reader.Version, version); err != nil {
+		return err
+	}
+
+	isPrimary, err := s.readerRepo.IsPrimaryOfAudiobooks(id)
+	if err != nil {
+		return err
+	}
+	if isPrimary {
+		return errors.New("cannot delete reader with audiobooks")
+	}
+
+	if err := s.readerRepo.Delete(id, version); err != nil {
+		return err
+	}
+
+	// Trashed readers no longer find the audiobooks they are credited on
+	return s.searchIndexRepo.RefreshByReaderID(id)
 }
 
 // SearchReaders searches readers by name
@@ -135,8 +156,9 @@ func (s *ReaderService) SearchReaders(req dto.SearchRequest) (*dto.ListResponse,
 	var readerResponses []dto.ReaderResponse
 	for _, reader := range readers {
 		readerResponses = append(readerResponses, dto.ReaderResponse{
-			ID:   reader.ID,
-			Name: reader.Name,
+			ID:      reader.ID,
+			Name:    reader.Name,
+			Version: reader.Version,
 		})
 	}
 
@@ -172,8 +194,9 @@ func (s *ReaderService) SearchReadersByName(name string, page, limit int) (*dto.
 	var readerResponses []dto.ReaderResponse
 	for _, reader := range readers {
 		readerResponses = append(readerResponses, dto.ReaderResponse{
-			ID:   reader.ID,
-			Name: reader.Name,
+			ID:      reader.ID,
+			Name:    reader.Name,
+			Version: reader.Version,
 		})
 	}
 
@@ -0,0 +1,469 @@
+package service
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/data_layer/entity"
+	"catalog-service/data_layer/repository"
+	"catalog-service/helpers/duration"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ContentManagementSource is the source name content-management-service replicates under
+const ContentManagementSource = "content-management"
+
+// audiobookPublished is the content management status of audiobooks listed in the catalog
+const audiobookPublished = "published"
+
+// ReplicationService applies the events of content-management-service to the catalog. Every event
+// carries the full state of its aggregate and is applied in its own transaction together with the
+// cursor, so applying an event twice, or replaying older events, converges on the same catalog.
+type ReplicationService struct {
+	replicationRepo repository.ReplicationRepositoryInterface
+	unitOfWork      repository.UnitOfWorkInterface
+}
+
+func NewReplicationService(replicationRepo repository.ReplicationRepositoryInterface, unitOfWork repository.UnitOfWorkInterface) *ReplicationService {
+	return &ReplicationService{
+		replicationRepo: replicationRepo,
+		unitOfWork:      unitOfWork,
+	}
+}
+
+// ApplyBatch applies the events of a batch after the cursor in offset order, stopping at the first
+// one that fails, and returns the offset of the last event applied. A batch that starts past the
+// cursor, which happens after a replay, is rejected with "offset mismatch" so the sender can resend
+// from the returned offset; events at or before the cursor are skipped.
+func (s *ReplicationService) ApplyBatch(req dto.ReplicationBatchRequest) (uint, error) {
+	if req.Source != ContentManagementSource {
+		return 0, errors.New("unknown source")
+	}
+
+	cursor, err := s.replicationRepo.GetCursor(req.Source)
+	if err != nil {
+		return 0, err
+	}
+	if req.After > cursor.Offset {
+		return cursor.Offset, errors.New("offset mismatch")
+	}
+
+	for _, event := range req.Events {
+		if err := s.applyEvent(req.Source, event); err != nil {
+			log.Printf("Replication: event %d (%s %d): %v", event.Offset, event.EventType, event.AggregateID, err)
+			s.recordError(req.Source, fmt.Sprintf("event %d (%s): %v", event.Offset, event.EventType, err))
+			if current, getErr := s.replicationRepo.GetCursor(req.Source); getErr == nil {
+				return current.Offset, err
+			}
+			return cursor.Offset, err
+		}
+	}
+
+	var offset uint
+	err = s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		cursor, err := repos.Replication.LockCursor(req.Source)
+		if err != nil {
+			return err
+		}
+
+		cursor.HeadOffset = req.HeadOffset
+		if cursor.HeadOffset < cursor.Offset {
+			cursor.HeadOffset = cursor.Offset
+		}
+		if cursor.Offset == cursor.HeadOffset {
+			now := time.Now()
+			cursor.CaughtUpAt = &now
+		}
+		offset = cursor.Offset
+		return repos.Replication.SaveCursor(cursor)
+	})
+	return offset, err
+}
+
+// Replay moves the cursor back so that events are applied again starting at fromOffset
+func (s *ReplicationService) Replay(fromOffset uint) (*dto.ReplicationStatusResponse, error) {
+	var cursor *entity.ReplicationCursor
+	err := s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		var err error
+		cursor, err = repos.Replication.LockCursor(ContentManagementSource)
+		if err != nil {
+			return err
+		}
+
+		cursor.Offset = fromOffset - 1
+		if cursor.HeadOffset < cursor.Offset {
+			cursor.HeadOffset = cursor.Offset
+		}
+		cursor.LastError = ""
+		return repos.Replication.SaveCursor(cursor)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToReplicationStatusResponse(cursor), nil
+}
+
+// GetStatus reports how far the catalog is behind content management, in events and in seconds since
+// it was last caught up
+func (s *ReplicationService) GetStatus() (*dto.ReplicationStatusResponse, error) {
+	cursor, err := s.replicationRepo.GetCursor(ContentManagementSource)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToReplicationStatusResponse(cursor), nil
+}
+
+// applyEvent applies an event after the cursor and moves the cursor to it in one transaction
+func (s *ReplicationService) applyEvent(source string, event dto.ReplicationEventRequest) error {
+	return s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		cursor, err := repos.Replication.LockCursor(source)
+		if err != nil {
+			return err
+		}
+		if event.Offset <= cursor.Offset {
+			return nil
+		}
+
+		if err := applyReplicationEvent(repos, event); err != nil {
+			return err
+		}
+
+		createdAt := event.CreatedAt
+		cursor.Offset = event.Offset
+		cursor.LastEventAt = &createdAt
+		cursor.LastError = ""
+		return repos.Replication.SaveCursor(cursor)
+	})
+}
+
+// recordError keeps the error that stopped replication on the cursor until an event is applied
+func (s *ReplicationService) recordError(source, message string) {
+	err := s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		cursor, err := repos.Replication.LockCursor(source)
+		if err != nil {
+			return err
+		}
+		cursor.LastError = message
+		return repos.Replication.SaveCursor(cursor)
+	})
+	if err != nil {
+		log.Printf("Replication: failed to record error: %v", err)
+	}
+}
+
+// applyReplicationEvent brings the catalog in line with the state an event carries
+func applyReplicationEvent(repos *repository.Repositories, event dto.ReplicationEventRequest) error {
+	id := event.AggregateID
+
+	switch event.EventType {
+	case "author.upserted":
+		var payload dto.NamedReplicationPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		authorID, err := repos.Replication.UpsertAuthor(replicatedExternalID(id), payload.Name)
+		if err != nil {
+			return err
+		}
+		return repos.SearchIndex.RefreshByAuthorID(authorID)
+	case "reader.upserted":
+		var payload dto.NamedReplicationPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		readerID, err := repos.Replication.UpsertReader(replicatedExternalID(id), payload.Name)
+		if err != nil {
+			return err
+		}
+		return repos.SearchIndex.RefreshByReaderID(readerID)
+	case "genre.upserted":
+		var payload dto.NamedReplicationPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		genreID, err := repos.Replication.UpsertGenre(replicatedExternalID(id), payload.Name)
+		if err != nil {
+			return err
+		}
+		return repos.SearchIndex.RefreshByGenreID(genreID)
+	case "author.deleted":
+		authorID, err := getReplicatedID(repos, &entity.Author{}, id)
+		if err != nil || authorID == 0 {
+			return err
+		}
+		if err := repos.Authors.Delete(authorID, 0); err != nil {
+			return err
+		}
+		return repos.SearchIndex.RefreshByAuthorID(authorID)
+	case "reader.deleted":
+		readerID, err := getReplicatedID(repos, &entity.Reader{}, id)
+		if err != nil || readerID == 0 {
+			return err
+		}
+		if err := repos.Readers.Delete(readerID, 0); err != nil {
+			return err
+		}
+		return repos.SearchIndex.RefreshByReaderID(readerID)
+	case "genre.deleted":
+		genreID, err := getReplicatedID(repos, &entity.Genre{}, id)
+		if err != nil || genreID == 0 {
+			return err
+		}
+		if err := repos.Genres.Delete(genreID, 0); err != nil {
+			return err
+		}
+		return repos.SearchIndex.RefreshByGenreID(genreID)
+	case "audiobook.upserted":
+		var payload dto.AudiobookReplicationPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		// Only published audiobooks are listed; drafts and unpublished ones leave the catalog
+		if payload.Status != audiobookPublished {
+			return removeReplicatedAudiobook(repos, id)
+		}
+		return applyReplicatedAudiobook(repos, id, &payload)
+	case "audiobook.deleted":
+		return removeReplicatedAudiobook(repos, id)
+	case "track.upserted":
+		var payload dto.TrackReplicationPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		return applyReplicatedTrack(repos, id, &payload)
+	case "track.deleted":
+		return removeReplicatedTrack(repos, id)
+	default:
+		return fmt.Errorf("unknown event type %q", event.EventType)
+	}
+}
+
+// applyReplicatedAudiobook upserts a published audiobook with its credits, genres and tracks. Tracks
+// are numbered in the order of the payload; catalog tracks it no longer has are moved to the trash.
+func applyReplicatedAudiobook(repos *repository.Repositories, id uint, payload *dto.AudiobookReplicationPayload) error {
+	authorID, err := getReplicatedID(repos, &entity.Author{}, payload.AuthorID)
+	if err != nil {
+		return err
+	}
+	if authorID == 0 {
+		return fmt.Errorf("author %d has not been replicated", payload.AuthorID)
+	}
+	readerID, err := getReplicatedID(repos, &entity.Reader{}, payload.ReaderID)
+	if err != nil {
+		return err
+	}
+	if readerID == 0 {
+		return fmt.Errorf("reader %d has not been replicated", payload.ReaderID)
+	}
+
+	audiobook := entity.Audiobook{
+		ExternalID:       replicatedExternalID(id),
+		Title:            payload.Title,
+		AuthorID:         authorID,
+		ReaderID:         readerID,
+		Description:      payload.Description,
+		ImageURL:         payload.ImageURL,
+		Language:         payload.Language,
+		YearOfPublishing: payload.YearOfPublishing,
+	}
+	if err := repos.Replication.UpsertAudiobook(&audiobook); err != nil {
+		return err
+	}
+
+	if err := repos.Audiobooks.SyncPrimaryCredits(audiobook.ID, authorID, readerID); err != nil {
+		return err
+	}
+
+	genreExternalIDs := make([]string, len(payload.GenreIDs))
+	for i, genreID := range payload.GenreIDs {
+		genreExternalIDs[i] = replicatedExternalID(genreID)
+	}
+	genreIDs, err := repos.Replication.GetIDsByExternalIDs(&entity.Genre{}, genreExternalIDs)
+	if err != nil {
+		return err
+	}
+	if err := repos.Replication.ReplaceGenres(audiobook.ID, genreIDs); err != nil {
+		return err
+	}
+
+	trackIDs := make([]uint, len(payload.Tracks))
+	for i, trackPayload := range payload.Tracks {
+		track := newReplicatedTrack(trackPayload.ID, audiobook.ID, &trackPayload)
+		track.Position = i + 1
+		if err := repos.Replication.UpsertTrack(&track); err != nil {
+			return err
+		}
+		trackIDs[i] = track.ID
+	}
+	if err := repos.Replication.TrashTracksExcept(audiobook.ID, trackIDs); err != nil {
+		return err
+	}
+
+	if len(payload.Tracks) > 0 {
+		if err := syncTotalDuration(repos.Tracks, repos.Audiobooks, audiobook.ID); err != nil {
+			return err
+		}
+	} else {
+		// Without tracks, the audiobook's own total is all there is
+		seconds, _ := duration.Parse(payload.TotalDuration)
+		if err := repos.Audiobooks.UpdateTotalDuration(audiobook.ID, seconds, formatDuration(seconds)); err != nil {
+			return err
+		}
+	}
+
+	return repos.SearchIndex.RefreshAudiobooks([]uint{audiobook.ID})
+}
+
+// removeReplicatedAudiobook moves an audiobook and its tracks to the trash if it is listed
+func removeReplicatedAudiobook(repos *repository.Repositories, id uint) error {
+	audiobookID, err := getReplicatedID(repos, &entity.Audiobook{}, id)
+	if err != nil || audiobookID == 0 {
+		return err
+	}
+
+	if _, err := repos.Audiobooks.GetByID(audiobookID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if err := repos.Audiobooks.Delete(audiobookID, 0); err != nil {
+		return err
+	}
+	return repos.SearchIndex.RefreshAudiobooks([]uint{audiobookID})
+}
+
+// applyReplicatedTrack upserts a track of a listed audiobook, keeping its place in the play order or
+// appending it. Tracks of audiobooks that are not listed arrive with the audiobook when it is published.
+func applyReplicatedTrack(repos *repository.Repositories, id uint, payload *dto.TrackReplicationPayload) error {
+	audiobookID, err := getReplicatedID(repos, &entity.Audiobook{}, payload.AudiobookID)
+	if err != nil {
+		return err
+	}
+	if audiobookID == 0 {
+		return removeReplicatedTrack(repos, id)
+	}
+	if _, err := repos.Audiobooks.GetByID(audiobookID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return removeReplicatedTrack(repos, id)
+		}
+		return err
+	}
+
+	track := newReplicatedTrack(id, audiobookID, payload)
+
+	current, err := getReplicatedTrack(repos, id)
+	if err != nil {
+		return err
+	}
+	if current != nil && current.AudiobookID == audiobookID {
+		track.Position = current.Position
+	} else {
+		// Moved from another audiobook, or new: leave the old play order gapless and append
+		if current != nil {
+			if err := removeReplicatedTrack(repos, id); err != nil {
+				return err
+			}
+		}
+		_, count, err := repos.Tracks.SumDurationByAudiobookID(audiobookID)
+		if err != nil {
+			return err
+		}
+		track.Position = int(count) + 1
+	}
+
+	if err := repos.Replication.UpsertTrack(&track); err != nil {
+		return err
+	}
+	return syncTotalDuration(repos.Tracks, repos.Audiobooks, audiobookID)
+}
+
+// removeReplicatedTrack moves a track to the trash if it is listed
+func removeReplicatedTrack(repos *repository.Repositories, id uint) error {
+	track, err := getReplicatedTrack(repos, id)
+	if err != nil || track == nil {
+		return err
+	}
+
+	if err := repos.Tracks.Delete(track.ID, 0); err != nil {
+		return err
+	}
+	return syncTotalDuration(repos.Tracks, repos.Audiobooks, track.AudiobookID)
+}
+
+// getReplicatedTrack retrieves the listed track replicated from a content management track, or nil
+func getReplicatedTrack(repos *repository.Repositories, id uint) (*entity.Track, error) {
+	trackID, err := getReplicatedID(repos, &entity.Track{}, id)
+	if err != nil || trackID == 0 {
+		return nil, err
+	}
+
+	track, err := repos.Tracks.GetByID(trackID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return track, err
+}
+
+// getReplicatedID returns the catalog ID of the row replicated from a content management ID, trashed
+// or not, or 0 when it was never replicated
+func getReplicatedID(repos *repository.Repositories, model interface{}, id uint) (uint, error) {
+	catalogID, err := repos.Replication.GetIDByExternalID(model, replicatedExternalID(id))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	return catalogID, err
+}
+
+// replicatedExternalID returns the external ID of the catalog row of a content management ID
+func replicatedExternalID(id uint) string {
+	return repository.ReplicatedExternalID(ContentManagementSource, id)
+}
+
+// newReplicatedTrack builds a track from its payload; durations the catalog cannot read count as unknown
+func newReplicatedTrack(id, audiobookID uint, payload *dto.TrackReplicationPayload) entity.Track {
+	seconds, _ := duration.Parse(payload.Duration)
+
+	return entity.Track{
+		ExternalID:      replicatedExternalID(id),
+		AudiobookID:     audiobookID,
+		Title:           payload.Title,
+		URL:             payload.URL,
+		Duration:        formatDuration(seconds),
+		DurationSeconds: seconds,
+	}
+}
+
+func convertToReplicationStatusResponse(cursor *entity.ReplicationCursor) *dto.ReplicationStatusResponse {
+	response := &dto.ReplicationStatusResponse{
+		Source:      cursor.Source,
+		Offset:      cursor.Offset,
+		HeadOffset:  cursor.HeadOffset,
+		LastEventAt: cursor.LastEventAt,
+		CaughtUpAt:  cursor.CaughtUpAt,
+		LastError:   cursor.LastError,
+		UpdatedAt:   cursor.UpdatedAt,
+	}
+
+	if cursor.HeadOffset > cursor.Offset {
+		response.LagEvents = cursor.HeadOffset - cursor.Offset
+		// A catalog that never caught up is behind since the last event it applied, or since it started
+		switch {
+		case cursor.CaughtUpAt != nil:
+			response.LagSeconds = int64(time.Since(*cursor.CaughtUpAt).Seconds())
+		case cursor.LastEventAt != nil:
+			response.LagSeconds = int64(time.Since(*cursor.LastEventAt).Seconds())
+		case !cursor.CreatedAt.IsZero():
+			response.LagSeconds = int64(time.Since(cursor.CreatedAt).Seconds())
+		}
+	}
+
+	return response
+}
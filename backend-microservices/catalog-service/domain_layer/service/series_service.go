@@ -0,0 +1,171 @@
+package service
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/data_layer/entity"
+	"catalog-service/data_layer/repository"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type SeriesService struct {
+	seriesRepo    repository.SeriesRepositoryInterface
+	audiobookRepo repository.AudiobookRepositoryInterface
+}
+
+func NewSeriesService(seriesRepo repository.SeriesRepositoryInterface, audiobookRepo repository.AudiobookRepositoryInterface) *SeriesService {
+	return &SeriesService{
+		seriesRepo:    seriesRepo,
+		audiobookRepo: audiobookRepo,
+	}
+}
+
+// CreateSeries creates a new series without volumes
+func (s *SeriesService) CreateSeries(req dto.CreateSeriesRequest) (*dto.SeriesResponse, error) {
+	series := entity.Series{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := s.seriesRepo.Create(&series); err != nil {
+		return nil, err
+	}
+
+	return convertToSeriesResponse(&series), nil
+}
+
+// GetSeriesByID retrieves a series with its volumes in order
+func (s *SeriesService) GetSeriesByID(id uint) (*dto.SeriesResponse, error) {
+	series, err := s.seriesRepo.GetByIDWithVolumes(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("series not found")
+		}
+		return nil, err
+	}
+
+	return convertToSeriesResponse(series), nil
+}
+
+// GetAllSeries retrieves all series with pagination
+func (s *SeriesService) GetAllSeries(req dto.ListRequest) (*dto.ListResponse, error) {
+	// Get paginated results
+	series, result, err := s.seriesRepo.GetAll(listPage(req))
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response format
+	var seriesResponses []dto.SeriesResponse
+	for i := range series {
+		seriesResponses = append(seriesResponses, *convertToSeriesResponse(&series[i]))
+	}
+
+	return &dto.ListResponse{
+		Items:      seriesResponses,
+		Pagination: listPagination(req, result),
+	}, nil
+}
+
+// UpdateSeries updates the name and description of a series
+func (s *SeriesService) UpdateSeries(id uint, req dto.UpdateSeriesRequest) (*dto.SeriesResponse, error) {
+	series, err := s.seriesRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("series not found")
+		}
+		return nil, err
+	}
+
+	series.Name = req.Name
+	series.Description = req.Description
+	if err := s.seriesRepo.Update(series); err != nil {
+		return nil, err
+	}
+
+	return s.GetSeriesByID(id)
+}
+
+// DeleteSeries deletes a series; its audiobooks stay in the catalog
+func (s *SeriesService) DeleteSeries(id uint) error {
+	_, err := s.seriesRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("series not found")
+		}
+		return err
+	}
+
+	return s.seriesRepo.Delete(id)
+}
+
+// SetVolumes replaces the volumes of a series, numbered 1..n in request order.
+// Audiobooks that were volumes of another series move to this one.
+func (s *SeriesService) SetVolumes(id uint, req dto.SetSeriesVolumesRequest) (*dto.SeriesResponse, error) {
+	_, err := s.seriesRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("series not found")
+		}
+		return nil, err
+	}
+
+	seen := make(map[uint]bool, len(req.Volumes))
+	volumes := make([]entity.SeriesVolume, 0, len(req.Volumes))
+	for i, volume := range req.Volumes {
+		if seen[volume.AudiobookID] {
+			return nil, errors.New("an audiobook can only be one volume of a series")
+		}
+		seen[volume.AudiobookID] = true
+
+		if _, err := s.audiobookRepo.GetByID(volume.AudiobookID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("audiobook not found")
+			}
+			return nil, err
+		}
+
+		volumes = append(volumes, entity.SeriesVolume{
+			AudiobookID: volume.AudiobookID,
+			Position:    i + 1,
+			Label:       volume.Label,
+		})
+	}
+
+	if err := s.seriesRepo.ReplaceVolumes(id, volumes); err != nil {
+		return nil, err
+	}
+
+	return s.GetSeriesByID(id)
+}
+
+func convertToSeriesResponse(series *entity.Series) *dto.SeriesResponse {
+	response := &dto.SeriesResponse{
+		ID:          series.ID,
+		Name:        series.Name,
+		Description: series.Description,
+		CreatedAt:   series.CreatedAt,
+		UpdatedAt:   series.UpdatedAt,
+	}
+
+	for _, volume := range series.Volumes {
+		if volume.Audiobook == nil {
+			continue
+		}
+
+		volumeResponse := dto.SeriesVolumeResponse{
+			Volume:      volume.Position,
+			Label:       volume.Label,
+			AudiobookID: volume.AudiobookID,
+			Title:       volume.Audiobook.Title,
+			ImageURL:    volume.Audiobook.ImageURL,
+		}
+		if author := volume.Audiobook.Author; author != nil {
+			volumeResponse.Author = &dto.AuthorResponse{ID: author.ID, Name: author.Name}
+		}
+		response.Volumes = append(response.Volumes, volumeResponse)
+	}
+
+	return response
+}
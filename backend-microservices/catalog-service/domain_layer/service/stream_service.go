@@ -0,0 +1,207 @@
+package service
+
+import (
+	"catalog-service/data_layer/repository"
+	"catalog-service/helpers/cache"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrUpstreamUnavailable is returned when a file could not be fetched from its upstream location
+var ErrUpstreamUnavailable = errors.New("upstream unavailable")
+
+// AudioFetcher fetches the original audio file of a track from its upstream location
+type AudioFetcher interface {
+	Fetch(ctx context.Context, url string) (*UpstreamFile, error)
+}
+
+// UpstreamFile is a file being downloaded from its upstream location. Validator is the ETag the
+// upstream sent for it, or its Last-Modified time when it sent no ETag, and empty when it sent neither.
+type UpstreamFile struct {
+	Body      io.ReadCloser
+	Validator string
+}
+
+// HTTPAudioFetcher fetches audio over HTTP, e.g. from archive.org or a local file server
+type HTTPAudioFetcher struct {
+	httpClient *http.Client
+}
+
+// NewHTTPAudioFetcher creates a new HTTP audio fetcher
+func NewHTTPAudioFetcher() *HTTPAudioFetcher {
+	return &HTTPAudioFetcher{
+		httpClient: &http.Client{},
+	}
+}
+
+// Fetch downloads the file at url
+func (f *HTTPAudioFetcher) Fetch(ctx context.Context, url string) (*UpstreamFile, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("upstream responded with status: %d", resp.StatusCode)
+	}
+
+	validator := resp.Header.Get("ETag")
+	if validator == "" {
+		validator = resp.Header.Get("Last-Modified")
+	}
+
+	return &UpstreamFile{Body: resp.Body, Validator: validator}, nil
+}
+
+// TrackStream is a seekable local copy of a track ready to be served
+type TrackStream struct {
+	File        *os.File
+	Name        string
+	ContentType string
+	ETag        string
+	ModTime     time.Time
+}
+
+type StreamService struct {
+	trackRepo    repository.TrackRepositoryInterface
+	fetcher      AudioFetcher
+	cache        *cache.DiskLRUCache
+	fetchTimeout time.Duration
+}
+
+func NewStreamService(trackRepo repository.TrackRepositoryInterface, fetcher AudioFetcher, diskCache *cache.DiskLRUCache) *StreamService {
+	return &StreamService{
+		trackRepo:    trackRepo,
+		fetcher:      fetcher,
+		cache:        diskCache,
+		fetchTimeout: 10 * time.Minute,
+	}
+}
+
+// OpenTrack returns the audio of a track, downloading it into the disk cache on a miss.
+// The caller must close the returned file.
+func (s *StreamService) OpenTrack(ctx context.Context, trackID uint) (*TrackStream, error) {
+	track, err := s.trackRepo.GetByID(trackID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("track not found")
+		}
+		return nil, err
+	}
+
+	key := cacheKey(track.URL)
+
+	file, err := s.cache.Fill(key, func(w io.Writer) error {
+		// The download fills a shared cache entry, so it must outlive the request that started it
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.fetchTimeout)
+		defer cancel()
+
+		upstream, err := s.fetcher.Fetch(fetchCtx, track.URL)
+		if err != nil {
+			return err
+		}
+		defer upstream.Body.Close()
+
+		if _, err := io.Copy(w, upstream.Body); err != nil {
+			return err
+		}
+
+		// Saved before the audio itself is cached, so every reader of the audio finds it
+		return s.putValidator(key, upstream.Validator)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUpstreamUnavailable, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	name := path.Base(track.URL)
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		contentType = "audio/mpeg"
+	}
+
+	return &TrackStream{
+		File:        file,
+		Name:        name,
+		ContentType: contentType,
+		ETag:        s.trackETag(key, info.Size()),
+		ModTime:     track.UpdatedAt,
+	}, nil
+}
+
+// trackETag derives the entity tag of a cached track from the validator the upstream sent for it, so
+// a file replaced upstream gets a new tag. The URL and size stand in when there is no validator.
+func (s *StreamService) trackETag(key string, size int64) string {
+	if validator := s.readValidator(key); validator != "" {
+		return fmt.Sprintf("\"%s\"", cacheKey(key + "\n" + validator)[:32])
+	}
+	return fmt.Sprintf("\"%s-%d\"", key[:16], size)
+}
+
+// putValidator caches the upstream validator of a track next to its audio
+func (s *StreamService) putValidator(key, validator string) error {
+	if validator == "" {
+		return nil
+	}
+
+	tmpDir, err := s.cache.TempDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	validatorPath := filepath.Join(tmpDir, "validator")
+	if err := os.WriteFile(validatorPath, []byte(validator), 0o644); err != nil {
+		return err
+	}
+	return s.cache.Put(validatorKey(key), validatorPath)
+}
+
+// readValidator returns the cached upstream validator of a track, or "" when there is none
+func (s *StreamService) readValidator(key string) string {
+	file, ok := s.cache.Open(validatorKey(key))
+	if !ok {
+		return ""
+	}
+	defer file.Close()
+
+	validator, err := io.ReadAll(file)
+	if err != nil {
+		return ""
+	}
+	return string(validator)
+}
+
+// validatorKey is the cache key of the upstream validator of the track cached under key
+func validatorKey(key string) string {
+	return key + "-validator"
+}
+
+// cacheKey derives a file-system safe cache key from an upstream URL
+func cacheKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
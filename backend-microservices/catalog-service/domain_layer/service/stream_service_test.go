@@ -0,0 +1,91 @@
+package service
+
+import (
+	"catalog-service/data_layer/entity"
+	"catalog-service/helpers/cache"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTestStreamService(t *testing.T, fetcher AudioFetcher) *StreamService {
+	t.Helper()
+
+	trackRepo := &stubTrackRepository{tracks: map[uint]*entity.Track{
+		1: {ID: 1, AudiobookID: 1, Title: "Chapter 1", URL: "https://example.com/chapter1.mp3"},
+	}}
+
+	streamCache, err := cache.NewDiskLRUCache(t.TempDir(), 1<<30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewStreamService(trackRepo, fetcher, streamCache)
+}
+
+// openTrackETag opens track 1 and returns its entity tag
+func openTrackETag(t *testing.T, s *StreamService) string {
+	t.Helper()
+
+	stream, err := s.OpenTrack(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("OpenTrack: %v", err)
+	}
+	defer stream.File.Close()
+	return stream.ETag
+}
+
+func TestStreamServiceETagFollowsUpstreamValidator(t *testing.T) {
+	body := mp3Frames(10)
+
+	tests := []struct {
+		name       string
+		validator  string
+		other      string
+		sameETag   bool
+		sizeSuffix bool
+	}{
+		{name: "same upstream ETag", validator: `"v1"`, other: `"v1"`, sameETag: true},
+		{name: "replaced upstream", validator: `"v1"`, other: `"v2"`, sameETag: false},
+		{name: "Last-Modified only", validator: "Mon, 02 Jan 2006 15:04:05 GMT", other: "Tue, 03 Jan 2006 15:04:05 GMT", sameETag: false},
+		{name: "no validator", validator: "", other: "", sameETag: true, sizeSuffix: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := openTrackETag(t, newTestStreamService(t, &stubAudioFetcher{body: body, validator: tt.validator}))
+			second := openTrackETag(t, newTestStreamService(t, &stubAudioFetcher{body: body, validator: tt.other}))
+
+			if (first == second) != tt.sameETag {
+				t.Errorf("ETags %s and %s: same = %v, want %v", first, second, first == second, tt.sameETag)
+			}
+			if got := strings.HasSuffix(first, "-4170\""); got != tt.sizeSuffix {
+				t.Errorf("ETag %s ends in the size = %v, want %v", first, got, tt.sizeSuffix)
+			}
+		})
+	}
+}
+
+func TestStreamServiceKeepsETagOnCacheHit(t *testing.T) {
+	fetcher := &stubAudioFetcher{body: mp3Frames(10), validator: `"v1"`}
+	s := newTestStreamService(t, fetcher)
+
+	first := openTrackETag(t, s)
+	second := openTrackETag(t, s)
+
+	if first != second {
+		t.Errorf("ETag changed on cache hit: %s, then %s", first, second)
+	}
+	if got := fetcher.fetches.Load(); got != 1 {
+		t.Errorf("fetched %d times, want 1", got)
+	}
+}
+
+func TestStreamServiceWrapsUpstreamErrors(t *testing.T) {
+	s := newTestStreamService(t, &stubAudioFetcher{err: errors.New("connection refused")})
+
+	_, err := s.OpenTrack(context.Background(), 1)
+	if !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Fatalf("OpenTrack error = %v, want ErrUpstreamUnavailable", err)
+	}
+}
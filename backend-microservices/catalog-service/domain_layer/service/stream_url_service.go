@@ -0,0 +1,54 @@
+package service
+
+import (
+	"catalog-service/helpers/signing"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// StreamURLService issues and verifies short-lived signed URLs for track audio
+type StreamURLService struct {
+	signer  *signing.URLSigner
+	baseURL string
+}
+
+func NewStreamURLService(signer *signing.URLSigner, baseURL string) *StreamURLService {
+	return &StreamURLService{
+		signer:  signer,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// SignedStreamURL returns the signed progressive stream URL of a track, or "" for anonymous callers
+func (s *StreamURLService) SignedStreamURL(userID string, trackID uint) string {
+	if userID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/api/v1/tracks/%d/stream?%s", s.baseURL, trackID, s.SignedQuery(userID, trackID))
+}
+
+// SignedHLSURL returns the signed HLS playlist URL of a track, or "" for anonymous callers
+func (s *StreamURLService) SignedHLSURL(userID string, trackID uint) string {
+	if userID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/api/v1/tracks/%d/hls/index.m3u8?%s", s.baseURL, trackID, s.SignedQuery(userID, trackID))
+}
+
+// SignedQuery returns the encoded signature query string of a track for a user
+func (s *StreamURLService) SignedQuery(userID string, trackID uint) string {
+	return s.signer.Sign(userID, trackID).Encode()
+}
+
+// SignedPlaylistQuery returns the encoded signature query string of a track for the segments of a
+// playlist that plays for playlistDuration, valid until the whole playlist could have been played
+func (s *StreamURLService) SignedPlaylistQuery(userID string, trackID uint, playlistDuration time.Duration) string {
+	return s.signer.SignForPlayback(userID, trackID, playlistDuration).Encode()
+}
+
+// Verify checks a signed query for a track and returns the user it was issued to
+func (s *StreamURLService) Verify(trackID uint, query url.Values) (string, error) {
+	return s.signer.Verify(trackID, query)
+}
@@ -4,42 +4,71 @@ import (
 	"catalog-service/data_layer/dto"
 	"catalog-service/data_layer/entity"
 	"catalog-service/data_layer/repository"
+	"catalog-service/helpers/duration"
 	"errors"
+	"sort"
 
 	"gorm.io/gorm"
 )
 
 type TrackService struct {
-	trackRepo repository.TrackRepositoryInterface
+	trackRepo        repository.TrackRepositoryInterface
+	unitOfWork       repository.UnitOfWorkInterface
+	streamURLService *StreamURLService
 }
 
-func NewTrackService(trackRepo repository.TrackRepositoryInterface) *TrackService {
-	return &TrackService{trackRepo: trackRepo}
+func NewTrackService(trackRepo repository.TrackRepositoryInterface, unitOfWork repository.UnitOfWorkInterface, streamURLService *StreamURLService) *TrackService {
+	return &TrackService{
+		trackRepo:        trackRepo,
+		unitOfWork:       unitOfWork,
+		streamURLService: streamURLService,
+	}
 }
 
-// CreateTrack creates a new track
-func (s *TrackService) CreateTrack(req dto.CreateTrackRequest) (*dto.TrackResponse, error) {
+// CreateTrack creates a new track and recomputes the total duration of its audiobook
+func (s *TrackService) CreateTrack(userID string, req dto.CreateTrackRequest) (*dto.TrackResponse, error) {
+	seconds, err := duration.Parse(req.Duration)
+	if err != nil {
+		return nil, errors.New("invalid duration")
+	}
+
 	track := entity.Track{
-		AudiobookID: req.AudiobookID,
-		Title:       req.Title,
-		URL:         req.URL,
-		Duration:    req.Duration,
+		AudiobookID:     req.AudiobookID,
+		Title:           req.Title,
+		URL:             req.URL,
+		Duration:        formatDuration(seconds),
+		DurationSeconds: seconds,
 	}
 
-	if err := s.trackRepo.Create(&track); err != nil {
+	position := 0
+	if req.Position != nil {
+		position = *req.Position
+	}
+
+	err = s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		if _, err := repos.Audiobooks.GetByID(req.AudiobookID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("audiobook not found")
+			}
+			return err
+		}
+
+		if err := repos.Tracks.CreateAt(&track, position); err != nil {
+			return err
+		}
+
+		return syncTotalDuration(repos.Tracks, repos.Audiobooks, track.AudiobookID)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return &dto.TrackResponse{
-		ID:       track.ID,
-		Title:    track.Title,
-		URL:      track.URL,
-		Duration: track.Duration,
-	}, nil
+	response := convertToTrackResponse(&track, s.streamURLService, userID)
+	return &response, nil
 }
 
 // GetTrackByID retrieves a track by ID
-func (s *TrackService) GetTrackByID(id uint) (*dto.TrackResponse, error) {
+func (s *TrackService) GetTrackByID(userID string, id uint) (*dto.TrackResponse, error) {
 	track, err := s.trackRepo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -48,21 +77,14 @@ func (s *TrackService) GetTrackByID(id uint) (*dto.TrackResponse, error) {
 		return nil, err
 	}
 
-	return &dto.TrackResponse{
-		ID:       track.ID,
-		Title:    track.Title,
-		URL:      track.URL,
-		Duration: track.Duration,
-	}, nil
+	response := convertToTrackResponse(track, s.streamURLService, userID)
+	return &response, nil
 }
 
-// GetAllTracks retrieves all tracks with pagination
-func (s *TrackService) GetAllTracks(req dto.PaginationRequest) (*dto.ListResponse, error) {
-	// Calculate offset
-	offset := (req.Page - 1) * req.Limit
-
+// GetAllTracks retrieves all tracks within a duration range with pagination
+func (s *TrackService) GetAllTracks(userID string, filter dto.DurationFilter, req dto.ListRequest) (*dto.ListResponse, error) {
 	// Get paginated results
-	tracks, total, err := s.trackRepo.GetAll(offset, req.Limit)
+	tracks, result, err := s.trackRepo.GetAll(repository.DurationFilter(filter), listPage(req))
 	if err != nil {
 		return nil, err
 	}
@@ -70,72 +92,107 @@ func (s *TrackService) GetAllTracks(req dto.PaginationRequest) (*dto.ListRespons
 	// Convert to response format
 	var trackResponses []dto.TrackResponse
 	for _, track := range tracks {
-		trackResponses = append(trackResponses, dto.TrackResponse{
-			ID:       track.ID,
-			Title:    track.Title,
-			URL:      track.URL,
-			Duration: track.Duration,
-		})
-	}
-
-	// Calculate total pages
-	totalPages := int(total) / req.Limit
-	if int(total)%req.Limit > 0 {
-		totalPages++
+		trackResponses = append(trackResponses, convertToTrackResponse(&track, s.streamURLService, userID))
 	}
 
 	return &dto.ListResponse{
-		Items: trackResponses,
-		Pagination: dto.PaginationResponse{
-			Page:       req.Page,
-			Limit:      req.Limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
+		Items:      trackResponses,
+		Pagination: listPagination(req, result),
 	}, nil
 }
 
-// UpdateTrack updates an existing track
-func (s *TrackService) UpdateTrack(id uint, req dto.UpdateTrackRequest) (*dto.TrackResponse, error) {
-	track, err := s.trackRepo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("track not found")
+// UpdateTrack updates every field of a track at version and recomputes the total duration of its audiobook
+func (s *TrackService) UpdateTrack(userID string, id, version uint, req dto.UpdateTrackRequest) (*dto.TrackResponse, error) {
+	return s.PatchTrack(userID, id, version, dto.PatchTrackRequest{
+		Title:    &req.Title,
+		URL:      &req.URL,
+		Duration: &req.Duration,
+		Position: req.Position,
+	})
+}
+
+// PatchTrack updates the given fields of a track at version and recomputes the total duration of its audiobook
+func (s *TrackService) PatchTrack(userID string, id, version uint, req dto.PatchTrackRequest) (*dto.TrackResponse, error) {
+	var seconds int
+	if req.Duration != nil {
+		var err error
+		seconds, err = duration.Parse(*req.Duration)
+		if err != nil {
+			return nil, errors.New("invalid duration")
 		}
-		return nil, err
 	}
 
-	track.Title = req.Title
-	track.URL = req.URL
-	track.Duration = req.Duration
+	var track *entity.Track
+	err := s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		var err error
+		track, err = repos.Tracks.GetByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("track not found")
+			}
+			return err
+		}
+
+		if err := checkVersion(track.Version, version); err != nil {
+			return err
+		}
+
+		if req.Title != nil {
+			track.Title = *req.Title
+		}
+		if req.URL != nil {
+			track.URL = *req.URL
+		}
+		if req.Duration != nil {
+			track.Duration = formatDuration(seconds)
+			track.DurationSeconds = seconds
+		}
+
+		if err := repos.Tracks.Update(track); err != nil {
+			return err
+		}
 
-	if err := s.trackRepo.Update(track); err != nil {
+		if req.Position != nil && *req.Position != track.Position {
+			if err := moveTrack(repos.Tracks, track, *req.Position); err != nil {
+				return err
+			}
+		}
+
+		return syncTotalDuration(repos.Tracks, repos.Audiobooks, track.AudiobookID)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return &dto.TrackResponse{
-		ID:       track.ID,
-		Title:    track.Title,
-		URL:      track.URL,
-		Duration: track.Duration,
-	}, nil
+	response := convertToTrackResponse(track, s.streamURLService, userID)
+	return &response, nil
 }
 
-// DeleteTrack deletes a track
-func (s *TrackService) DeleteTrack(id uint) error {
-	_, err := s.trackRepo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("track not found")
+// DeleteTrack deletes a track at version and recomputes the total duration of its audiobook
+func (s *TrackService) DeleteTrack(id, version uint) error {
+	return s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		track, err := repos.Tracks.GetByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("track not found")
+			}
+			return err
 		}
-		return err
-	}
 
-	return s.trackRepo.Delete(id)
+		if err := checkVersion(track.Version, version); err != nil {
+			return err
+		}
+
+		if err := repos.Tracks.Delete(id, version); err != nil {
+			return err
+		}
+
+		return syncTotalDuration(repos.Tracks, repos.Audiobooks, track.AudiobookID)
+	})
 }
 
 // SearchTracks searches tracks by title
-func (s *TrackService) SearchTracks(req dto.SearchRequest) (*dto.ListResponse, error) {
+func (s *TrackService) SearchTracks(userID string, req dto.SearchRequest) (*dto.ListResponse, error) {
 	// Calculate offset
 	offset := (req.Page - 1) * req.Limit
 
@@ -148,12 +205,7 @@ func (s *TrackService) SearchTracks(req dto.SearchRequest) (*dto.ListResponse, e
 	// Convert to response format
 	var trackResponses []dto.TrackResponse
 	for _, track := range tracks {
-		trackResponses = append(trackResponses, dto.TrackResponse{
-			ID:       track.ID,
-			Title:    track.Title,
-			URL:      track.URL,
-			Duration: track.Duration,
-		})
+		trackResponses = append(trackResponses, convertToTrackResponse(&track, s.streamURLService, userID))
 	}
 
 	// Calculate total pages
@@ -174,7 +226,7 @@ func (s *TrackService) SearchTracks(req dto.SearchRequest) (*dto.ListResponse, e
 }
 
 // GetTracksByAudiobookID retrieves all tracks for a specific audiobook
-func (s *TrackService) GetTracksByAudiobookID(audiobookID uint) ([]dto.TrackResponse, error) {
+func (s *TrackService) GetTracksByAudiobookID(userID string, audiobookID uint) ([]dto.TrackResponse, error) {
 	tracks, err := s.trackRepo.GetByAudiobookID(audiobookID)
 	if err != nil {
 		return nil, err
@@ -182,80 +234,166 @@ func (s *TrackService) GetTracksByAudiobookID(audiobookID uint) ([]dto.TrackResp
 
 	var trackResponses []dto.TrackResponse
 	for _, track := range tracks {
-		trackResponses = append(trackResponses, dto.TrackResponse{
-			ID:       track.ID,
-			Title:    track.Title,
-			URL:      track.URL,
-			Duration: track.Duration,
-		})
+		trackResponses = append(trackResponses, convertToTrackResponse(&track, s.streamURLService, userID))
 	}
 
 	return trackResponses, nil
 }
 
-// GetTracksByAudiobook retrieves tracks by audiobook ID with pagination
-func (s *TrackService) GetTracksByAudiobook(audiobookID uint, page, limit int) (*dto.ListResponse, error) {
-	// Get all tracks for the audiobook first
-	allTracks, err := s.trackRepo.GetByAudiobookID(audiobookID)
+// GetTracksByAudiobook retrieves one page of the tracks of an audiobook, in play order by default
+func (s *TrackService) GetTracksByAudiobook(userID string, audiobookID uint, req dto.ListRequest) (*dto.ListResponse, error) {
+	tracks, result, err := s.trackRepo.GetPageByAudiobookID(audiobookID, listPage(req))
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate pagination manually
-	total := int64(len(allTracks))
-	offset := (page - 1) * limit
-
-	// Apply pagination
-	var tracks []entity.Track
-	if offset < len(allTracks) {
-		end := offset + limit
-		if end > len(allTracks) {
-			end = len(allTracks)
-		}
-		tracks = allTracks[offset:end]
-	}
-
 	// Convert to response format
 	var trackResponses []dto.TrackResponse
 	for _, track := range tracks {
-		trackResponses = append(trackResponses, dto.TrackResponse{
-			ID:       track.ID,
-			Title:    track.Title,
-			URL:      track.URL,
-			Duration: track.Duration,
-		})
-	}
-
-	// Calculate total pages
-	totalPages := int(total) / limit
-	if int(total)%limit > 0 {
-		totalPages++
+		trackResponses = append(trackResponses, convertToTrackResponse(&track, s.streamURLService, userID))
 	}
 
 	return &dto.ListResponse{
-		Items: trackResponses,
-		Pagination: dto.PaginationResponse{
-			Page:       page,
-			Limit:      limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
+		Items:      trackResponses,
+		Pagination: listPagination(req, result),
 	}, nil
 }
 
-// UpdateTrackOrder updates the order of tracks in an audiobook
-func (s *TrackService) UpdateTrackOrder(audiobookID uint, trackOrders []struct {
-	TrackID uint `json:"track_id"`
-	Order   int  `json:"order"`
-}) error {
-	// First check if audiobook exists
-	_, err := s.trackRepo.GetByID(audiobookID) // Using a track to verify audiobook exists
+// UpdateTrackOrder puts the tracks of an audiobook in the given order and renumbers them 1..n.
+// Every track of the audiobook must be listed exactly once; order values only need to be distinct.
+func (s *TrackService) UpdateTrackOrder(userID string, audiobookID uint, req dto.UpdateTrackOrderRequest) ([]dto.TrackResponse, error) {
+	var trackResponses []dto.TrackResponse
+	err := s.unitOfWork.Do(func(repos *repository.Repositories) error {
+		if _, err := repos.Audiobooks.GetByID(audiobookID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("audiobook not found")
+			}
+			return err
+		}
+
+		if err := repos.Tracks.LockPlayOrder(audiobookID); err != nil {
+			return err
+		}
+
+		tracks, err := repos.Tracks.GetByAudiobookID(audiobookID)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[uint]entity.Track, len(tracks))
+		for _, track := range tracks {
+			byID[track.ID] = track
+		}
+
+		seenTracks := make(map[uint]bool, len(req.TrackOrders))
+		seenOrders := make(map[int]bool, len(req.TrackOrders))
+		for _, trackOrder := range req.TrackOrders {
+			if _, ok := byID[trackOrder.TrackID]; !ok {
+				return errors.New("track does not belong to audiobook")
+			}
+			if seenTracks[trackOrder.TrackID] {
+				return errors.New("track_orders must list every track of the audiobook exactly once")
+			}
+			if seenOrders[trackOrder.Order] {
+				return errors.New("track orders must be distinct")
+			}
+			seenTracks[trackOrder.TrackID] = true
+			seenOrders[trackOrder.Order] = true
+		}
+		if len(seenTracks) != len(tracks) {
+			return errors.New("track_orders must list every track of the audiobook exactly once")
+		}
+
+		trackOrders := append([]dto.TrackOrder(nil), req.TrackOrders...)
+		sort.Slice(trackOrders, func(i, j int) bool {
+			return trackOrders[i].Order < trackOrders[j].Order
+		})
+
+		trackIDs := make([]uint, 0, len(trackOrders))
+		for _, trackOrder := range trackOrders {
+			trackIDs = append(trackIDs, trackOrder.TrackID)
+		}
+
+		if err := repos.Tracks.UpdatePositions(audiobookID, trackIDs); err != nil {
+			return err
+		}
+
+		trackResponses = make([]dto.TrackResponse, 0, len(trackIDs))
+		for i, trackID := range trackIDs {
+			track := byID[trackID]
+			track.Position = i + 1
+			trackResponses = append(trackResponses, convertToTrackResponse(&track, s.streamURLService, userID))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return trackResponses, nil
+}
+
+// moveTrack moves a track to a 1-based position of its audiobook, clamped to the last place.
+// It must run inside a unit of work so the play order stays locked between reading and saving it.
+func moveTrack(trackRepo repository.TrackRepositoryInterface, track *entity.Track, position int) error {
+	if err := trackRepo.LockPlayOrder(track.AudiobookID); err != nil {
+		return err
+	}
+
+	tracks, err := trackRepo.GetByAudiobookID(track.AudiobookID)
 	if err != nil {
-		return errors.New("audiobook not found")
+		return err
+	}
+
+	trackIDs := make([]uint, 0, len(tracks))
+	for _, other := range tracks {
+		if other.ID != track.ID {
+			trackIDs = append(trackIDs, other.ID)
+		}
+	}
+
+	if position > len(trackIDs)+1 {
+		position = len(trackIDs) + 1
 	}
+	trackIDs = append(trackIDs[:position-1], append([]uint{track.ID}, trackIDs[position-1:]...)...)
 
-	// Update the track orders (this would need custom implementation in repository)
-	// For now, we'll just return success as order management would require
-	// additional fields in the track entity
+	if err := trackRepo.UpdatePositions(track.AudiobookID, trackIDs); err != nil {
+		return err
+	}
+
+	track.Position = position
 	return nil
 }
+
+// convertToTrackResponse builds a track response whose URLs are signed for the given user.
+// The raw media location is never exposed; anonymous callers get empty URLs.
+func convertToTrackResponse(track *entity.Track, streamURLService *StreamURLService, userID string) dto.TrackResponse {
+	return dto.TrackResponse{
+		ID:              track.ID,
+		Position:        track.Position,
+		Title:           track.Title,
+		URL:             streamURLService.SignedStreamURL(userID, track.ID),
+		HLSURL:          streamURLService.SignedHLSURL(userID, track.ID),
+		Duration:        track.Duration,
+		DurationSeconds: track.DurationSeconds,
+		Version:         track.Version,
+	}
+}
+
+// syncTotalDuration derives the total duration of an audiobook from its tracks
+func syncTotalDuration(trackRepo repository.TrackRepositoryInterface, audiobookRepo repository.AudiobookRepositoryInterface, audiobookID uint) error {
+	seconds, _, err := trackRepo.SumDurationByAudiobookID(audiobookID)
+	if err != nil {
+		return err
+	}
+
+	return audiobookRepo.UpdateTotalDuration(audiobookID, seconds, formatDuration(seconds))
+}
+
+// formatDuration renders a duration for display, leaving unknown (zero) durations empty
+func formatDuration(seconds int) string {
+	if seconds == 0 {
+		return ""
+	}
+	return duration.Format(seconds)
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/data_layer/repository"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// trashPurgeOrder purges audiobooks before authors and readers, so that the people of purged
+// audiobooks are no longer their primary author or reader and can be purged in the same run
+var trashPurgeOrder = []string{
+	repository.TrashAudiobook,
+	repository.TrashTrack,
+	repository.TrashAuthor,
+	repository.TrashReader,
+	repository.TrashGenre,
+}
+
+type TrashService struct {
+	trashRepo       repository.TrashRepositoryInterface
+	audiobookRepo   repository.AudiobookRepositoryInterface
+	trackRepo       repository.TrackRepositoryInterface
+	searchIndexRepo repository.SearchIndexRepositoryInterface
+	retention       time.Duration
+}
+
+func NewTrashService(
+	trashRepo repository.TrashRepositoryInterface,
+	audiobookRepo repository.AudiobookRepositoryInterface,
+	trackRepo repository.TrackRepositoryInterface,
+	searchIndexRepo repository.SearchIndexRepositoryInterface,
+	retention time.Duration,
+) *TrashService {
+	return &TrashService{
+		trashRepo:       trashRepo,
+		audiobookRepo:   audiobookRepo,
+		trackRepo:       trackRepo,
+		searchIndexRepo: searchIndexRepo,
+		retention:       retention,
+	}
+}
+
+// GetTrash lists the deleted entries of one type, most recently deleted first by default
+func (s *TrashService) GetTrash(trashType string, req dto.ListRequest) (*dto.ListResponse, error) {
+	items, result, err := s.trashRepo.List(trashType, listPage(req))
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []dto.TrashItemResponse
+	for _, item := range items {
+		responses = append(responses, s.convertToTrashItemResponse(trashType, item))
+	}
+
+	return &dto.ListResponse{
+		Items:      responses,
+		Pagination: listPagination(req, result),
+	}, nil
+}
+
+// Restore takes an entry out of the trash with its associations. Tracks can only be restored
+// while their audiobook is not in the trash.
+func (s *TrashService) Restore(trashType string, id uint) (*dto.TrashItemResponse, error) {
+	item, err := s.trashRepo.GetByID(trashType, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("item not found in trash")
+		}
+		return nil, err
+	}
+
+	if trashType == repository.TrashTrack {
+		if _, err := s.audiobookRepo.GetByID(item.AudiobookID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("audiobook of the track is in the trash")
+			}
+			return nil, err
+		}
+	}
+
+	if err := s.trashRepo.Restore(trashType, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("item not found in trash")
+		}
+		return nil, err
+	}
+
+	if err := s.afterRestore(trashType, item); err != nil {
+		return nil, err
+	}
+
+	response := s.convertToTrashItemResponse(trashType, *item)
+	return &response, nil
+}
+
+// Purge permanently deletes every entry that has been in the trash longer than the retention
+// period, returning how many entries of each type were deleted
+func (s *TrashService) Purge() (*dto.TrashPurgeResponse, error) {
+	deletedBefore := time.Now().Add(-s.retention)
+	response := &dto.TrashPurgeResponse{Purged: map[string]int64{}}
+
+	for _, trashType := range trashPurgeOrder {
+		purged, err := s.trashRepo.Purge(trashType, deletedBefore)
+		if err != nil {
+			return nil, err
+		}
+		response.Purged[trashType] = purged
+	}
+
+	return response, nil
+}
+
+// RunPurger purges expired trash entries every interval until ctx is cancelled
+func (s *TrashService) RunPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := s.Purge()
+			if err != nil {
+				log.Printf("Trash purger: %v", err)
+				continue
+			}
+			for _, trashType := range trashPurgeOrder {
+				if purged := result.Purged[trashType]; purged > 0 {
+					log.Printf("Trash purger: purged %d %ss", purged, trashType)
+				}
+			}
+		}
+	}
+}
+
+// Helper methods
+
+// afterRestore brings the search index and audiobook lengths up to date with a restored entry
+func (s *TrashService) afterRestore(trashType string, item *repository.TrashedItem) error {
+	switch trashType {
+	case repository.TrashAudiobook:
+		// The primary author and reader may have been restored along with the audiobook
+		audiobook, err := s.audiobookRepo.GetByID(item.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.searchIndexRepo.RefreshByAuthorID(audiobook.AuthorID); err != nil {
+			return err
+		}
+		return s.searchIndexRepo.RefreshByReaderID(audiobook.ReaderID)
+	case repository.TrashAuthor:
+		return s.searchIndexRepo.RefreshByAuthorID(item.ID)
+	case repository.TrashReader:
+		return s.searchIndexRepo.RefreshByReaderID(item.ID)
+	case repository.TrashGenre:
+		return s.searchIndexRepo.RefreshByGenreID(item.ID)
+	case repository.TrashTrack:
+		return syncTotalDuration(s.trackRepo, s.audiobookRepo, item.AudiobookID)
+	}
+	return nil
+}
+
+func (s *TrashService) convertToTrashItemResponse(trashType string, item repository.TrashedItem) dto.TrashItemResponse {
+	return dto.TrashItemResponse{
+		Type:        trashType,
+		ID:          item.ID,
+		Name:        item.Name,
+		AudiobookID: item.AudiobookID,
+		DeletedAt:   item.DeletedAt,
+		PurgeAt:     item.DeletedAt.Add(s.retention),
+	}
+}
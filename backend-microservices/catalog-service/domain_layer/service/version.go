@@ -0,0 +1,12 @@
+package service
+
+import "catalog-service/data_layer/repository"
+
+// checkVersion returns repository.ErrVersionConflict when a row is no longer at the version a
+// write expects; an expected version of 0 matches any version
+func checkVersion(current, expected uint) error {
+	if expected != 0 && current != expected {
+		return repository.ErrVersionConflict
+	}
+	return nil
+}
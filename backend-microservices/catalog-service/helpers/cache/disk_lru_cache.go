@@ -0,0 +1,300 @@
+package cache
+
+import (
+	"container/list"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const tempFilePrefix = ".tmp-"
+
+// DiskLRUCache is a size-bounded least-recently-used cache of files on local disk
+type DiskLRUCache struct {
+	dir      string
+	maxBytes int64
+
+	mu       sync.Mutex
+	size     int64
+	ll       *list.List
+	items    map[string]*list.Element
+	inflight map[string]*cacheFill
+}
+
+type cacheEntry struct {
+	key  string
+	size int64
+}
+
+type cacheFill struct {
+	done chan struct{}
+	err  error
+}
+
+// NewDiskLRUCache creates a cache in dir, indexing files left there by a previous run
+func NewDiskLRUCache(dir string, maxBytes int64) (*DiskLRUCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	c := &DiskLRUCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+		inflight: make(map[string]*cacheFill),
+	}
+
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Open returns the cached file for key and marks it as recently used
+func (c *DiskLRUCache) Open(key string) (*os.File, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.openLocked(key)
+}
+
+// Fill returns the cached file for key, calling fetch to populate it on a miss.
+// Concurrent fills of the same key share a single fetch.
+func (c *DiskLRUCache) Fill(key string, fetch func(w io.Writer) error) (*os.File, error) {
+	for {
+		c.mu.Lock()
+		if file, ok := c.openLocked(key); ok {
+			c.mu.Unlock()
+			return file, nil
+		}
+
+		if pending, ok := c.inflight[key]; ok {
+			c.mu.Unlock()
+			<-pending.done
+			if pending.err != nil {
+				return nil, pending.err
+			}
+			continue
+		}
+
+		pending := &cacheFill{done: make(chan struct{})}
+		c.inflight[key] = pending
+		c.mu.Unlock()
+
+		file, err := c.fill(key, fetch)
+
+		c.mu.Lock()
+		delete(c.inflight, key)
+		c.mu.Unlock()
+
+		pending.err = err
+		close(pending.done)
+
+		return file, err
+	}
+}
+
+// Has reports whether key is cached and marks it as recently used
+func (c *DiskLRUCache) Has(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if ok {
+		c.ll.MoveToFront(element)
+	}
+	return ok
+}
+
+// TempDir creates a scratch directory inside the cache for files that are later added with Put.
+// The caller removes it when done; leftovers are removed on the next start.
+func (c *DiskLRUCache) TempDir() (string, error) {
+	return os.MkdirTemp(c.dir, tempFilePrefix)
+}
+
+// Put moves the file at path into the cache under key, replacing any cached file, and evicts least
+// recently used files to make room. path must be on the same file system as the cache, e.g. in TempDir.
+func (c *DiskLRUCache) Put(key string, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	// A file larger than the whole cache is not kept
+	if info.Size() > c.maxBytes {
+		return os.Remove(path)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.Rename(path, c.path(key)); err != nil {
+		return err
+	}
+	c.addLocked(key, info.Size())
+	c.evictLocked()
+
+	return nil
+}
+
+// Size returns the number of bytes currently held by the cache
+func (c *DiskLRUCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.size
+}
+
+func (c *DiskLRUCache) fill(key string, fetch func(w io.Writer) error) (*os.File, error) {
+	tmp, err := os.CreateTemp(c.dir, tempFilePrefix)
+	if err != nil {
+		return nil, err
+	}
+	tmpPath := tmp.Name()
+
+	if err := fetch(tmp); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return nil, err
+	}
+
+	info, err := os.Stat(tmpPath)
+	if err != nil {
+		os.Remove(tmpPath)
+		return nil, err
+	}
+
+	path := c.path(key)
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// A file larger than the whole cache is served once and not kept
+	if info.Size() > c.maxBytes {
+		os.Remove(path)
+		return file, nil
+	}
+
+	c.mu.Lock()
+	c.addLocked(key, info.Size())
+	c.evictLocked()
+	c.mu.Unlock()
+
+	return file, nil
+}
+
+func (c *DiskLRUCache) openLocked(key string) (*os.File, bool) {
+	element, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	file, err := os.Open(c.path(key))
+	if err != nil {
+		c.removeLocked(element)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(element)
+
+	// Persist recency so the LRU order survives restarts
+	now := time.Now()
+	os.Chtimes(c.path(key), now, now)
+
+	return file, true
+}
+
+func (c *DiskLRUCache) addLocked(key string, size int64) {
+	if element, ok := c.items[key]; ok {
+		c.size -= element.Value.(*cacheEntry).size
+		element.Value.(*cacheEntry).size = size
+		c.size += size
+		c.ll.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&cacheEntry{key: key, size: size})
+	c.size += size
+}
+
+func (c *DiskLRUCache) evictLocked() {
+	for c.size > c.maxBytes {
+		oldest := c.ll.Back()
+		if oldest == nil {
+			return
+		}
+
+		entry := oldest.Value.(*cacheEntry)
+		c.removeLocked(oldest)
+		// Files still being served stay readable until closed
+		if err := os.Remove(c.path(entry.key)); err != nil && !os.IsNotExist(err) {
+			log.Printf("Disk cache: failed to evict %s: %v", entry.key, err)
+		}
+	}
+}
+
+func (c *DiskLRUCache) removeLocked(element *list.Element) {
+	entry := element.Value.(*cacheEntry)
+	c.ll.Remove(element)
+	delete(c.items, entry.key)
+	c.size -= entry.size
+}
+
+func (c *DiskLRUCache) load() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	var files []os.FileInfo
+	for _, entry := range entries {
+		// Leftovers of interrupted fills and temporary directories
+		if strings.HasPrefix(entry.Name(), tempFilePrefix) {
+			os.RemoveAll(filepath.Join(c.dir, entry.Name()))
+			continue
+		}
+
+		if entry.IsDir() {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, info)
+	}
+
+	// Oldest first, so the most recently used file ends up at the front
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	for _, info := range files {
+		c.addLocked(info.Name(), info.Size())
+	}
+	c.evictLocked()
+
+	return nil
+}
+
+func (c *DiskLRUCache) path(key string) string {
+	return filepath.Join(c.dir, key)
+}
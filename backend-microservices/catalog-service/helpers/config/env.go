@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
 // GetUserManagementBaseURL returns the base URL for user management service
@@ -28,3 +30,152 @@ func GetUserManagementValidateURL() string {
 	}
 	return url
 }
+
+// GetStreamCacheDir returns the directory used to cache streamed audio files
+func GetStreamCacheDir() string {
+	dir := os.Getenv("STREAM_CACHE_DIR")
+	if dir == "" {
+		dir = "storage/stream-cache" // default
+	}
+	return dir
+}
+
+// GetStreamCacheMaxBytes returns the maximum size of the stream cache in bytes
+func GetStreamCacheMaxBytes() int64 {
+	maxBytes, err := strconv.ParseInt(os.Getenv("STREAM_CACHE_MAX_BYTES"), 10, 64)
+	if err != nil || maxBytes <= 0 {
+		maxBytes = 2 << 30 // default 2 GiB
+	}
+	return maxBytes
+}
+
+// GetDownloadCacheDir returns the directory used to cache audiobook download bundles
+func GetDownloadCacheDir() string {
+	dir := os.Getenv("DOWNLOAD_CACHE_DIR")
+	if dir == "" {
+		dir = "storage/download-cache" // default
+	}
+	return dir
+}
+
+// GetDownloadCacheMaxBytes returns the maximum size of the download bundle cache in bytes
+func GetDownloadCacheMaxBytes() int64 {
+	maxBytes, err := strconv.ParseInt(os.Getenv("DOWNLOAD_CACHE_MAX_BYTES"), 10, 64)
+	if err != nil || maxBytes <= 0 {
+		maxBytes = 5 << 30 // default 5 GiB
+	}
+	return maxBytes
+}
+
+// GetHLSCacheDir returns the directory used to store HLS segments and playlists
+func GetHLSCacheDir() string {
+	dir := os.Getenv("HLS_CACHE_DIR")
+	if dir == "" {
+		dir = "storage/hls-cache" // default
+	}
+	return dir
+}
+
+// GetHLSCacheMaxBytes returns the maximum size of the HLS segment cache in bytes
+func GetHLSCacheMaxBytes() int64 {
+	maxBytes, err := strconv.ParseInt(os.Getenv("HLS_CACHE_MAX_BYTES"), 10, 64)
+	if err != nil || maxBytes <= 0 {
+		maxBytes = 5 << 30 // default 5 GiB
+	}
+	return maxBytes
+}
+
+// GetHLSSegmentDuration returns the target duration of HLS media segments
+func GetHLSSegmentDuration() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("HLS_SEGMENT_SECONDS"))
+	if err != nil || seconds <= 0 {
+		seconds = 10 // default
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// GetUserManagementAPIKey returns the API key used for the external user management endpoints
+func GetUserManagementAPIKey() string {
+	apiKey := os.Getenv("USER_MANAGEMENT_API_KEY")
+	if apiKey == "" {
+		apiKey = "alat-service-api-key" // default
+	}
+	return apiKey
+}
+
+// GetPublicBaseURL returns the externally reachable base URL of this service
+func GetPublicBaseURL() string {
+	baseURL := os.Getenv("PUBLIC_BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:3163" // default
+	}
+	return baseURL
+}
+
+// GetStreamSigningKeys returns the stream URL signing key ring ("kid:secret,..."), newest key first
+func GetStreamSigningKeys() string {
+	return os.Getenv("STREAM_SIGNING_KEYS")
+}
+
+// GetStreamURLTTL returns how long signed stream URLs stay valid
+func GetStreamURLTTL() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("STREAM_URL_TTL_SECONDS"))
+	if err != nil || seconds <= 0 {
+		seconds = 900 // default 15 minutes
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// GetPlaySessionHeartbeatInterval returns how often players should send session heartbeats
+func GetPlaySessionHeartbeatInterval() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("PLAY_SESSION_HEARTBEAT_SECONDS"))
+	if err != nil || seconds <= 0 {
+		seconds = 30 // default
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// GetPlaySessionStaleAfter returns how long a session may go without a heartbeat before it is abandoned
+func GetPlaySessionStaleAfter() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("PLAY_SESSION_STALE_SECONDS"))
+	if err != nil || seconds <= 0 {
+		seconds = 180 // default
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// GetSearchDefaultLanguage returns the text search language used when a search request names none
+func GetSearchDefaultLanguage() string {
+	language := os.Getenv("SEARCH_DEFAULT_LANGUAGE")
+	if language == "" {
+		language = "english" // default
+	}
+	return language
+}
+
+// GetTrashRetention returns how long deleted catalog entries stay in the trash before they are purged
+func GetTrashRetention() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS"))
+	if err != nil || days <= 0 {
+		days = 30 // default
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// GetTrashPurgeInterval returns how often entries past the trash retention are purged
+func GetTrashPurgeInterval() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("TRASH_PURGE_INTERVAL_MINUTES"))
+	if err != nil || minutes <= 0 {
+		minutes = 60 // default
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// GetReplicationAPIKey returns the API key content-management-service sends with replicated events
+func GetReplicationAPIKey() string {
+	apiKey := os.Getenv("REPLICATION_API_KEY")
+	if apiKey == "" {
+		apiKey = "alat-replication-api-key" // default
+	}
+	return apiKey
+}
@@ -0,0 +1,105 @@
+package duration
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidDuration = errors.New("invalid duration")
+
+var (
+	clockPattern = regexp.MustCompile(`^\d+(:\d{1,2}){0,2}(\.\d+)?$`)
+	unitPattern  = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*([a-z]+)`)
+	separators   = regexp.MustCompile(`[\s,]+|\band\b`)
+)
+
+// unitSeconds maps the unit spellings found in catalog data to their length in seconds
+var unitSeconds = map[string]float64{
+	"h": 3600, "hr": 3600, "hrs": 3600, "hour": 3600, "hours": 3600,
+	"m": 60, "min": 60, "mins": 60, "minute": 60, "minutes": 60,
+	"s": 1, "sec": 1, "secs": 1, "second": 1, "seconds": 1,
+}
+
+// Parse converts a free-form duration into whole seconds.
+// It accepts clock notation ("00:04:44", "12:34:56", "4:44", "284"), unit notation
+// ("10 hr 23 min", "1h30m", "45 minutes") and ISO 8601 ("PT1H2M3S").
+// An empty string is zero; fractional seconds are rounded.
+func Parse(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, nil
+	}
+
+	if clockPattern.MatchString(s) {
+		return parseClock(s)
+	}
+
+	if strings.HasPrefix(s, "pt") {
+		s = s[2:]
+	}
+	return parseUnits(s)
+}
+
+// Format renders seconds in the HH:MM:SS clock notation used by track and audiobook durations
+func Format(seconds int) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
+
+func parseClock(s string) (int, error) {
+	parts := strings.Split(s, ":")
+
+	total := 0.0
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, ErrInvalidDuration
+		}
+		// Only the leading component may exceed its unit
+		if i > 0 && value >= 60 {
+			return 0, ErrInvalidDuration
+		}
+		total = total*60 + value
+	}
+
+	return int(math.Round(total)), nil
+}
+
+func parseUnits(s string) (int, error) {
+	matches := unitPattern.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return 0, ErrInvalidDuration
+	}
+
+	total := 0.0
+	last := 0
+	for _, match := range matches {
+		// Anything between two amounts must be a separator
+		if separators.ReplaceAllString(s[last:match[0]], "") != "" {
+			return 0, ErrInvalidDuration
+		}
+
+		value, err := strconv.ParseFloat(s[match[2]:match[3]], 64)
+		if err != nil {
+			return 0, ErrInvalidDuration
+		}
+		unit, ok := unitSeconds[s[match[4]:match[5]]]
+		if !ok {
+			return 0, ErrInvalidDuration
+		}
+
+		total += value * unit
+		last = match[1]
+	}
+	if separators.ReplaceAllString(s[last:], "") != "" {
+		return 0, ErrInvalidDuration
+	}
+
+	return int(math.Round(total)), nil
+}
@@ -0,0 +1,85 @@
+package duration
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{name: "empty", input: "", want: 0},
+		{name: "blank", input: "   ", want: 0},
+		{name: "seconds only", input: "284", want: 284},
+		{name: "minutes and seconds", input: "4:44", want: 284},
+		{name: "hours, minutes and seconds", input: "00:04:44", want: 284},
+		{name: "long clock", input: "12:34:56", want: 45296},
+		{name: "leading component may exceed its unit", input: "100:00:00", want: 360000},
+		{name: "fractional seconds are rounded", input: "4:44.6", want: 285},
+		{name: "minutes out of range", input: "1:60:00", wantErr: true},
+		{name: "seconds out of range", input: "1:60", wantErr: true},
+		{name: "too many clock components", input: "1:2:3:4", wantErr: true},
+		{name: "spaced units", input: "10 hr 23 min", want: 37380},
+		{name: "compact units", input: "1h30m", want: 5400},
+		{name: "single unit", input: "45 minutes", want: 2700},
+		{name: "fractional unit", input: "1.5 hours", want: 5400},
+		{name: "units with separators", input: "1 hour and 2 minutes, 3 seconds", want: 3723},
+		{name: "case and padding ignored", input: "  2 MIN ", want: 120},
+		{name: "ISO 8601", input: "PT1H2M3S", want: 3723},
+		{name: "ISO 8601 fractional seconds", input: "PT2M3.5S", want: 124},
+		{name: "unknown unit", input: "10 parsecs", wantErr: true},
+		{name: "words only", input: "abc", wantErr: true},
+		{name: "trailing junk", input: "1h 30m extra", wantErr: true},
+		{name: "junk between amounts", input: "1h foo 30m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidDuration) {
+					t.Fatalf("Parse(%q) error = %v, want ErrInvalidDuration", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    string
+	}{
+		{seconds: 0, want: "00:00:00"},
+		{seconds: 59, want: "00:00:59"},
+		{seconds: 3723, want: "01:02:03"},
+		{seconds: 360000, want: "100:00:00"},
+		{seconds: -5, want: "00:00:00"},
+	}
+
+	for _, tt := range tests {
+		if got := Format(tt.seconds); got != tt.want {
+			t.Errorf("Format(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestFormatParsesBack(t *testing.T) {
+	for _, seconds := range []int{0, 1, 59, 60, 3599, 3600, 45296, 360000} {
+		got, err := Parse(Format(seconds))
+		if err != nil || got != seconds {
+			t.Errorf("Parse(Format(%d)) = %d, %v", seconds, got, err)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package hls
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// ErrNoFrames is returned when a stream contains no MPEG audio frame
+var ErrNoFrames = errors.New("no MPEG audio frames found")
+
+// bitrates in kbps indexed by [version row][layer row][bitrate index]
+var bitrateTable = [2][3][16]int{
+	// MPEG-1
+	{
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448, 0}, // Layer I
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384, 0},    // Layer II
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},     // Layer III
+	},
+	// MPEG-2 and MPEG-2.5
+	{
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256, 0}, // Layer I
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},      // Layer II
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},      // Layer III
+	},
+}
+
+// sample rates in Hz indexed by [version bits][sample rate index]
+var sampleRateTable = [4][3]int{
+	{11025, 12000, 8000},  // MPEG-2.5
+	{0, 0, 0},             // reserved
+	{22050, 24000, 16000}, // MPEG-2
+	{44100, 48000, 32000}, // MPEG-1
+}
+
+// FrameHeader describes a single MPEG audio frame
+type FrameHeader struct {
+	Bitrate         int // bits per second
+	SampleRate      int // Hz
+	SamplesPerFrame int
+	Length          int // bytes, header included
+}
+
+// ParseFrameHeader decodes a 4-byte MPEG audio frame header
+func ParseFrameHeader(b []byte) (FrameHeader, bool) {
+	if len(b) < 4 || b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		return FrameHeader{}, false
+	}
+
+	versionBits := (b[1] >> 3) & 0x03
+	layerBits := (b[1] >> 1) & 0x03
+	bitrateIndex := b[2] >> 4
+	sampleRateIndex := (b[2] >> 2) & 0x03
+	padding := int((b[2] >> 1) & 0x01)
+
+	// Reserved version/layer/sample rate and free-format or bad bitrates are not playable
+	if versionBits == 1 || layerBits == 0 || sampleRateIndex == 3 || bitrateIndex == 0 || bitrateIndex == 15 {
+		return FrameHeader{}, false
+	}
+
+	versionRow := 0
+	if versionBits != 3 {
+		versionRow = 1
+	}
+	layer := 4 - int(layerBits) // 1, 2 or 3
+
+	bitrate := bitrateTable[versionRow][layer-1][bitrateIndex] * 1000
+	sampleRate := sampleRateTable[versionBits][sampleRateIndex]
+
+	var samples, length int
+	switch layer {
+	case 1:
+		samples = 384
+		length = (12*bitrate/sampleRate + padding) * 4
+	case 2:
+		samples = 1152
+		length = 144*bitrate/sampleRate + padding
+	default:
+		samples = 1152
+		if versionRow == 1 {
+			samples = 576
+		}
+		length = samples/8*bitrate/sampleRate + padding
+	}
+
+	if length < 4 {
+		return FrameHeader{}, false
+	}
+
+	return FrameHeader{
+		Bitrate:         bitrate,
+		SampleRate:      sampleRate,
+		SamplesPerFrame: samples,
+		Length:          length,
+	}, true
+}
+
+// FrameReader reads whole MPEG audio frames from a stream, skipping ID3 tags and junk
+type FrameReader struct {
+	r       *bufio.Reader
+	started bool
+	synced  bool
+}
+
+// NewFrameReader creates a frame reader over r
+func NewFrameReader(r io.Reader) *FrameReader {
+	return &FrameReader{r: bufio.NewReaderSize(r, 64*1024)}
+}
+
+// Next returns the next frame header and its bytes. It returns io.EOF at the end of the stream.
+func (fr *FrameReader) Next() (FrameHeader, []byte, error) {
+	if !fr.started {
+		fr.started = true
+		if err := fr.skipID3v2(); err != nil {
+			return FrameHeader{}, nil, err
+		}
+	}
+
+	for {
+		head, err := fr.r.Peek(4)
+		if err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return FrameHeader{}, nil, io.EOF
+			}
+			return FrameHeader{}, nil, err
+		}
+
+		header, ok := ParseFrameHeader(head)
+		if !ok {
+			fr.synced = false
+			if _, err := fr.r.Discard(1); err != nil {
+				return FrameHeader{}, nil, err
+			}
+			continue
+		}
+
+		// After losing sync, only trust a header that is followed by another valid one
+		if !fr.synced {
+			ahead, err := fr.r.Peek(header.Length + 4)
+			if err == nil {
+				if _, ok := ParseFrameHeader(ahead[header.Length:]); !ok {
+					fr.r.Discard(1)
+					continue
+				}
+			}
+		}
+
+		frame, err := fr.r.Peek(header.Length)
+		if err != nil {
+			// Truncated last frame
+			if err == io.EOF || err == io.ErrUnexpectedEOF || err == bufio.ErrBufferFull {
+				return FrameHeader{}, nil, io.EOF
+			}
+			return FrameHeader{}, nil, err
+		}
+
+		out := make([]byte, len(frame))
+		copy(out, frame)
+		fr.r.Discard(header.Length)
+		fr.synced = true
+
+		return header, out, nil
+	}
+}
+
+// skipID3v2 skips a leading ID3v2 tag if present
+func (fr *FrameReader) skipID3v2() error {
+	head, err := fr.r.Peek(10)
+	if err != nil || string(head[:3]) != "ID3" {
+		return nil
+	}
+
+	size := int(head[6])<<21 | int(head[7])<<14 | int(head[8])<<7 | int(head[9])
+	size += 10
+	if head[5]&0x10 != 0 {
+		size += 10 // footer
+	}
+
+	if _, err := fr.r.Discard(size); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
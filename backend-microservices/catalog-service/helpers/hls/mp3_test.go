@@ -0,0 +1,132 @@
+package hls
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// frame returns one MPEG audio frame with the given header, zero-filled to length bytes
+func frame(header []byte, length int) []byte {
+	b := make([]byte, length)
+	copy(b, header)
+	return b
+}
+
+// mpeg1Layer3 is the header of a 128 kbps, 44.1 kHz MPEG-1 Layer III frame of 417 bytes
+var mpeg1Layer3 = []byte{0xFF, 0xFB, 0x90, 0x00}
+
+func TestParseFrameHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []byte
+		want   FrameHeader
+		ok     bool
+	}{
+		{
+			name:   "MPEG-1 Layer III 128 kbps 44.1 kHz",
+			header: mpeg1Layer3,
+			want:   FrameHeader{Bitrate: 128000, SampleRate: 44100, SamplesPerFrame: 1152, Length: 417},
+			ok:     true,
+		},
+		{
+			name:   "padding adds a byte",
+			header: []byte{0xFF, 0xFB, 0x92, 0x00},
+			want:   FrameHeader{Bitrate: 128000, SampleRate: 44100, SamplesPerFrame: 1152, Length: 418},
+			ok:     true,
+		},
+		{
+			name:   "MPEG-1 Layer III 320 kbps 48 kHz",
+			header: []byte{0xFF, 0xFB, 0xE4, 0x00},
+			want:   FrameHeader{Bitrate: 320000, SampleRate: 48000, SamplesPerFrame: 1152, Length: 960},
+			ok:     true,
+		},
+		{
+			name:   "MPEG-2 Layer III has half the samples",
+			header: []byte{0xFF, 0xF3, 0x80, 0x00},
+			want:   FrameHeader{Bitrate: 64000, SampleRate: 22050, SamplesPerFrame: 576, Length: 208},
+			ok:     true,
+		},
+		{
+			name:   "MPEG-1 Layer II",
+			header: []byte{0xFF, 0xFD, 0xA4, 0x00},
+			want:   FrameHeader{Bitrate: 192000, SampleRate: 48000, SamplesPerFrame: 1152, Length: 576},
+			ok:     true,
+		},
+		{
+			name:   "MPEG-1 Layer I",
+			header: []byte{0xFF, 0xFF, 0x18, 0x00},
+			want:   FrameHeader{Bitrate: 32000, SampleRate: 32000, SamplesPerFrame: 384, Length: 48},
+			ok:     true,
+		},
+		{name: "no frame sync", header: []byte{0xFF, 0x7B, 0x90, 0x00}},
+		{name: "reserved version", header: []byte{0xFF, 0xEB, 0x90, 0x00}},
+		{name: "reserved layer", header: []byte{0xFF, 0xF9, 0x90, 0x00}},
+		{name: "free format bitrate", header: []byte{0xFF, 0xFB, 0x00, 0x00}},
+		{name: "bad bitrate", header: []byte{0xFF, 0xFB, 0xF0, 0x00}},
+		{name: "reserved sample rate", header: []byte{0xFF, 0xFB, 0x9C, 0x00}},
+		{name: "too short", header: []byte{0xFF, 0xFB, 0x90}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseFrameHeader(tt.header)
+			if ok != tt.ok {
+				t.Fatalf("ParseFrameHeader(% X) ok = %v, want %v", tt.header, ok, tt.ok)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFrameHeader(% X) = %+v, want %+v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrameReader(t *testing.T) {
+	one := frame(mpeg1Layer3, 417)
+	frames := func(n int) []byte {
+		return bytes.Repeat(one, n)
+	}
+	id3Tag := append([]byte{'I', 'D', '3', 3, 0, 0, 0, 0, 0, 20}, make([]byte, 20)...)
+	// A frame sync in junk that is not followed by another frame
+	falseSync := append(append([]byte{}, mpeg1Layer3...), []byte("not an mp3 frame")...)
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  int
+	}{
+		{name: "empty", input: nil, want: 0},
+		{name: "frames only", input: frames(3), want: 3},
+		{name: "ID3v2 tag is skipped", input: append(append([]byte{}, id3Tag...), frames(3)...), want: 3},
+		{name: "junk before the first frame", input: append([]byte("junk"), frames(3)...), want: 3},
+		{name: "junk between frames", input: bytes.Join([][]byte{frames(2), []byte("junk"), frames(2)}, nil), want: 4},
+		{name: "a first frame followed by junk is not trusted", input: bytes.Join([][]byte{frames(1), []byte("junk"), frames(2)}, nil), want: 2},
+		{name: "false sync in junk", input: append(falseSync, frames(2)...), want: 2},
+		{name: "truncated last frame", input: append(frames(2), one[:100]...), want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewFrameReader(bytes.NewReader(tt.input))
+
+			got := 0
+			for {
+				header, data, err := reader.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next: %v", err)
+				}
+				if header.Length != len(data) || !bytes.Equal(data, one) {
+					t.Fatalf("frame %d: got %d bytes starting % X, want the 417-byte test frame", got, len(data), data[:4])
+				}
+				got++
+			}
+
+			if got != tt.want {
+				t.Errorf("read %d frames, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package hls
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+// PlaylistSegment is one entry of a media playlist
+type PlaylistSegment struct {
+	URI           string
+	Duration      float64
+	Discontinuity bool
+}
+
+// MediaPlaylist renders a VOD media playlist
+func MediaPlaylist(segments []PlaylistSegment) []byte {
+	targetDuration := 1
+	for _, segment := range segments {
+		if d := int(math.Ceil(segment.Duration)); d > targetDuration {
+			targetDuration = d
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("#EXTM3U\n")
+	buf.WriteString("#EXT-X-VERSION:3\n")
+	fmt.Fprintf(&buf, "#EXT-X-TARGETDURATION:%d\n", targetDuration)
+	buf.WriteString("#EXT-X-MEDIA-SEQUENCE:0\n")
+	buf.WriteString("#EXT-X-PLAYLIST-TYPE:VOD\n")
+
+	for _, segment := range segments {
+		if segment.Discontinuity {
+			buf.WriteString("#EXT-X-DISCONTINUITY\n")
+		}
+		fmt.Fprintf(&buf, "#EXTINF:%.3f,\n", segment.Duration)
+		buf.WriteString(segment.URI + "\n")
+	}
+
+	buf.WriteString("#EXT-X-ENDLIST\n")
+	return buf.Bytes()
+}
+
+// MasterPlaylist renders a master playlist with a single MP3 audio rendition
+func MasterPlaylist(uri string, bandwidth, averageBandwidth int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("#EXTM3U\n")
+	buf.WriteString("#EXT-X-VERSION:3\n")
+	fmt.Fprintf(&buf, "#EXT-X-STREAM-INF:BANDWIDTH=%d,AVERAGE-BANDWIDTH=%d,CODECS=\"mp4a.40.34\"\n", bandwidth, averageBandwidth)
+	buf.WriteString(uri + "\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,163 @@
+package hls
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// transportStreamTimestampOwner identifies the ID3 PRIV frame carrying the
+// timestamp of a packed audio segment (RFC 8216, section 3.4)
+const transportStreamTimestampOwner = "com.apple.streaming.transportStreamTimestamp"
+
+// Segment is one media segment file of a segmented track
+type Segment struct {
+	Name     string  `json:"name"`
+	Duration float64 `json:"duration"`
+	Size     int64   `json:"size"`
+}
+
+// SegmentedTrack describes the media segments produced for a track
+type SegmentedTrack struct {
+	Segments []Segment `json:"segments"`
+	Duration float64   `json:"duration"`
+}
+
+// SegmentName returns the file name of the segment at index
+func SegmentName(index int) string {
+	return fmt.Sprintf("segment_%05d.mp3", index)
+}
+
+// SegmentMP3 splits an MP3 stream on frame boundaries into packed audio segments
+// of roughly target duration each, written into dir
+func SegmentMP3(r io.Reader, dir string, target time.Duration) (*SegmentedTrack, error) {
+	frames := NewFrameReader(r)
+	result := &SegmentedTrack{}
+
+	var (
+		file            *os.File
+		writer          *bufio.Writer
+		segment         Segment
+		segmentSamples  int64
+		totalSamples    int64
+		sampleRate      int
+		targetInSeconds = target.Seconds()
+	)
+
+	closeSegment := func() error {
+		if file == nil {
+			return nil
+		}
+		if err := writer.Flush(); err != nil {
+			file.Close()
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+		segment.Duration = float64(segmentSamples) / float64(sampleRate)
+		result.Segments = append(result.Segments, segment)
+		file = nil
+		return nil
+	}
+
+	for {
+		header, frame, err := frames.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if file != nil {
+				file.Close()
+			}
+			return nil, err
+		}
+
+		// Changing sample rates are not expected in a single track; keep the first one for timing
+		if sampleRate == 0 {
+			sampleRate = header.SampleRate
+		}
+
+		if file != nil && float64(segmentSamples)/float64(sampleRate) >= targetInSeconds {
+			if err := closeSegment(); err != nil {
+				return nil, err
+			}
+		}
+
+		if file == nil {
+			segment = Segment{Name: SegmentName(len(result.Segments))}
+			segmentSamples = 0
+
+			file, err = os.Create(filepath.Join(dir, segment.Name))
+			if err != nil {
+				return nil, err
+			}
+			writer = bufio.NewWriter(file)
+
+			// 90 kHz presentation timestamp of the first frame in the segment
+			pts := uint64(totalSamples) * 90000 / uint64(sampleRate)
+			tag := timestampTag(pts)
+			if _, err := writer.Write(tag); err != nil {
+				file.Close()
+				return nil, err
+			}
+			segment.Size += int64(len(tag))
+		}
+
+		if _, err := writer.Write(frame); err != nil {
+			file.Close()
+			return nil, err
+		}
+		segment.Size += int64(len(frame))
+		segmentSamples += int64(header.SamplesPerFrame)
+		totalSamples += int64(header.SamplesPerFrame)
+	}
+
+	if err := closeSegment(); err != nil {
+		return nil, err
+	}
+
+	if len(result.Segments) == 0 {
+		return nil, ErrNoFrames
+	}
+
+	result.Duration = float64(totalSamples) / float64(sampleRate)
+	return result, nil
+}
+
+// timestampTag builds the ID3v2.4 tag with the PRIV timestamp frame that
+// must start every packed audio segment
+func timestampTag(pts uint64) []byte {
+	payload := make([]byte, 0, len(transportStreamTimestampOwner)+1+8)
+	payload = append(payload, transportStreamTimestampOwner...)
+	payload = append(payload, 0)
+	payload = binary.BigEndian.AppendUint64(payload, pts&0x1FFFFFFFF)
+
+	frame := make([]byte, 0, 10+len(payload))
+	frame = append(frame, "PRIV"...)
+	frame = append(frame, syncsafe(len(payload))...)
+	frame = append(frame, 0, 0) // frame flags
+	frame = append(frame, payload...)
+
+	tag := make([]byte, 0, 10+len(frame))
+	tag = append(tag, "ID3"...)
+	tag = append(tag, 4, 0, 0) // version 2.4.0, no flags
+	tag = append(tag, syncsafe(len(frame))...)
+	tag = append(tag, frame...)
+
+	return tag
+}
+
+// syncsafe encodes n as a 4-byte ID3 syncsafe integer
+func syncsafe(n int) []byte {
+	return []byte{
+		byte(n>>21) & 0x7F,
+		byte(n>>14) & 0x7F,
+		byte(n>>7) & 0x7F,
+		byte(n) & 0x7F,
+	}
+}
@@ -0,0 +1,108 @@
+package hls
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSegmentMP3(t *testing.T) {
+	// Every test frame holds 1152 samples at 44.1 kHz, about 26 ms
+	const frameSeconds = 1152.0 / 44100
+
+	tests := []struct {
+		name         string
+		frames       int
+		target       time.Duration
+		wantSegments []int // frames per segment
+	}{
+		{name: "one segment when shorter than the target", frames: 100, target: 10 * time.Second, wantSegments: []int{100}},
+		{name: "a segment closes once it reaches the target", frames: 100, target: time.Second, wantSegments: []int{39, 39, 22}},
+		{name: "a full last segment is not followed by an empty one", frames: 39, target: time.Second, wantSegments: []int{39}},
+		{name: "one frame past the target starts a segment", frames: 40, target: time.Second, wantSegments: []int{39, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			input := bytes.Repeat(frame(mpeg1Layer3, 417), tt.frames)
+
+			track, err := SegmentMP3(bytes.NewReader(input), dir, tt.target)
+			if err != nil {
+				t.Fatalf("SegmentMP3: %v", err)
+			}
+
+			if len(track.Segments) != len(tt.wantSegments) {
+				t.Fatalf("got %d segments, want %d", len(track.Segments), len(tt.wantSegments))
+			}
+			if want := float64(tt.frames) * frameSeconds; math.Abs(track.Duration-want) > 1e-9 {
+				t.Errorf("track duration = %f, want %f", track.Duration, want)
+			}
+
+			framesBefore := 0
+			for i, segment := range track.Segments {
+				if segment.Name != SegmentName(i) {
+					t.Errorf("segment %d is named %q, want %q", i, segment.Name, SegmentName(i))
+				}
+				if want := float64(tt.wantSegments[i]) * frameSeconds; math.Abs(segment.Duration-want) > 1e-9 {
+					t.Errorf("segment %d duration = %f, want %f", i, segment.Duration, want)
+				}
+
+				data, err := os.ReadFile(filepath.Join(dir, segment.Name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if int64(len(data)) != segment.Size {
+					t.Errorf("segment %d has %d bytes, its size says %d", i, len(data), segment.Size)
+				}
+
+				// Each segment starts with the timestamp of its first frame on the 90 kHz clock
+				tag := timestampTag(uint64(framesBefore) * 1152 * 90000 / 44100)
+				if !bytes.HasPrefix(data, tag) {
+					t.Errorf("segment %d does not start with its timestamp tag", i)
+				}
+				if want := len(tag) + tt.wantSegments[i]*417; len(data) != want {
+					t.Errorf("segment %d has %d bytes, want %d", i, len(data), want)
+				}
+				framesBefore += tt.wantSegments[i]
+			}
+		})
+	}
+}
+
+func TestSegmentMP3RejectsStreamsWithoutFrames(t *testing.T) {
+	_, err := SegmentMP3(bytes.NewReader([]byte("not an mp3 at all")), t.TempDir(), time.Second)
+	if !errors.Is(err, ErrNoFrames) {
+		t.Fatalf("SegmentMP3 error = %v, want ErrNoFrames", err)
+	}
+}
+
+func TestTimestampTag(t *testing.T) {
+	tests := []struct {
+		name string
+		pts  uint64
+		want uint64
+	}{
+		{name: "zero", pts: 0, want: 0},
+		{name: "one second", pts: 90000, want: 90000},
+		{name: "wraps at 33 bits", pts: 1<<33 + 5, want: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag := timestampTag(tt.pts)
+
+			if !bytes.HasPrefix(tag, []byte("ID3")) {
+				t.Fatalf("tag starts with % X, want ID3", tag[:3])
+			}
+			if got := binary.BigEndian.Uint64(tag[len(tag)-8:]); got != tt.want {
+				t.Errorf("timestamp = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
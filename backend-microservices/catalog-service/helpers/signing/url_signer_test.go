@@ -0,0 +1,163 @@
+package signing
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	oldKey = Key{ID: "k1", Secret: []byte("old secret")}
+	newKey = Key{ID: "k2", Secret: []byte("new secret")}
+)
+
+// newTestSigner creates a signer over keys whose clock reads *now
+func newTestSigner(t *testing.T, now *time.Time, keys ...Key) *URLSigner {
+	t.Helper()
+
+	signer, err := NewURLSigner(keys, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer.now = func() time.Time { return *now }
+	return signer
+}
+
+func TestURLSignerVerify(t *testing.T) {
+	issuedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		signWith []Key
+		verifyAt time.Time
+		trackID  uint
+		modify   func(query url.Values)
+		wantErr  error
+	}{
+		{name: "valid", signWith: []Key{oldKey}, verifyAt: issuedAt, trackID: 7},
+		{name: "valid until the TTL elapses", signWith: []Key{oldKey}, verifyAt: issuedAt.Add(time.Hour), trackID: 7},
+		{name: "expired", signWith: []Key{oldKey}, verifyAt: issuedAt.Add(time.Hour + time.Second), trackID: 7, wantErr: ErrSignatureExpired},
+		{name: "other track", signWith: []Key{oldKey}, verifyAt: issuedAt, trackID: 8, wantErr: ErrSignatureInvalid},
+		{
+			name: "tampered signature", signWith: []Key{oldKey}, verifyAt: issuedAt, trackID: 7,
+			modify:  func(query url.Values) { query.Set("sig", "AAAA"+query.Get("sig")[4:]) },
+			wantErr: ErrSignatureInvalid,
+		},
+		{
+			name: "other user", signWith: []Key{oldKey}, verifyAt: issuedAt, trackID: 7,
+			modify:  func(query url.Values) { query.Set("uid", "user-2") },
+			wantErr: ErrSignatureInvalid,
+		},
+		{
+			name: "extended expiry", signWith: []Key{oldKey}, verifyAt: issuedAt.Add(2 * time.Hour), trackID: 7,
+			modify:  func(query url.Values) { query.Set("exp", "9999999999") },
+			wantErr: ErrSignatureInvalid,
+		},
+		{
+			name: "malformed expiry", signWith: []Key{oldKey}, verifyAt: issuedAt, trackID: 7,
+			modify:  func(query url.Values) { query.Set("exp", "soon") },
+			wantErr: ErrSignatureInvalid,
+		},
+		{
+			name: "missing signature", signWith: []Key{oldKey}, verifyAt: issuedAt, trackID: 7,
+			modify:  func(query url.Values) { query.Del("sig") },
+			wantErr: ErrSignatureMissing,
+		},
+		{
+			name: "unknown key", signWith: []Key{oldKey}, verifyAt: issuedAt, trackID: 7,
+			modify:  func(query url.Values) { query.Set("kid", "k9") },
+			wantErr: ErrUnknownKey,
+		},
+		{name: "signed before the key rotated", signWith: []Key{oldKey}, verifyAt: issuedAt, trackID: 7},
+		{name: "signed after the key rotated", signWith: []Key{newKey, oldKey}, verifyAt: issuedAt, trackID: 7},
+		{name: "signed with a key rotated out", signWith: []Key{{ID: "k0", Secret: []byte("retired")}}, verifyAt: issuedAt, trackID: 7, wantErr: ErrUnknownKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := issuedAt
+			query := newTestSigner(t, &now, tt.signWith...).Sign("user-1", 7)
+			if tt.modify != nil {
+				tt.modify(query)
+			}
+
+			// The verifying side has rotated to the new key and keeps the old one in its ring
+			now = tt.verifyAt
+			userID, err := newTestSigner(t, &now, newKey, oldKey).Verify(tt.trackID, query)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && userID != "user-1" {
+				t.Errorf("Verify user = %q, want user-1", userID)
+			}
+		})
+	}
+}
+
+func TestURLSignerSignsWithActiveKey(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	query := newTestSigner(t, &now, newKey, oldKey).SignForPlayback("user-1", 7, 30*time.Minute)
+
+	if got := query.Get("kid"); got != newKey.ID {
+		t.Errorf("kid = %q, want %q", got, newKey.ID)
+	}
+	if got, want := query.Get("exp"), "1704115800"; got != want {
+		t.Errorf("exp = %s, want %s (playback plus TTL)", got, want)
+	}
+}
+
+func TestNewURLSignerRejectsBadKeyRings(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []Key
+	}{
+		{name: "empty ring", keys: nil},
+		{name: "missing ID", keys: []Key{{Secret: []byte("secret")}}},
+		{name: "missing secret", keys: []Key{{ID: "k1"}}},
+		{name: "duplicate ID", keys: []Key{oldKey, {ID: oldKey.ID, Secret: []byte("other")}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewURLSigner(tt.keys, time.Hour); err == nil {
+				t.Error("NewURLSigner succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    []Key
+		wantErr bool
+	}{
+		{name: "empty", spec: "", want: nil},
+		{name: "one key", spec: "k1:secret", want: []Key{{ID: "k1", Secret: []byte("secret")}}},
+		{
+			name: "active key first, spaces and empty parts ignored",
+			spec: " k2:new , k1:old,,",
+			want: []Key{{ID: "k2", Secret: []byte("new")}, {ID: "k1", Secret: []byte("old")}},
+		},
+		{name: "secret containing a colon", spec: "k1:a:b", want: []Key{{ID: "k1", Secret: []byte("a:b")}}},
+		{name: "missing secret", spec: "k1:", wantErr: true},
+		{name: "missing ID", spec: ":secret", wantErr: true},
+		{name: "no separator", spec: "k1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseKeys(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseKeys(%q) error = %v, want error %v", tt.spec, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseKeys(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
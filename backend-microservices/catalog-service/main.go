@@ -4,9 +4,14 @@ import (
 	"catalog-service/data_layer/migration"
 	"catalog-service/data_layer/repository"
 	"catalog-service/domain_layer/service"
+	"catalog-service/helpers/cache"
 	"catalog-service/helpers/config"
+	"catalog-service/helpers/signing"
 	"catalog-service/presentation_layer/controller"
 	"catalog-service/presentation_layer/route"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"log"
 	"os"
 
@@ -42,36 +47,130 @@ func main() {
 	trackRepo := repository.NewTrackRepository(db)
 	userRepo := repository.NewUserRepository(db)
 	analyticsRepo := repository.NewAnalyticsRepository(db)
+	bookmarkRepo := repository.NewBookmarkRepository(db)
+	playSessionRepo := repository.NewPlaySessionRepository(db)
+	listeningLedgerRepo := repository.NewListeningLedgerRepository(db)
+	queueRepo := repository.NewQueueRepository(db)
+	feedTokenRepo := repository.NewFeedTokenRepository(db)
+	seriesRepo := repository.NewSeriesRepository(db)
+	searchIndexRepo := repository.NewSearchIndexRepository(db)
+	trashRepo := repository.NewTrashRepository(db)
+	replicationRepo := repository.NewReplicationRepository(db)
+	unitOfWork := repository.NewUnitOfWork(db)
 
 	// Initialize user management service for API validation
 	userManagementBaseURL := config.GetUserManagementBaseURL()
-	userManagementService := service.NewUserManagementService(userManagementBaseURL)
+	userManagementService := service.NewUserManagementService(userManagementBaseURL, config.GetUserManagementAPIKey())
 	log.Printf("User Management Service configured at: %s", userManagementBaseURL)
 
+	// Initialize stream URL signing
+	signingKeys, err := signing.ParseKeys(config.GetStreamSigningKeys())
+	if err != nil {
+		log.Fatalf("Invalid STREAM_SIGNING_KEYS: %v", err)
+	}
+	if len(signingKeys) == 0 {
+		// Links signed with an ephemeral key stop working when the service restarts
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatalf("Failed to generate stream signing key: %v", err)
+		}
+		signingKeys = []signing.Key{{ID: "ephemeral-" + hex.EncodeToString(secret[:4]), Secret: secret}}
+		log.Printf("Warning: STREAM_SIGNING_KEYS is not set, using an ephemeral signing key")
+	}
+	urlSigner, err := signing.NewURLSigner(signingKeys, config.GetStreamURLTTL())
+	if err != nil {
+		log.Fatalf("Failed to initialize stream URL signer: %v", err)
+	}
+	streamURLService := service.NewStreamURLService(urlSigner, config.GetPublicBaseURL())
+
 	// Initialize services
-	authorService := service.NewAuthorService(authorRepo)
-	readerService := service.NewReaderService(readerRepo)
-	genreService := service.NewGenreService(genreRepo)
+	authorService := service.NewAuthorService(authorRepo, searchIndexRepo)
+	readerService := service.NewReaderService(readerRepo, searchIndexRepo)
+	genreService := service.NewGenreService(genreRepo, searchIndexRepo)
+	seriesService := service.NewSeriesService(seriesRepo, audiobookRepo)
 	audiobookService := service.NewAudiobookService(
 		audiobookRepo,
 		authorRepo,
 		readerRepo,
-		genreRepo,
-		trackRepo,     // Add trackRepo
-		analyticsRepo, // Add analyticsRepo
+		config.GetSearchDefaultLanguage(),
+		unitOfWork,
+		streamURLService,
 	)
-	trackService := service.NewTrackService(trackRepo)
+	trackService := service.NewTrackService(trackRepo, unitOfWork, streamURLService)
 	userService := service.NewUserService(userRepo)
 	analyticsService := service.NewAnalyticsService(analyticsRepo)
+	bookmarkService := service.NewBookmarkService(bookmarkRepo, trackRepo, audiobookRepo)
+	playSessionService := service.NewPlaySessionService(
+		playSessionRepo,
+		trackRepo,
+		analyticsRepo,
+		listeningLedgerRepo,
+		unitOfWork,
+		config.GetPlaySessionHeartbeatInterval(),
+		config.GetPlaySessionStaleAfter(),
+	)
+
+	listeningStatsService := service.NewListeningStatsService(listeningLedgerRepo)
+	queueService := service.NewQueueService(queueRepo, trackRepo, audiobookRepo, unitOfWork)
+	exportService := service.NewExportService(audiobookRepo)
+	feedService := service.NewFeedService(audiobookRepo, feedTokenRepo, config.GetPublicBaseURL())
+	trashService := service.NewTrashService(trashRepo, audiobookRepo, trackRepo, searchIndexRepo, config.GetTrashRetention())
+	replicationService := service.NewReplicationService(replicationRepo, unitOfWork)
+
+	// Close sessions whose player stopped sending heartbeats
+	go playSessionService.RunSweeper(context.Background(), config.GetPlaySessionHeartbeatInterval())
+
+	// Permanently delete catalog entries that have been in the trash past the retention period
+	go trashService.RunPurger(context.Background(), config.GetTrashPurgeInterval())
+
+	// Initialize disk cache for streamed audio
+	streamCacheDir := config.GetStreamCacheDir()
+	streamCache, err := cache.NewDiskLRUCache(streamCacheDir, config.GetStreamCacheMaxBytes())
+	if err != nil {
+		log.Fatalf("Failed to initialize stream cache: %v", err)
+	}
+	log.Printf("Stream cache configured at: %s", streamCacheDir)
+	audioFetcher := service.NewHTTPAudioFetcher()
+	streamService := service.NewStreamService(trackRepo, audioFetcher, streamCache)
+
+	// Initialize disk cache for HLS segments
+	hlsCacheDir := config.GetHLSCacheDir()
+	hlsCache, err := cache.NewDiskLRUCache(hlsCacheDir, config.GetHLSCacheMaxBytes())
+	if err != nil {
+		log.Fatalf("Failed to initialize HLS cache: %v", err)
+	}
+	log.Printf("HLS cache configured at: %s", hlsCacheDir)
+	hlsService := service.NewHLSService(trackRepo, audiobookRepo, streamService, streamURLService, hlsCache, config.GetHLSSegmentDuration())
+
+	// Initialize disk cache for audiobook download bundles
+	downloadCacheDir := config.GetDownloadCacheDir()
+	downloadCache, err := cache.NewDiskLRUCache(downloadCacheDir, config.GetDownloadCacheMaxBytes())
+	if err != nil {
+		log.Fatalf("Failed to initialize download cache: %v", err)
+	}
+	log.Printf("Download cache configured at: %s", downloadCacheDir)
+	downloadService := service.NewDownloadService(audiobookService, trackRepo, analyticsRepo, streamService, audioFetcher, downloadCache)
 
 	// Initialize controllers
 	authorController := controller.NewAuthorController(authorService)
 	readerController := controller.NewReaderController(readerService)
 	genreController := controller.NewGenreController(genreService)
+	seriesController := controller.NewSeriesController(seriesService)
 	audiobookController := controller.NewAudiobookController(audiobookService)
 	trackController := controller.NewTrackController(trackService)
 	userController := controller.NewUserController(userService)
 	analyticsController := controller.NewAnalyticsController(analyticsService)
+	bookmarkController := controller.NewBookmarkController(bookmarkService)
+	playSessionController := controller.NewPlaySessionController(playSessionService)
+	listeningStatsController := controller.NewListeningStatsController(listeningStatsService)
+	queueController := controller.NewQueueController(queueService)
+	exportController := controller.NewExportController(exportService)
+	feedController := controller.NewFeedController(feedService)
+	trashController := controller.NewTrashController(trashService)
+	replicationController := controller.NewReplicationController(replicationService)
+	streamController := controller.NewStreamController(streamService)
+	hlsController := controller.NewHLSController(hlsService)
+	downloadController := controller.NewDownloadController(downloadService)
 
 	// Setup Gin router
 	if os.Getenv("GIN_MODE") == "release" {
@@ -83,8 +182,9 @@ func main() {
 	// Add CORS middleware
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		c.Header("Access-Control-Allow-Methods", "GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, Range, If-Range, If-Match, If-None-Match, If-Modified-Since")
+		c.Header("Access-Control-Expose-Headers", "Content-Range, Content-Length, Content-Disposition, Accept-Ranges, ETag, Last-Modified")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -95,7 +195,7 @@ func main() {
 	})
 
 	// Setup routes with user management service for middleware
-	route.SetupRoutes(router, authorController, readerController, genreController, audiobookController, trackController, userController, analyticsController, userManagementService)
+	route.SetupRoutes(router, authorController, readerController, genreController, audiobookController, trackController, streamController, hlsController, downloadController, userController, analyticsController, bookmarkController, playSessionController, listeningStatsController, queueController, exportController, feedController, seriesController, trashController, replicationController, streamURLService, feedService, config.GetReplicationAPIKey(), userManagementService)
 
 	// Get port from environment or use default
 	port := os.Getenv("SERVER_PORT")
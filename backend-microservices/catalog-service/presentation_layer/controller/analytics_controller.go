@@ -101,11 +101,12 @@ func (ac *AnalyticsController) GetAnalyticsByDateRange(c *gin.Context) {
 		limit = 10
 	}
 
-	analytics, err := ac.analyticsService.GetAnalyticsByDateRange(startDate, endDate, dto.PaginationRequest{
-		Page:  page,
-		Limit: limit,
-	})
+	analytics, err := ac.analyticsService.GetAnalyticsByDateRange(startDate, endDate, listRequest(c, page, limit))
 	if err != nil {
+		if isListRequestError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -125,13 +126,14 @@ func (ac *AnalyticsController) GetAnalyticsByUser(c *gin.Context) {
 		limit = 10
 	}
 
-	paginationReq := dto.PaginationRequest{
-		Page:  page,
-		Limit: limit,
-	}
+	listReq := listRequest(c, page, limit)
 
-	analytics, err := ac.analyticsService.GetAnalyticsByUser(c.Param("user_id"), paginationReq)
+	analytics, err := ac.analyticsService.GetAnalyticsByUser(c.Param("user_id"), listReq)
 	if err != nil {
+		if isListRequestError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -157,13 +159,14 @@ func (ac *AnalyticsController) GetAnalyticsByAudiobook(c *gin.Context) {
 		limit = 10
 	}
 
-	paginationReq := dto.PaginationRequest{
-		Page:  page,
-		Limit: limit,
-	}
+	listReq := listRequest(c, page, limit)
 
-	analytics, err := ac.analyticsService.GetAnalyticsByAudiobook(uint(audiobookID), paginationReq)
+	analytics, err := ac.analyticsService.GetAnalyticsByAudiobook(uint(audiobookID), listReq)
 	if err != nil {
+		if isListRequestError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -189,13 +192,14 @@ func (ac *AnalyticsController) GetAnalyticsByEventType(c *gin.Context) {
 		limit = 10
 	}
 
-	paginationReq := dto.PaginationRequest{
-		Page:  page,
-		Limit: limit,
-	}
+	listReq := listRequest(c, page, limit)
 
-	analytics, err := ac.analyticsService.GetAnalyticsByEventType(eventType, paginationReq)
+	analytics, err := ac.analyticsService.GetAnalyticsByEventType(eventType, listReq)
 	if err != nil {
+		if isListRequestError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -28,8 +28,12 @@ func (ac *AudiobookController) CreateAudiobook(c *gin.Context) {
 		return
 	}
 
-	audiobook, err := ac.audiobookService.CreateAudiobook(req)
+	audiobook, err := ac.audiobookService.CreateAudiobook(c.GetString("user_id"), req)
 	if err != nil {
+		if err.Error() == "invalid total_duration" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "author not found" || err.Error() == "reader not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -38,6 +42,7 @@ func (ac *AudiobookController) CreateAudiobook(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(audiobook.Version))
 	c.JSON(http.StatusCreated, audiobook)
 }
 
@@ -49,7 +54,7 @@ func (ac *AudiobookController) GetAudiobookByID(c *gin.Context) {
 		return
 	}
 
-	audiobook, err := ac.audiobookService.GetAudiobookByID(uint(id))
+	audiobook, err := ac.audiobookService.GetAudiobookByID(c.GetString("user_id"), uint(id))
 	if err != nil {
 		if err.Error() == "audiobook not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -59,16 +64,14 @@ func (ac *AudiobookController) GetAudiobookByID(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(audiobook.Version))
 	c.JSON(http.StatusOK, audiobook)
 }
 
-// GetAllAudiobooks retrieves all audiobooks with pagination and optional filtering
+// GetAllAudiobooks retrieves all audiobooks with pagination, composable filters and facet counts
 func (ac *AudiobookController) GetAllAudiobooks(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	authorID, _ := strconv.ParseUint(c.Query("author_id"), 10, 32)
-	readerID, _ := strconv.ParseUint(c.Query("reader_id"), 10, 32)
-	genreID, _ := strconv.ParseUint(c.Query("genre_id"), 10, 32)
 
 	if page < 1 {
 		page = 1
@@ -77,14 +80,18 @@ func (ac *AudiobookController) GetAllAudiobooks(c *gin.Context) {
 		limit = 10
 	}
 
-	filter := dto.AudiobookFilter{
-		AuthorID: uint(authorID),
-		ReaderID: uint(readerID),
-		GenreID:  uint(genreID),
+	filter, err := parseAudiobookFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	audiobooks, err := ac.audiobookService.GetAudiobooks(filter, page, limit)
+	audiobooks, err := ac.audiobookService.GetAudiobooks(filter, listRequest(c, page, limit))
 	if err != nil {
+		if isListRequestError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -92,7 +99,7 @@ func (ac *AudiobookController) GetAllAudiobooks(c *gin.Context) {
 	c.JSON(http.StatusOK, audiobooks)
 }
 
-// UpdateAudiobook updates an existing audiobook
+// UpdateAudiobook replaces every field of an existing audiobook
 func (ac *AudiobookController) UpdateAudiobook(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -100,14 +107,57 @@ func (ac *AudiobookController) UpdateAudiobook(c *gin.Context) {
 		return
 	}
 
+	version, status, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
 	var req dto.UpdateAudiobookRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	audiobook, err := ac.audiobookService.UpdateAudiobook(uint(id), req)
+	audiobook, err := ac.audiobookService.UpdateAudiobook(c.GetString("user_id"), uint(id), version, req)
+	ac.respondUpdated(c, uint(id), audiobook, err)
+}
+
+// PatchAudiobook updates the fields of an existing audiobook given in the request
+func (ac *AudiobookController) PatchAudiobook(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	version, status, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req dto.PatchAudiobookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	audiobook, err := ac.audiobookService.PatchAudiobook(c.GetString("user_id"), uint(id), version, req)
+	ac.respondUpdated(c, uint(id), audiobook, err)
+}
+
+// respondUpdated answers an audiobook update or patch
+func (ac *AudiobookController) respondUpdated(c *gin.Context, id uint, audiobook *dto.AudiobookResponse, err error) {
 	if err != nil {
+		if isVersionConflict(err) {
+			ac.preconditionFailed(c, id)
+			return
+		}
+		if err.Error() == "invalid total_duration" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "audiobook not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -120,6 +170,7 @@ func (ac *AudiobookController) UpdateAudiobook(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(audiobook.Version))
 	c.JSON(http.StatusOK, audiobook)
 }
 
@@ -131,7 +182,17 @@ func (ac *AudiobookController) DeleteAudiobook(c *gin.Context) {
 		return
 	}
 
-	if err := ac.audiobookService.DeleteAudiobook(uint(id)); err != nil {
+	version, status, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := ac.audiobookService.DeleteAudiobook(uint(id), version); err != nil {
+		if isVersionConflict(err) {
+			ac.preconditionFailed(c, uint(id))
+			return
+		}
 		if err.Error() == "audiobook not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -143,11 +204,15 @@ func (ac *AudiobookController) DeleteAudiobook(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Audiobook deleted successfully"})
 }
 
-// SearchAudiobooks searches audiobooks by title
+// SearchAudiobooks runs a ranked full-text search over the catalog
 func (ac *AudiobookController) SearchAudiobooks(c *gin.Context) {
-	title := c.Query("title")
-	if title == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Title parameter is required"})
+	query := c.Query("q")
+	if query == "" {
+		// Legacy clients search by title
+		query = c.Query("title")
+	}
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q parameter is required"})
 		return
 	}
 
@@ -161,8 +226,9 @@ func (ac *AudiobookController) SearchAudiobooks(c *gin.Context) {
 		limit = 10
 	}
 
-	searchReq := dto.SearchRequest{
-		Query: title,
+	searchReq := dto.AudiobookSearchRequest{
+		Query:    query,
+		Language: c.Query("language"),
 		PaginationRequest: dto.PaginationRequest{
 			Page:  page,
 			Limit: limit,
@@ -186,6 +252,12 @@ func (ac *AudiobookController) AddGenresToAudiobook(c *gin.Context) {
 		return
 	}
 
+	version, status, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
 	var req struct {
 		GenreIDs []uint `json:"genre_ids" binding:"required"`
 	}
@@ -195,7 +267,12 @@ func (ac *AudiobookController) AddGenresToAudiobook(c *gin.Context) {
 		return
 	}
 
-	if err := ac.audiobookService.AddGenresToAudiobook(uint(audiobookID), req.GenreIDs); err != nil {
+	newVersion, err := ac.audiobookService.AddGenresToAudiobook(uint(audiobookID), version, req.GenreIDs)
+	if err != nil {
+		if isVersionConflict(err) {
+			ac.preconditionFailed(c, uint(audiobookID))
+			return
+		}
 		if err.Error() == "audiobook not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -204,9 +281,51 @@ func (ac *AudiobookController) AddGenresToAudiobook(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(newVersion))
 	c.JSON(http.StatusOK, gin.H{"message": "Genres added successfully"})
 }
 
+// SetAudiobookCredits replaces the author and reader credits of an audiobook
+func (ac *AudiobookController) SetAudiobookCredits(c *gin.Context) {
+	audiobookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	version, status, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req dto.SetCreditsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	audiobook, err := ac.audiobookService.SetCredits(c.GetString("user_id"), uint(audiobookID), version, req)
+	if err != nil {
+		if isVersionConflict(err) {
+			ac.preconditionFailed(c, uint(audiobookID))
+			return
+		}
+		switch err.Error() {
+		case "audiobook not found", "author not found", "reader not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "duplicate credit", "at least one author credit is required":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Header("ETag", etag(audiobook.Version))
+	c.JSON(http.StatusOK, audiobook)
+}
+
 // RemoveGenresFromAudiobook removes genres from an audiobook
 func (ac *AudiobookController) RemoveGenresFromAudiobook(c *gin.Context) {
 	audiobookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -215,6 +334,12 @@ func (ac *AudiobookController) RemoveGenresFromAudiobook(c *gin.Context) {
 		return
 	}
 
+	version, status, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
 	var req struct {
 		GenreIDs []uint `json:"genre_ids" binding:"required"`
 	}
@@ -224,7 +349,12 @@ func (ac *AudiobookController) RemoveGenresFromAudiobook(c *gin.Context) {
 		return
 	}
 
-	if err := ac.audiobookService.RemoveGenresFromAudiobook(uint(audiobookID), req.GenreIDs); err != nil {
+	newVersion, err := ac.audiobookService.RemoveGenresFromAudiobook(uint(audiobookID), version, req.GenreIDs)
+	if err != nil {
+		if isVersionConflict(err) {
+			ac.preconditionFailed(c, uint(audiobookID))
+			return
+		}
 		if err.Error() == "audiobook not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -233,5 +363,22 @@ func (ac *AudiobookController) RemoveGenresFromAudiobook(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(newVersion))
 	c.JSON(http.StatusOK, gin.H{"message": "Genres removed successfully"})
 }
+
+// preconditionFailed answers a write with a stale If-Match version with the current audiobook
+func (ac *AudiobookController) preconditionFailed(c *gin.Context, id uint) {
+	audiobook, err := ac.audiobookService.GetAudiobookByID(c.GetString("user_id"), id)
+	if err != nil {
+		if err.Error() == "audiobook not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("ETag", etag(audiobook.Version))
+	c.JSON(http.StatusPreconditionFailed, audiobook)
+}
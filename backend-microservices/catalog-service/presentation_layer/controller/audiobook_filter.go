@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"catalog-service/data_layer/dto"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseAudiobookFilter reads the filter query parameters of the audiobook list. Genres are given
+// as genre_ids=1,2,3, as repeated genre_id parameters or both; genre_match=all requires every
+// genre instead of any of them.
+func parseAudiobookFilter(c *gin.Context) (dto.AudiobookFilter, error) {
+	var filter dto.AudiobookFilter
+
+	authorID, err := parseOptionalID(c.Query("author_id"))
+	if err != nil {
+		return filter, errors.New("Invalid author_id")
+	}
+	filter.AuthorID = authorID
+
+	readerID, err := parseOptionalID(c.Query("reader_id"))
+	if err != nil {
+		return filter, errors.New("Invalid reader_id")
+	}
+	filter.ReaderID = readerID
+
+	genreValues := c.QueryArray("genre_id")
+	if genreIDs := c.Query("genre_ids"); genreIDs != "" {
+		genreValues = append(genreValues, strings.Split(genreIDs, ",")...)
+	}
+	for _, value := range genreValues {
+		genreID, err := parseOptionalID(strings.TrimSpace(value))
+		if err != nil || genreID == 0 {
+			return filter, errors.New("Invalid genre_ids")
+		}
+		filter.GenreIDs = append(filter.GenreIDs, genreID)
+	}
+
+	switch c.Query("genre_match") {
+	case "", "any":
+		filter.GenreMatch = "any"
+	case "all":
+		filter.GenreMatch = "all"
+	default:
+		return filter, errors.New("Invalid genre_match. Use any or all")
+	}
+
+	filter.Language = strings.TrimSpace(c.Query("language"))
+
+	if filter.YearFrom, err = parseOptionalInt(c.Query("year_from")); err != nil {
+		return filter, errors.New("Invalid year_from")
+	}
+	if filter.YearTo, err = parseOptionalInt(c.Query("year_to")); err != nil {
+		return filter, errors.New("Invalid year_to")
+	}
+	if filter.YearFrom > 0 && filter.YearTo > 0 && filter.YearFrom > filter.YearTo {
+		return filter, errors.New("year_from must not be after year_to")
+	}
+
+	if value := c.Query("has_tracks"); value != "" {
+		hasTracks, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, errors.New("Invalid has_tracks")
+		}
+		filter.HasTracks = &hasTracks
+	}
+
+	if filter.Duration, err = parseDurationFilter(c); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// parseOptionalID parses an ID query parameter, returning zero when it is empty
+func parseOptionalID(value string) (uint, error) {
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	return uint(id), err
+}
+
+// parseOptionalInt parses a non-negative integer query parameter, returning zero when it is empty
+func parseOptionalInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err == nil && n < 0 {
+		return 0, errors.New("negative value")
+	}
+	return n, err
+}
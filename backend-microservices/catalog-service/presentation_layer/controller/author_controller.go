@@ -51,6 +51,7 @@ func (c *AuthorController) CreateAuthor(ctx *gin.Context) {
 		return
 	}
 
+	ctx.Header("ETag", etag(author.Version))
 	ctx.JSON(http.StatusCreated, dto.APIResponse{
 		Success: true,
 		Message: "Author created successfully",
@@ -94,6 +95,7 @@ func (c *AuthorController) GetAuthor(ctx *gin.Context) {
 		return
 	}
 
+	ctx.Header("ETag", etag(author.Version))
 	ctx.JSON(http.StatusOK, dto.APIResponse{
 		Success: true,
 		Message: "Author retrieved successfully",
@@ -113,7 +115,7 @@ func (c *AuthorController) GetAuthor(ctx *gin.Context) {
 // @Failure 500 {object} dto.APIResponse
 // @Router /authors [get]
 func (c *AuthorController) GetAllAuthors(ctx *gin.Context) {
-	var req dto.PaginationRequest
+	var req dto.ListRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, dto.APIResponse{
 			Success: false,
@@ -125,6 +127,14 @@ func (c *AuthorController) GetAllAuthors(ctx *gin.Context) {
 
 	result, err := c.authorService.GetAllAuthors(req)
 	if err != nil {
+		if isListRequestError(err) {
+			ctx.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid query parameters",
+				Error:   err.Error(),
+			})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, dto.APIResponse{
 			Success: false,
 			Message: "Failed to get authors",
@@ -147,10 +157,13 @@ func (c *AuthorController) GetAllAuthors(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Author ID"
+// @Param If-Match header string true "ETag of the author being updated"
 // @Param request body dto.UpdateAuthorRequest true "Author data"
 // @Success 200 {object} dto.APIResponse
 // @Failure 400 {object} dto.APIResponse
 // @Failure 404 {object} dto.APIResponse
+// @Failure 412 {object} dto.APIResponse
+// @Failure 428 {object} dto.APIResponse
 // @Failure 500 {object} dto.APIResponse
 // @Router /authors/{id} [put]
 func (c *AuthorController) UpdateAuthor(ctx *gin.Context) {
@@ -165,6 +178,16 @@ func (c *AuthorController) UpdateAuthor(ctx *gin.Context) {
 		return
 	}
 
+	version, status, err := ifMatchVersion(ctx)
+	if err != nil {
+		ctx.JSON(status, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request headers",
+			Error:   err.Error(),
+		})
+		return
+	}
+
 	var req dto.UpdateAuthorRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, dto.APIResponse{
@@ -175,8 +198,12 @@ func (c *AuthorController) UpdateAuthor(ctx *gin.Context) {
 		return
 	}
 
-	author, err := c.authorService.UpdateAuthor(uint(id), req)
+	author, err := c.authorService.UpdateAuthor(uint(id), version, req)
 	if err != nil {
+		if isVersionConflict(err) {
+			c.preconditionFailed(ctx, uint(id))
+			return
+		}
 		statusCode := http.StatusInternalServerError
 		if err.Error() == "author not found" {
 			statusCode = http.StatusNotFound
@@ -189,6 +216,7 @@ func (c *AuthorController) UpdateAuthor(ctx *gin.Context) {
 		return
 	}
 
+	ctx.Header("ETag", etag(author.Version))
 	ctx.JSON(http.StatusOK, dto.APIResponse{
 		Success: true,
 		Message: "Author updated successfully",
@@ -202,9 +230,13 @@ func (c *AuthorController) UpdateAuthor(ctx *gin.Context) {
 // @Tags authors
 // @Produce json
 // @Param id path int true "Author ID"
+// @Param If-Match header string true "ETag of the author being deleted"
 // @Success 200 {object} dto.APIResponse
 // @Failure 400 {object} dto.APIResponse
 // @Failure 404 {object} dto.APIResponse
+// @Failure 409 {object} dto.APIResponse
+// @Failure 412 {object} dto.APIResponse
+// @Failure 428 {object} dto.APIResponse
 // @Failure 500 {object} dto.APIResponse
 // @Router /authors/{id} [delete]
 func (c *AuthorController) DeleteAuthor(ctx *gin.Context) {
@@ -219,12 +251,29 @@ func (c *AuthorController) DeleteAuthor(ctx *gin.Context) {
 		return
 	}
 
-	err = c.authorService.DeleteAuthor(uint(id))
+	version, status, err := ifMatchVersion(ctx)
 	if err != nil {
+		ctx.JSON(status, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request headers",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	err = c.authorService.DeleteAuthor(uint(id), version)
+	if err != nil {
+		if isVersionConflict(err) {
+			c.preconditionFailed(ctx, uint(id))
+			return
+		}
 		statusCode := http.StatusInternalServerError
 		if err.Error() == "author not found" {
 			statusCode = http.StatusNotFound
 		}
+		if err.Error() == "cannot delete author with audiobooks" {
+			statusCode = http.StatusConflict
+		}
 		ctx.JSON(statusCode, dto.APIResponse{
 			Success: false,
 			Message: "Failed to delete author",
@@ -278,3 +327,28 @@ func (c *AuthorController) SearchAuthors(ctx *gin.Context) {
 		Data:    result,
 	})
 }
+
+// preconditionFailed answers a write with a stale If-Match version with the current author
+func (c *AuthorController) preconditionFailed(ctx *gin.Context, id uint) {
+	author, err := c.authorService.GetAuthorByID(id)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "author not found" {
+			statusCode = http.StatusNotFound
+		}
+		ctx.JSON(statusCode, dto.APIResponse{
+			Success: false,
+			Message: "Failed to get author",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx.Header("ETag", etag(author.Version))
+	ctx.JSON(http.StatusPreconditionFailed, dto.APIResponse{
+		Success: false,
+		Message: "Author was changed since it was read",
+		Error:   "version conflict",
+		Data:    author,
+	})
+}
@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/domain_layer/service"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookmarkController struct {
+	bookmarkService *service.BookmarkService
+}
+
+func NewBookmarkController(bookmarkService *service.BookmarkService) *BookmarkController {
+	return &BookmarkController{
+		bookmarkService: bookmarkService,
+	}
+}
+
+// CreateBookmark creates a new bookmark for the current user
+func (bc *BookmarkController) CreateBookmark(c *gin.Context) {
+	var req dto.CreateBookmarkRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bookmark, err := bc.bookmarkService.CreateBookmark(c.GetString("user_id"), req)
+	if err != nil {
+		bc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, bookmark)
+}
+
+// GetBookmarkByID retrieves a bookmark of the current user by ID
+func (bc *BookmarkController) GetBookmarkByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bookmark ID"})
+		return
+	}
+
+	bookmark, err := bc.bookmarkService.GetBookmarkByID(c.GetString("user_id"), uint(id))
+	if err != nil {
+		bc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bookmark)
+}
+
+// UpdateBookmark updates a bookmark of the current user
+func (bc *BookmarkController) UpdateBookmark(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bookmark ID"})
+		return
+	}
+
+	var req dto.UpdateBookmarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bookmark, err := bc.bookmarkService.UpdateBookmark(c.GetString("user_id"), uint(id), req)
+	if err != nil {
+		bc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bookmark)
+}
+
+// DeleteBookmark deletes a bookmark of the current user
+func (bc *BookmarkController) DeleteBookmark(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bookmark ID"})
+		return
+	}
+
+	if err := bc.bookmarkService.DeleteBookmark(c.GetString("user_id"), uint(id)); err != nil {
+		bc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bookmark deleted successfully"})
+}
+
+// GetBookmarksByTrack retrieves the bookmarks of the current user in a track
+func (bc *BookmarkController) GetBookmarksByTrack(c *gin.Context) {
+	trackID, err := strconv.ParseUint(c.Param("track_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid track ID"})
+		return
+	}
+
+	bookmarks, err := bc.bookmarkService.GetBookmarksByTrack(c.GetString("user_id"), uint(trackID))
+	if err != nil {
+		bc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bookmarks)
+}
+
+// GetBookmarksByAudiobook retrieves the bookmarks of the current user in an audiobook in track order
+func (bc *BookmarkController) GetBookmarksByAudiobook(c *gin.Context) {
+	audiobookID, err := strconv.ParseUint(c.Param("audiobook_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+
+	req := dto.PaginationRequest{
+		Page:  page,
+		Limit: limit,
+	}
+
+	bookmarks, err := bc.bookmarkService.GetBookmarksByAudiobook(c.GetString("user_id"), uint(audiobookID), req)
+	if err != nil {
+		bc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bookmarks)
+}
+
+// ExportBookmarks exports every note of the current user for an audiobook as Markdown or JSON
+func (bc *BookmarkController) ExportBookmarks(c *gin.Context) {
+	audiobookID, err := strconv.ParseUint(c.Param("audiobook_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	userID := c.GetString("user_id")
+	fileName := fmt.Sprintf("audiobook-%d-notes", audiobookID)
+
+	switch c.DefaultQuery("format", "markdown") {
+	case "markdown", "md":
+		markdown, err := bc.bookmarkService.ExportBookmarksMarkdown(userID, uint(audiobookID))
+		if err != nil {
+			bc.handleError(c, err)
+			return
+		}
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName + ".md"}))
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(markdown))
+	case "json":
+		export, err := bc.bookmarkService.ExportBookmarks(userID, uint(audiobookID))
+		if err != nil {
+			bc.handleError(c, err)
+			return
+		}
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName + ".json"}))
+		c.JSON(http.StatusOK, export)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be one of: markdown, json"})
+	}
+}
+
+func (bc *BookmarkController) handleError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "bookmark not found", "track not found", "audiobook not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "bookmark already exists at this offset":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
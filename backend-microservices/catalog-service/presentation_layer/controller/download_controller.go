@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"catalog-service/domain_layer/service"
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DownloadController struct {
+	downloadService *service.DownloadService
+}
+
+func NewDownloadController(downloadService *service.DownloadService) *DownloadController {
+	return &DownloadController{
+		downloadService: downloadService,
+	}
+}
+
+// DownloadAudiobook serves the ZIP bundle of an audiobook with HTTP Range support for resumable downloads
+func (dc *DownloadController) DownloadAudiobook(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	bundle, err := dc.downloadService.OpenBundle(c.Request.Context(), uint(id))
+	if err != nil {
+		switch {
+		case err.Error() == "audiobook not found" || err.Error() == "audiobook has no tracks":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrUpstreamUnavailable):
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	defer bundle.File.Close()
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": bundle.Name}))
+	c.Header("ETag", bundle.ETag)
+	c.Header("Cache-Control", "private, no-cache")
+
+	// ServeContent handles Range/If-Range (206) and If-None-Match/If-Modified-Since (304)
+	http.ServeContent(c.Writer, c.Request, bundle.Name, bundle.ModTime, bundle.File)
+
+	// Only a download that got a body is counted, from its first byte: not a 304 or a HEAD, nor
+	// the range requests resuming it
+	if isDownloadStart(c.Writer.Status(), c.GetHeader("Range")) && c.Writer.Size() > 0 {
+		if err := dc.downloadService.RecordDownload(c.GetString("user_id"), uint(id)); err != nil {
+			log.Printf("Failed to record download of audiobook %d: %v", id, err)
+		}
+	}
+}
+
+// isDownloadStart reports whether a response sent the bundle from its first byte: the whole of it,
+// or a range starting there
+func isDownloadStart(status int, rangeHeader string) bool {
+	switch status {
+	case http.StatusOK:
+		return true
+	case http.StatusPartialContent:
+		return strings.HasPrefix(strings.ReplaceAll(rangeHeader, " ", ""), "bytes=0-")
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/helpers/duration"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseDurationFilter reads the min_duration and max_duration query parameters of a list.
+// Durations accept any format the catalog stores, e.g. "3h", "10 hr 23 min" or "01:30:00".
+func parseDurationFilter(c *gin.Context) (dto.DurationFilter, error) {
+	var filter dto.DurationFilter
+	var err error
+
+	if filter.MinSeconds, err = duration.Parse(c.Query("min_duration")); err != nil {
+		return filter, errors.New("Invalid min_duration")
+	}
+	if filter.MaxSeconds, err = duration.Parse(c.Query("max_duration")); err != nil {
+		return filter, errors.New("Invalid max_duration")
+	}
+
+	return filter, nil
+}
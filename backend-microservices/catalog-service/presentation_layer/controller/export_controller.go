@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/domain_layer/service"
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExportController struct {
+	exportService *service.ExportService
+}
+
+func NewExportController(exportService *service.ExportService) *ExportController {
+	return &ExportController{
+		exportService: exportService,
+	}
+}
+
+// ExportAudiobooks streams the audiobooks matching the list filters as JSON, NDJSON or CSV
+func (ec *ExportController) ExportAudiobooks(c *gin.Context) {
+	var req dto.ExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req, err := service.NormalizeExportRequest(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter, err := parseAudiobookFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", service.ExportContentType(req.Format))
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": service.ExportFileName(req)}))
+	c.Status(http.StatusOK)
+
+	// The status line has gone out with the first batch, so a failure can only cut the export short
+	if err := ec.exportService.Export(c.Writer, req, filter); err != nil {
+		log.Printf("Catalog export failed: %v", err)
+		c.Abort()
+	}
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/domain_layer/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedController struct {
+	feedService *service.FeedService
+}
+
+func NewFeedController(feedService *service.FeedService) *FeedController {
+	return &FeedController{
+		feedService: feedService,
+	}
+}
+
+// GetAudiobookFeed renders the podcast RSS feed of an audiobook, private when a feed token is given
+func (fc *FeedController) GetAudiobookFeed(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	token := c.Query("token")
+	feed, err := fc.feedService.RenderFeed(uint(id), token)
+	if err != nil {
+		switch {
+		case err.Error() == "audiobook not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrInvalidFeedToken):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if token != "" {
+		c.Header("Cache-Control", "private, max-age=300")
+	} else {
+		c.Header("Cache-Control", "public, max-age=300")
+	}
+	c.Data(http.StatusOK, "application/rss+xml; charset=utf-8", feed)
+}
+
+// CreateFeedToken creates a private feed token for the current user
+func (fc *FeedController) CreateFeedToken(c *gin.Context) {
+	var req dto.CreateFeedTokenRequest
+
+	// The body is optional
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	token, err := fc.feedService.CreateToken(c.GetString("user_id"), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
+
+// GetFeedTokens lists the feed tokens of the current user
+func (fc *FeedController) GetFeedTokens(c *gin.Context) {
+	tokens, err := fc.feedService.GetTokens(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// RevokeFeedToken revokes a feed token of the current user
+func (fc *FeedController) RevokeFeedToken(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed token ID"})
+		return
+	}
+
+	if err := fc.feedService.RevokeToken(c.GetString("user_id"), uint(id)); err != nil {
+		if err.Error() == "feed token not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Feed token revoked successfully"})
+}
@@ -38,6 +38,7 @@ func (gc *GenreController) CreateGenre(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(genre.Version))
 	c.JSON(http.StatusCreated, genre)
 }
 
@@ -59,6 +60,7 @@ func (gc *GenreController) GetGenreByID(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(genre.Version))
 	c.JSON(http.StatusOK, genre)
 }
 
@@ -74,13 +76,14 @@ func (gc *GenreController) GetAllGenres(c *gin.Context) {
 		limit = 10
 	}
 
-	paginationReq := dto.PaginationRequest{
-		Page:  page,
-		Limit: limit,
-	}
+	listReq := listRequest(c, page, limit)
 
-	genres, err := gc.genreService.GetAllGenres(paginationReq)
+	genres, err := gc.genreService.GetAllGenres(listReq)
 	if err != nil {
+		if isListRequestError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -96,14 +99,24 @@ func (gc *GenreController) UpdateGenre(c *gin.Context) {
 		return
 	}
 
+	version, status, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
 	var req dto.UpdateGenreRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	genre, err := gc.genreService.UpdateGenre(uint(id), req)
+	genre, err := gc.genreService.UpdateGenre(uint(id), version, req)
 	if err != nil {
+		if isVersionConflict(err) {
+			gc.preconditionFailed(c, uint(id))
+			return
+		}
 		if err.Error() == "genre not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -116,6 +129,7 @@ func (gc *GenreController) UpdateGenre(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(genre.Version))
 	c.JSON(http.StatusOK, genre)
 }
 
@@ -127,7 +141,17 @@ func (gc *GenreController) DeleteGenre(c *gin.Context) {
 		return
 	}
 
-	if err := gc.genreService.DeleteGenre(uint(id)); err != nil {
+	version, status, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := gc.genreService.DeleteGenre(uint(id), version); err != nil {
+		if isVersionConflict(err) {
+			gc.preconditionFailed(c, uint(id))
+			return
+		}
 		if err.Error() == "genre not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -162,3 +186,19 @@ func (gc *GenreController) GetGenresByIDs(c *gin.Context) {
 
 	c.JSON(http.StatusOK, genres)
 }
+
+// preconditionFailed answers a write with a stale If-Match version with the current genre
+func (gc *GenreController) preconditionFailed(c *gin.Context, id uint) {
+	genre, err := gc.genreService.GetGenreByID(id)
+	if err != nil {
+		if err.Error() == "genre not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("ETag", etag(genre.Version))
+	c.JSON(http.StatusPreconditionFailed, genre)
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"catalog-service/domain_layer/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const playlistContentType = "application/vnd.apple.mpegurl"
+
+// hlsRetryAfterSeconds is how long clients wait before asking again for a playlist being prepared
+const hlsRetryAfterSeconds = 5
+
+type HLSController struct {
+	hlsService *service.HLSService
+}
+
+func NewHLSController(hlsService *service.HLSService) *HLSController {
+	return &HLSController{
+		hlsService: hlsService,
+	}
+}
+
+// GetTrackFile serves the media playlist (index.m3u8) or a media segment of a track
+func (hc *HLSController) GetTrackFile(c *gin.Context) {
+	trackID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid track ID"})
+		return
+	}
+
+	file := c.Param("file")
+	if file == "index.m3u8" {
+		playlist, err := hc.hlsService.GetTrackPlaylist(c.Request.Context(), c.GetString("user_id"), uint(trackID))
+		if err != nil {
+			hc.handleError(c, err)
+			return
+		}
+		c.Header("Cache-Control", "private, no-store")
+		c.Data(http.StatusOK, playlistContentType, playlist)
+		return
+	}
+
+	segment, err := hc.hlsService.OpenTrackSegment(c.Request.Context(), uint(trackID), file)
+	if err != nil {
+		hc.handleError(c, err)
+		return
+	}
+	defer segment.File.Close()
+
+	c.Header("Content-Type", segment.ContentType)
+	c.Header("Cache-Control", "private, max-age=86400")
+	http.ServeContent(c.Writer, c.Request, segment.Name, segment.ModTime, segment.File)
+}
+
+// GetAudiobookFile serves the master playlist (master.m3u8) or the media playlist (index.m3u8) of an audiobook
+func (hc *HLSController) GetAudiobookFile(c *gin.Context) {
+	audiobookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	var playlist []byte
+	switch c.Param("file") {
+	case "master.m3u8":
+		playlist, err = hc.hlsService.GetAudiobookMasterPlaylist(c.Request.Context(), uint(audiobookID))
+	case "index.m3u8":
+		playlist, err = hc.hlsService.GetAudiobookPlaylist(c.Request.Context(), c.GetString("user_id"), uint(audiobookID))
+	default:
+		c.JSON(http.StatusNotFound, gin.H{"error": "playlist not found"})
+		return
+	}
+	if err != nil {
+		hc.handleError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, playlistContentType, playlist)
+}
+
+func (hc *HLSController) handleError(c *gin.Context, err error) {
+	switch {
+	case err.Error() == "playlist is being prepared":
+		// Segmenting runs in the background; the client polls until the playlist is ready
+		c.Header("Retry-After", strconv.Itoa(hlsRetryAfterSeconds))
+		c.JSON(http.StatusAccepted, gin.H{"message": err.Error()})
+	case err.Error() == "track not found" || err.Error() == "audiobook not found" || err.Error() == "segment not found" || err.Error() == "audiobook has no tracks":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err.Error() == "track audio is not a valid MP3":
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrUpstreamUnavailable):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
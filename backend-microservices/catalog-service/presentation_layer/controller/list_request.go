@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/data_layer/repository"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// listRequest combines page and limit with the sort and cursor query parameters of a list
+func listRequest(c *gin.Context, page, limit int) dto.ListRequest {
+	return dto.ListRequest{
+		PaginationRequest: dto.PaginationRequest{
+			Page:  page,
+			Limit: limit,
+		},
+		Sort:   c.Query("sort"),
+		Cursor: c.Query("cursor"),
+	}
+}
+
+// isListRequestError reports whether a list failed because of its sort or cursor parameter
+func isListRequestError(err error) bool {
+	return errors.Is(err, repository.ErrInvalidSort) || errors.Is(err, repository.ErrInvalidCursor)
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"catalog-service/domain_layer/service"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListeningStatsController struct {
+	listeningStatsService *service.ListeningStatsService
+}
+
+func NewListeningStatsController(listeningStatsService *service.ListeningStatsService) *ListeningStatsController {
+	return &ListeningStatsController{
+		listeningStatsService: listeningStatsService,
+	}
+}
+
+// GetDailyTotals retrieves the listening time of the current user per day
+func (lc *ListeningStatsController) GetDailyTotals(c *gin.Context) {
+	lc.getTotals(c, service.ListeningPeriodDay)
+}
+
+// GetWeeklyTotals retrieves the listening time of the current user per week
+func (lc *ListeningStatsController) GetWeeklyTotals(c *gin.Context) {
+	lc.getTotals(c, service.ListeningPeriodWeek)
+}
+
+// GetMonthlyTotals retrieves the listening time of the current user per month
+func (lc *ListeningStatsController) GetMonthlyTotals(c *gin.Context) {
+	lc.getTotals(c, service.ListeningPeriodMonth)
+}
+
+// GetStreak retrieves the consecutive listening days of the current user
+func (lc *ListeningStatsController) GetStreak(c *gin.Context) {
+	streak, err := lc.listeningStatsService.GetStreak(c.GetString("user_id"))
+	if err != nil {
+		lc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, streak)
+}
+
+// GetTopGenres retrieves the genres the current user listened to most
+func (lc *ListeningStatsController) GetTopGenres(c *gin.Context) {
+	from, to, ok := lc.parseRange(c)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	if limit < 1 || limit > 50 {
+		limit = 5
+	}
+
+	genres, err := lc.listeningStatsService.GetTopGenres(c.GetString("user_id"), from, to, limit)
+	if err != nil {
+		lc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, genres)
+}
+
+func (lc *ListeningStatsController) getTotals(c *gin.Context, period string) {
+	from, to, ok := lc.parseRange(c)
+	if !ok {
+		return
+	}
+
+	totals, err := lc.listeningStatsService.GetTotals(c.GetString("user_id"), period, from, to)
+	if err != nil {
+		lc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, totals)
+}
+
+// parseRange reads the optional from and to days; missing values are left zero for the service to default
+func (lc *ListeningStatsController) parseRange(c *gin.Context) (time.Time, time.Time, bool) {
+	var from, to time.Time
+	var err error
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err = time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from format. Use YYYY-MM-DD"})
+			return from, to, false
+		}
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		to, err = time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to format. Use YYYY-MM-DD"})
+			return from, to, false
+		}
+	}
+
+	return from, to, true
+}
+
+func (lc *ListeningStatsController) handleError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "invalid listening period", "from must not be after to", "range must not exceed 366 days":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/domain_layer/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PlaySessionController struct {
+	playSessionService *service.PlaySessionService
+}
+
+func NewPlaySessionController(playSessionService *service.PlaySessionService) *PlaySessionController {
+	return &PlaySessionController{
+		playSessionService: playSessionService,
+	}
+}
+
+// StartSession starts a playback session for the current user
+func (pc *PlaySessionController) StartSession(c *gin.Context) {
+	var req dto.StartPlaySessionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := pc.playSessionService.StartSession(c.GetString("user_id"), req)
+	if err != nil {
+		pc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// GetSessionByID retrieves a playback session of the current user
+func (pc *PlaySessionController) GetSessionByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	session, err := pc.playSessionService.GetSessionByID(c.GetString("user_id"), uint(id))
+	if err != nil {
+		pc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// Heartbeat reports the playback position of an active session
+func (pc *PlaySessionController) Heartbeat(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	var req dto.PlaySessionHeartbeatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := pc.playSessionService.Heartbeat(c.GetString("user_id"), uint(id), req)
+	if err != nil {
+		pc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// StopSession reports the final position of a session and closes it
+func (pc *PlaySessionController) StopSession(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	var req dto.PlaySessionHeartbeatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := pc.playSessionService.StopSession(c.GetString("user_id"), uint(id), req)
+	if err != nil {
+		pc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+func (pc *PlaySessionController) handleError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "play session not found", "track not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "track does not belong to audiobook":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case "play session is not active":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
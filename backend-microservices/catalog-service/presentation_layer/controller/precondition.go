@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"catalog-service/data_layer/repository"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etag is the entity tag of a catalog entity at a version
+func etag(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// ifMatchVersion reads the version a write expects from the If-Match header, along with the status
+// to answer with when it is missing or malformed. "*" matches any version and is read as 0.
+func ifMatchVersion(c *gin.Context) (uint, int, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		return 0, http.StatusPreconditionRequired, errors.New("If-Match header is required")
+	}
+	if header == "*" {
+		return 0, 0, nil
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, http.StatusBadRequest, errors.New("Invalid If-Match header")
+	}
+	version, err := strconv.ParseUint(tag[1:len(tag)-1], 10, 32)
+	if err != nil || version == 0 {
+		return 0, http.StatusBadRequest, errors.New("Invalid If-Match header")
+	}
+
+	return uint(version), 0, nil
+}
+
+// isVersionConflict reports whether a write failed because the entity changed since the If-Match version
+func isVersionConflict(err error) bool {
+	return errors.Is(err, repository.ErrVersionConflict)
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/domain_layer/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QueueController struct {
+	queueService *service.QueueService
+}
+
+func NewQueueController(queueService *service.QueueService) *QueueController {
+	return &QueueController{
+		queueService: queueService,
+	}
+}
+
+// GetQueue retrieves the play queue of the current user
+func (qc *QueueController) GetQueue(c *gin.Context) {
+	queue, err := qc.queueService.GetQueue(c.GetString("user_id"))
+	if err != nil {
+		qc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// Enqueue adds a track or a whole audiobook to the play queue of the current user
+func (qc *QueueController) Enqueue(c *gin.Context) {
+	var req dto.EnqueueRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	queue, err := qc.queueService.Enqueue(c.GetString("user_id"), req)
+	if err != nil {
+		qc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, queue)
+}
+
+// ReorderQueue changes the order of the play queue of the current user
+func (qc *QueueController) ReorderQueue(c *gin.Context) {
+	var req dto.ReorderQueueRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	queue, err := qc.queueService.ReorderQueue(c.GetString("user_id"), req)
+	if err != nil {
+		qc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// RemoveEntry removes one entry from the play queue of the current user
+func (qc *QueueController) RemoveEntry(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid queue entry ID"})
+		return
+	}
+
+	queue, err := qc.queueService.RemoveEntry(c.GetString("user_id"), uint(id))
+	if err != nil {
+		qc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// ClearQueue removes every entry from the play queue of the current user
+func (qc *QueueController) ClearQueue(c *gin.Context) {
+	if err := qc.queueService.ClearQueue(c.GetString("user_id")); err != nil {
+		qc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Queue cleared successfully"})
+}
+
+// AdvanceQueue takes the next track off the play queue of the current user
+func (qc *QueueController) AdvanceQueue(c *gin.Context) {
+	result, err := qc.queueService.AdvanceQueue(c.GetString("user_id"))
+	if err != nil {
+		qc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (qc *QueueController) handleError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "queue entry not found", "track not found", "audiobook not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "either track_id or audiobook_id is required", "entry_ids must list every queue entry exactly once", "audiobook has no tracks":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case "play queue is full":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
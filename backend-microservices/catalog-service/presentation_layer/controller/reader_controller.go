@@ -34,6 +34,7 @@ func (rc *ReaderController) CreateReader(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(reader.Version))
 	c.JSON(http.StatusCreated, reader)
 }
 
@@ -55,6 +56,7 @@ func (rc *ReaderController) GetReaderByID(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(reader.Version))
 	c.JSON(http.StatusOK, reader)
 }
 
@@ -70,13 +72,14 @@ func (rc *ReaderController) GetAllReaders(c *gin.Context) {
 		limit = 10
 	}
 
-	paginationReq := dto.PaginationRequest{
-		Page:  page,
-		Limit: limit,
-	}
+	listReq := listRequest(c, page, limit)
 
-	readers, err := rc.readerService.GetAllReaders(paginationReq)
+	readers, err := rc.readerService.GetAllReaders(listReq)
 	if err != nil {
+		if isListRequestError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -92,14 +95,24 @@ func (rc *ReaderController) UpdateReader(c *gin.Context) {
 		return
 	}
 
+	version, status, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
 	var req dto.UpdateReaderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	reader, err := rc.readerService.UpdateReader(uint(id), req)
+	reader, err := rc.readerService.UpdateReader(uint(id), version, req)
 	if err != nil {
+		if isVersionConflict(err) {
+			rc.preconditionFailed(c, uint(id))
+			return
+		}
 		if err.Error() == "reader not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -108,6 +121,7 @@ func (rc *ReaderController) UpdateReader(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(reader.Version))
 	c.JSON(http.StatusOK, reader)
 }
 
@@ -119,11 +133,25 @@ func (rc *ReaderController) DeleteReader(c *gin.Context) {
 		return
 	}
 
-	if err := rc.readerService.DeleteReader(uint(id)); err != nil {
+	version, status, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := rc.readerService.DeleteReader(uint(id), version); err != nil {
+		if isVersionConflict(err) {
+			rc.preconditionFailed(c, uint(id))
+			return
+		}
 		if err.Error() == "reader not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == "cannot delete reader with audiobooks" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -157,3 +185,19 @@ func (rc *ReaderController) SearchReaders(c *gin.Context) {
 
 	c.JSON(http.StatusOK, readers)
 }
+
+// preconditionFailed answers a write with a stale If-Match version with the current reader
+func (rc *ReaderController) preconditionFailed(c *gin.Context, id uint) {
+	reader, err := rc.readerService.GetReaderByID(id)
+	if err != nil {
+		if err.Error() == "reader not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("ETag", etag(reader.Version))
+	c.JSON(http.StatusPreconditionFailed, reader)
+}
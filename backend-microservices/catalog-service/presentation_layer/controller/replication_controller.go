@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/domain_layer/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReplicationController struct {
+	replicationService *service.ReplicationService
+}
+
+func NewReplicationController(replicationService *service.ReplicationService) *ReplicationController {
+	return &ReplicationController{
+		replicationService: replicationService,
+	}
+}
+
+// ApplyEvents applies a batch of content management events, answering with the offset reached
+func (rc *ReplicationController) ApplyEvents(c *gin.Context) {
+	var req dto.ReplicationBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	offset, err := rc.replicationService.ApplyBatch(req)
+	if err != nil {
+		switch err.Error() {
+		case "unknown source":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "offset mismatch":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "offset": offset})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "offset": offset})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ReplicationOffsetResponse{Offset: offset})
+}
+
+// GetStatus reports the replication offset and how far the catalog lags behind
+func (rc *ReplicationController) GetStatus(c *gin.Context) {
+	status, err := rc.replicationService.GetStatus()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// Replay applies the content management events again starting at an offset
+func (rc *ReplicationController) Replay(c *gin.Context) {
+	var req dto.ReplicationReplayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status, err := rc.replicationService.Replay(req.FromOffset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"catalog-service/data_layer/dto"
+	"catalog-service/domain_layer/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SeriesController struct {
+	seriesService *service.SeriesService
+}
+
+func NewSeriesController(seriesService *service.SeriesService) *SeriesController {
+	return &SeriesController{
+		seriesService: seriesService,
+	}
+}
+
+// CreateSeries creates a new series
+func (sc *SeriesController) CreateSeries(c *gin.Context) {
+	var req dto.CreateSeriesRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	series, err := sc.seriesService.CreateSeries(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, series)
+}
+
+// GetSeriesByID retrieves a series with its volumes
+func (sc *SeriesController) GetSeriesByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	series, err := sc.seriesService.GetSeriesByID(uint(id))
+	if err != nil {
+		sc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// GetAllSeries retrieves all series with pagination
+func (sc *SeriesController) GetAllSeries(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	series, err := sc.seriesService.GetAllSeries(listRequest(c, page, limit))
+	if err != nil {
+		if isListRequestError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// UpdateSeries updates an existing series
+func (sc *SeriesController) UpdateSeries(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	var req dto.UpdateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	series, err := sc.seriesService.UpdateSeries(uint(id), req)
+	if err != nil {
+		sc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// DeleteSeries deletes a series, keeping its audiobooks
+func (sc *SeriesController) DeleteSeries(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	if err := sc.seriesService.DeleteSeries(uint(id)); err != nil {
+		sc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Series deleted successfully"})
+}
+
+// SetSeriesVolumes replaces the ordered volumes of a series
+func (sc *SeriesController) SetSeriesVolumes(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	var req dto.SetSeriesVolumesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	series, err := sc.seriesService.SetVolumes(uint(id), req)
+	if err != nil {
+		sc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+func (sc *SeriesController) handleError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "series not found", "audiobook not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "an audiobook can only be one volume of a series":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
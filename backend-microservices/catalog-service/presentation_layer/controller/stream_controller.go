@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"catalog-service/domain_layer/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StreamController struct {
+	streamService *service.StreamService
+}
+
+func NewStreamController(streamService *service.StreamService) *StreamController {
+	return &StreamController{
+		streamService: streamService,
+	}
+}
+
+// StreamTrack serves the audio of a track with HTTP Range and conditional request support
+func (sc *StreamController) StreamTrack(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid track ID"})
+		return
+	}
+
+	stream, err := sc.streamService.OpenTrack(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "track not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrUpstreamUnavailable) {
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer stream.File.Close()
+
+	c.Header("Content-Type", stream.ContentType)
+	c.Header("ETag", stream.ETag)
+	c.Header("Cache-Control", "private, max-age=3600")
+
+	// ServeContent handles Range/If-Range (206) and If-None-Match/If-Modified-Since (304)
+	http.ServeContent(c.Writer, c.Request, stream.Name, stream.ModTime, stream.File)
+}
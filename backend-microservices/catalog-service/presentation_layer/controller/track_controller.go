@@ -28,8 +28,12 @@ func (tc *TrackController) CreateTrack(c *gin.Context) {
 		return
 	}
 
-	track, err := tc.trackService.CreateTrack(req)
+	track, err := tc.trackService.CreateTrack(c.GetString("user_id"), req)
 	if err != nil {
+		if err.Error() == "invalid duration" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "audiobook not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -38,6 +42,7 @@ func (tc *TrackController) CreateTrack(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(track.Version))
 	c.JSON(http.StatusCreated, track)
 }
 
@@ -49,7 +54,7 @@ func (tc *TrackController) GetTrackByID(c *gin.Context) {
 		return
 	}
 
-	track, err := tc.trackService.GetTrackByID(uint(id))
+	track, err := tc.trackService.GetTrackByID(c.GetString("user_id"), uint(id))
 	if err != nil {
 		if err.Error() == "track not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -59,6 +64,7 @@ func (tc *TrackController) GetTrackByID(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(track.Version))
 	c.JSON(http.StatusOK, track)
 }
 
@@ -74,13 +80,20 @@ func (tc *TrackController) GetAllTracks(c *gin.Context) {
 		limit = 50
 	}
 
-	req := dto.PaginationRequest{
-		Page:  page,
-		Limit: limit,
+	durationFilter, err := parseDurationFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	tracks, err := tc.trackService.GetAllTracks(req)
+	listReq := listRequest(c, page, limit)
+
+	tracks, err := tc.trackService.GetAllTracks(c.GetString("user_id"), durationFilter, listReq)
 	if err != nil {
+		if isListRequestError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -106,8 +119,12 @@ func (tc *TrackController) GetTracksByAudiobook(c *gin.Context) {
 		limit = 50
 	}
 
-	tracks, err := tc.trackService.GetTracksByAudiobook(uint(audiobookID), page, limit)
+	tracks, err := tc.trackService.GetTracksByAudiobook(c.GetString("user_id"), uint(audiobookID), listRequest(c, page, limit))
 	if err != nil {
+		if isListRequestError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -115,7 +132,7 @@ func (tc *TrackController) GetTracksByAudiobook(c *gin.Context) {
 	c.JSON(http.StatusOK, tracks)
 }
 
-// UpdateTrack updates an existing track
+// UpdateTrack replaces every field of an existing track
 func (tc *TrackController) UpdateTrack(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -123,14 +140,57 @@ func (tc *TrackController) UpdateTrack(c *gin.Context) {
 		return
 	}
 
+	version, status, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
 	var req dto.UpdateTrackRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	track, err := tc.trackService.UpdateTrack(uint(id), req)
+	track, err := tc.trackService.UpdateTrack(c.GetString("user_id"), uint(id), version, req)
+	tc.respondUpdated(c, uint(id), track, err)
+}
+
+// PatchTrack updates the fields of an existing track given in the request
+func (tc *TrackController) PatchTrack(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid track ID"})
+		return
+	}
+
+	version, status, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req dto.PatchTrackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	track, err := tc.trackService.PatchTrack(c.GetString("user_id"), uint(id), version, req)
+	tc.respondUpdated(c, uint(id), track, err)
+}
+
+// respondUpdated answers a track update or patch
+func (tc *TrackController) respondUpdated(c *gin.Context, id uint, track *dto.TrackResponse, err error) {
 	if err != nil {
+		if isVersionConflict(err) {
+			tc.preconditionFailed(c, id)
+			return
+		}
+		if err.Error() == "invalid duration" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		if err.Error() == "track not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -143,6 +203,7 @@ func (tc *TrackController) UpdateTrack(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(track.Version))
 	c.JSON(http.StatusOK, track)
 }
 
@@ -154,7 +215,17 @@ func (tc *TrackController) DeleteTrack(c *gin.Context) {
 		return
 	}
 
-	if err := tc.trackService.DeleteTrack(uint(id)); err != nil {
+	version, status, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tc.trackService.DeleteTrack(uint(id), version); err != nil {
+		if isVersionConflict(err) {
+			tc.preconditionFailed(c, uint(id))
+			return
+		}
 		if err.Error() == "track not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -174,36 +245,36 @@ func (tc *TrackController) UpdateTrackOrder(c *gin.Context) {
 		return
 	}
 
-	var req struct {
-		TrackOrders []struct {
-			TrackID uint `json:"track_id" binding:"required"`
-			Order   int  `json:"order" binding:"required"`
-		} `json:"track_orders" binding:"required"`
-	}
-
+	var req dto.UpdateTrackOrderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Convert request to service format
-	var trackOrders []struct {
-		TrackID uint `json:"track_id"`
-		Order   int  `json:"order"`
+	tracks, err := tc.trackService.UpdateTrackOrder(c.GetString("user_id"), uint(audiobookID), req)
+	if err != nil {
+		switch err.Error() {
+		case "audiobook not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "track does not belong to audiobook", "track orders must be distinct", "track_orders must list every track of the audiobook exactly once":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
 	}
 
-	for _, trackOrder := range req.TrackOrders {
-		trackOrders = append(trackOrders, struct {
-			TrackID uint `json:"track_id"`
-			Order   int  `json:"order"`
-		}{
-			TrackID: trackOrder.TrackID,
-			Order:   trackOrder.Order,
-		})
-	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Track order updated successfully",
+		"tracks":  tracks,
+	})
+}
 
-	if err := tc.trackService.UpdateTrackOrder(uint(audiobookID), trackOrders); err != nil {
-		if err.Error() == "audiobook not found" {
+// preconditionFailed answers a write with a stale If-Match version with the current track
+func (tc *TrackController) preconditionFailed(c *gin.Context, id uint) {
+	track, err := tc.trackService.GetTrackByID(c.GetString("user_id"), id)
+	if err != nil {
+		if err.Error() == "track not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
@@ -211,5 +282,6 @@ func (tc *TrackController) UpdateTrackOrder(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Track order updated successfully"})
+	c.Header("ETag", etag(track.Version))
+	c.JSON(http.StatusPreconditionFailed, track)
 }
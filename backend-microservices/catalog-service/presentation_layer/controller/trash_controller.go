@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"catalog-service/domain_layer/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashController struct {
+	trashService *service.TrashService
+}
+
+func NewTrashController(trashService *service.TrashService) *TrashController {
+	return &TrashController{
+		trashService: trashService,
+	}
+}
+
+// GetTrash lists the deleted audiobooks, authors, readers, genres or tracks with pagination
+func (tc *TrashController) GetTrash(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	trash, err := tc.trashService.GetTrash(c.Param("type"), listRequest(c, page, limit))
+	if err != nil {
+		tc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trash)
+}
+
+// RestoreItem takes a deleted entry out of the trash
+func (tc *TrashController) RestoreItem(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	item, err := tc.trashService.Restore(c.Param("type"), uint(id))
+	if err != nil {
+		tc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// PurgeTrash permanently deletes the entries that are past the trash retention period now
+func (tc *TrashController) PurgeTrash(c *gin.Context) {
+	result, err := tc.trashService.Purge()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (tc *TrashController) handleError(c *gin.Context, err error) {
+	if isListRequestError(err) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch err.Error() {
+	case "invalid trash type":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case "item not found in trash":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "audiobook of the track is in the trash":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -9,13 +9,27 @@ import (
 )
 
 // AudiobookRoutes sets up all audiobook-related routes
-func AudiobookRoutes(router *gin.RouterGroup, audiobookController *controller.AudiobookController, userManagementService *service.UserManagementService) {
+func AudiobookRoutes(router *gin.RouterGroup, audiobookController *controller.AudiobookController, hlsController *controller.HLSController, downloadController *controller.DownloadController, feedController *controller.FeedController, userManagementService *service.UserManagementService) {
 	audiobooks := router.Group("/audiobooks")
 	{
 		// Public routes (no authentication required)
 		audiobooks.GET("", audiobookController.GetAllAudiobooks)
 		audiobooks.GET("/search", audiobookController.SearchAudiobooks)
-		audiobooks.GET("/:id", audiobookController.GetAudiobookByID)
+		audiobooks.GET("/:id", middleware.OptionalAuthWithAPIValidationMiddleware(userManagementService), audiobookController.GetAudiobookByID)
+
+		// Podcast feed; ?token= turns it into the private feed of the token's user
+		audiobooks.GET("/:id/feed.xml", feedController.GetAudiobookFeed)
+
+		// Authenticated routes (playlists carry URLs signed for the caller)
+		userRoutes := audiobooks.Group("")
+		userRoutes.Use(middleware.RequireAuthWithAPIValidationMiddleware(userManagementService))
+		{
+			userRoutes.GET("/:id/hls/:file", hlsController.GetAudiobookFile)
+
+			// Offline download bundle (resumable)
+			userRoutes.GET("/:id/download", downloadController.DownloadAudiobook)
+			userRoutes.HEAD("/:id/download", downloadController.DownloadAudiobook)
+		}
 
 		// Protected routes (SuperAdmin only)
 		adminRoutes := audiobooks.Group("")
@@ -23,11 +37,15 @@ func AudiobookRoutes(router *gin.RouterGroup, audiobookController *controller.Au
 		{
 			adminRoutes.POST("", audiobookController.CreateAudiobook)
 			adminRoutes.PUT("/:id", audiobookController.UpdateAudiobook)
+			adminRoutes.PATCH("/:id", audiobookController.PatchAudiobook)
 			adminRoutes.DELETE("/:id", audiobookController.DeleteAudiobook)
 
 			// Genre management
 			adminRoutes.POST("/:id/genres", audiobookController.AddGenresToAudiobook)
 			adminRoutes.DELETE("/:id/genres", audiobookController.RemoveGenresFromAudiobook)
+
+			// Author and reader credits
+			adminRoutes.PUT("/:id/credits", audiobookController.SetAudiobookCredits)
 		}
 	}
 }
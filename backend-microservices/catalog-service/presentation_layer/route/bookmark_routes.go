@@ -0,0 +1,28 @@
+package route
+
+import (
+	"catalog-service/domain_layer/middleware"
+	"catalog-service/domain_layer/service"
+	"catalog-service/presentation_layer/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BookmarkRoutes sets up all bookmark-related routes
+func BookmarkRoutes(router *gin.RouterGroup, bookmarkController *controller.BookmarkController, userManagementService *service.UserManagementService) {
+	bookmarks := router.Group("/bookmarks")
+
+	// All bookmark routes are private to the authenticated user
+	bookmarks.Use(middleware.RequireAuthWithAPIValidationMiddleware(userManagementService))
+	{
+		bookmarks.POST("", bookmarkController.CreateBookmark)
+		bookmarks.GET("/:id", bookmarkController.GetBookmarkByID)
+		bookmarks.PUT("/:id", bookmarkController.UpdateBookmark)
+		bookmarks.DELETE("/:id", bookmarkController.DeleteBookmark)
+
+		// Query routes
+		bookmarks.GET("/track/:track_id", bookmarkController.GetBookmarksByTrack)
+		bookmarks.GET("/audiobook/:audiobook_id", bookmarkController.GetBookmarksByAudiobook)
+		bookmarks.GET("/audiobook/:audiobook_id/export", bookmarkController.ExportBookmarks)
+	}
+}
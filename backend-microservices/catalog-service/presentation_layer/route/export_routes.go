@@ -0,0 +1,20 @@
+package route
+
+import (
+	"catalog-service/domain_layer/middleware"
+	"catalog-service/domain_layer/service"
+	"catalog-service/presentation_layer/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportRoutes sets up the catalog export routes
+func ExportRoutes(router *gin.RouterGroup, exportController *controller.ExportController, userManagementService *service.UserManagementService) {
+	export := router.Group("/export")
+
+	// Protected routes (SuperAdmin only)
+	export.Use(middleware.RequireSuperAdminWithAPIValidationMiddleware(userManagementService))
+	{
+		export.GET("/audiobooks", exportController.ExportAudiobooks)
+	}
+}
@@ -0,0 +1,29 @@
+package route
+
+import (
+	"catalog-service/domain_layer/middleware"
+	"catalog-service/domain_layer/service"
+	"catalog-service/presentation_layer/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeedRoutes sets up the podcast feed routes
+func FeedRoutes(router *gin.RouterGroup, feedController *controller.FeedController, streamController *controller.StreamController, feedService *service.FeedService, userManagementService *service.UserManagementService) {
+	// Audio of private feeds, authorized by the feed token in the path
+	feeds := router.Group("/feeds/:token")
+	feeds.Use(middleware.RequireFeedTokenMiddleware(feedService))
+	{
+		feeds.GET("/tracks/:id/stream", streamController.StreamTrack)
+		feeds.HEAD("/tracks/:id/stream", streamController.StreamTrack)
+	}
+
+	// Feed tokens belong to the authenticated user
+	feedTokens := router.Group("/feed-tokens")
+	feedTokens.Use(middleware.RequireAuthWithAPIValidationMiddleware(userManagementService))
+	{
+		feedTokens.GET("", feedController.GetFeedTokens)
+		feedTokens.POST("", feedController.CreateFeedToken)
+		feedTokens.DELETE("/:id", feedController.RevokeFeedToken)
+	}
+}
@@ -0,0 +1,24 @@
+package route
+
+import (
+	"catalog-service/domain_layer/middleware"
+	"catalog-service/domain_layer/service"
+	"catalog-service/presentation_layer/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListeningStatsRoutes sets up all listening statistics routes
+func ListeningStatsRoutes(router *gin.RouterGroup, listeningStatsController *controller.ListeningStatsController, userManagementService *service.UserManagementService) {
+	stats := router.Group("/listening/stats")
+
+	// Listening statistics are private to the authenticated user
+	stats.Use(middleware.RequireAuthWithAPIValidationMiddleware(userManagementService))
+	{
+		stats.GET("/daily", listeningStatsController.GetDailyTotals)
+		stats.GET("/weekly", listeningStatsController.GetWeeklyTotals)
+		stats.GET("/monthly", listeningStatsController.GetMonthlyTotals)
+		stats.GET("/streak", listeningStatsController.GetStreak)
+		stats.GET("/genres", listeningStatsController.GetTopGenres)
+	}
+}
@@ -0,0 +1,23 @@
+package route
+
+import (
+	"catalog-service/domain_layer/middleware"
+	"catalog-service/domain_layer/service"
+	"catalog-service/presentation_layer/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlaySessionRoutes sets up all playback session routes
+func PlaySessionRoutes(router *gin.RouterGroup, playSessionController *controller.PlaySessionController, userManagementService *service.UserManagementService) {
+	sessions := router.Group("/sessions")
+
+	// All session routes belong to the authenticated user
+	sessions.Use(middleware.RequireAuthWithAPIValidationMiddleware(userManagementService))
+	{
+		sessions.POST("", playSessionController.StartSession)
+		sessions.GET("/:id", playSessionController.GetSessionByID)
+		sessions.PUT("/:id/heartbeat", playSessionController.Heartbeat)
+		sessions.POST("/:id/stop", playSessionController.StopSession)
+	}
+}
@@ -0,0 +1,25 @@
+package route
+
+import (
+	"catalog-service/domain_layer/middleware"
+	"catalog-service/domain_layer/service"
+	"catalog-service/presentation_layer/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueueRoutes sets up all play queue routes
+func QueueRoutes(router *gin.RouterGroup, queueController *controller.QueueController, userManagementService *service.UserManagementService) {
+	queue := router.Group("/queue")
+
+	// The queue belongs to the authenticated user
+	queue.Use(middleware.RequireAuthWithAPIValidationMiddleware(userManagementService))
+	{
+		queue.GET("", queueController.GetQueue)
+		queue.POST("", queueController.Enqueue)
+		queue.DELETE("", queueController.ClearQueue)
+		queue.PUT("/order", queueController.ReorderQueue)
+		queue.POST("/advance", queueController.AdvanceQueue)
+		queue.DELETE("/:id", queueController.RemoveEntry)
+	}
+}
@@ -0,0 +1,25 @@
+package route
+
+import (
+	"catalog-service/domain_layer/middleware"
+	"catalog-service/domain_layer/service"
+	"catalog-service/presentation_layer/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReplicationRoutes sets up the routes that replicate content management changes into the catalog
+func ReplicationRoutes(router *gin.RouterGroup, replicationController *controller.ReplicationController, replicationAPIKey string, userManagementService *service.UserManagementService) {
+	replication := router.Group("/replication")
+
+	// Consumer route for content-management-service
+	replication.POST("/events", middleware.RequireReplicationAPIKeyMiddleware(replicationAPIKey), replicationController.ApplyEvents)
+
+	// Protected routes (SuperAdmin only)
+	adminRoutes := replication.Group("")
+	adminRoutes.Use(middleware.RequireSuperAdminWithAPIValidationMiddleware(userManagementService))
+	{
+		adminRoutes.GET("/status", replicationController.GetStatus)
+		adminRoutes.POST("/replay", replicationController.Replay)
+	}
+}
@@ -15,8 +15,23 @@ func SetupRoutes(
 	genreController *controller.GenreController,
 	audiobookController *controller.AudiobookController,
 	trackController *controller.TrackController,
+	streamController *controller.StreamController,
+	hlsController *controller.HLSController,
+	downloadController *controller.DownloadController,
 	userController *controller.UserController,
 	analyticsController *controller.AnalyticsController,
+	bookmarkController *controller.BookmarkController,
+	playSessionController *controller.PlaySessionController,
+	listeningStatsController *controller.ListeningStatsController,
+	queueController *controller.QueueController,
+	exportController *controller.ExportController,
+	feedController *controller.FeedController,
+	seriesController *controller.SeriesController,
+	trashController *controller.TrashController,
+	replicationController *controller.ReplicationController,
+	streamURLService *service.StreamURLService,
+	feedService *service.FeedService,
+	replicationAPIKey string,
 	userManagementService *service.UserManagementService,
 ) {
 	// API versioning
@@ -34,8 +49,17 @@ func SetupRoutes(
 	AuthorRoutes(api, authorController, userManagementService)
 	ReaderRoutes(api, readerController, userManagementService)
 	GenreRoutes(api, genreController, userManagementService)
-	AudiobookRoutes(api, audiobookController, userManagementService)
-	TrackRoutes(api, trackController, userManagementService)
+	SeriesRoutes(api, seriesController, userManagementService)
+	AudiobookRoutes(api, audiobookController, hlsController, downloadController, feedController, userManagementService)
+	TrackRoutes(api, trackController, streamController, hlsController, streamURLService, userManagementService)
 	UserRoutes(api, userController, userManagementService)
 	AnalyticsRoutes(api, analyticsController, userManagementService)
+	BookmarkRoutes(api, bookmarkController, userManagementService)
+	PlaySessionRoutes(api, playSessionController, userManagementService)
+	ListeningStatsRoutes(api, listeningStatsController, userManagementService)
+	QueueRoutes(api, queueController, userManagementService)
+	ExportRoutes(api, exportController, userManagementService)
+	FeedRoutes(api, feedController, streamController, feedService, userManagementService)
+	TrashRoutes(api, trashController, userManagementService)
+	ReplicationRoutes(api, replicationController, replicationAPIKey, userManagementService)
 }
@@ -0,0 +1,29 @@
+package route
+
+import (
+	"catalog-service/domain_layer/middleware"
+	"catalog-service/domain_layer/service"
+	"catalog-service/presentation_layer/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SeriesRoutes sets up all series-related routes
+func SeriesRoutes(router *gin.RouterGroup, seriesController *controller.SeriesController, userManagementService *service.UserManagementService) {
+	series := router.Group("/series")
+	{
+		// Public routes (no authentication required)
+		series.GET("", seriesController.GetAllSeries)
+		series.GET("/:id", seriesController.GetSeriesByID)
+
+		// Protected routes (SuperAdmin only)
+		adminRoutes := series.Group("")
+		adminRoutes.Use(middleware.RequireSuperAdminWithAPIValidationMiddleware(userManagementService))
+		{
+			adminRoutes.POST("", seriesController.CreateSeries)
+			adminRoutes.PUT("/:id", seriesController.UpdateSeries)
+			adminRoutes.DELETE("/:id", seriesController.DeleteSeries)
+			adminRoutes.PUT("/:id/volumes", seriesController.SetSeriesVolumes)
+		}
+	}
+}
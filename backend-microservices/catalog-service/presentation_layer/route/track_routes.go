@@ -9,13 +9,26 @@ import (
 )
 
 // TrackRoutes sets up all track-related routes
-func TrackRoutes(router *gin.RouterGroup, trackController *controller.TrackController, userManagementService *service.UserManagementService) {
+func TrackRoutes(router *gin.RouterGroup, trackController *controller.TrackController, streamController *controller.StreamController, hlsController *controller.HLSController, streamURLService *service.StreamURLService, userManagementService *service.UserManagementService) {
 	tracks := router.Group("/tracks")
 	{
-		// Public routes (no authentication required)
-		tracks.GET("", trackController.GetAllTracks)
-		tracks.GET("/:id", trackController.GetTrackByID)
-		tracks.GET("/audiobook/:audiobook_id", trackController.GetTracksByAudiobook)
+		// Public routes (stream URLs are only signed for authenticated callers)
+		publicRoutes := tracks.Group("")
+		publicRoutes.Use(middleware.OptionalAuthWithAPIValidationMiddleware(userManagementService))
+		{
+			publicRoutes.GET("", trackController.GetAllTracks)
+			publicRoutes.GET("/:id", trackController.GetTrackByID)
+			publicRoutes.GET("/audiobook/:audiobook_id", trackController.GetTracksByAudiobook)
+		}
+
+		// Audio streaming through the local cache (signed URL required)
+		streamRoutes := tracks.Group("")
+		streamRoutes.Use(middleware.RequireSignedStreamURLMiddleware(streamURLService))
+		{
+			streamRoutes.GET("/:id/stream", streamController.StreamTrack)
+			streamRoutes.HEAD("/:id/stream", streamController.StreamTrack)
+			streamRoutes.GET("/:id/hls/:file", hlsController.GetTrackFile)
+		}
 
 		// Protected routes (SuperAdmin only)
 		adminRoutes := tracks.Group("")
@@ -23,6 +36,7 @@ func TrackRoutes(router *gin.RouterGroup, trackController *controller.TrackContr
 		{
 			adminRoutes.POST("", trackController.CreateTrack)
 			adminRoutes.PUT("/:id", trackController.UpdateTrack)
+			adminRoutes.PATCH("/:id", trackController.PatchTrack)
 			adminRoutes.DELETE("/:id", trackController.DeleteTrack)
 			adminRoutes.PUT("/audiobook/:audiobook_id/order", trackController.UpdateTrackOrder)
 		}
@@ -0,0 +1,22 @@
+package route
+
+import (
+	"catalog-service/domain_layer/middleware"
+	"catalog-service/domain_layer/service"
+	"catalog-service/presentation_layer/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrashRoutes sets up the routes of the trash of deleted catalog entries
+func TrashRoutes(router *gin.RouterGroup, trashController *controller.TrashController, userManagementService *service.UserManagementService) {
+	trash := router.Group("/trash")
+
+	// Protected routes (SuperAdmin only)
+	trash.Use(middleware.RequireSuperAdminWithAPIValidationMiddleware(userManagementService))
+	{
+		trash.GET("/:type", trashController.GetTrash)
+		trash.POST("/:type/:id/restore", trashController.RestoreItem)
+		trash.POST("/purge", trashController.PurgeTrash)
+	}
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // CreateAudiobookRequest represents the request to create a new audiobook
 type CreateAudiobookRequest struct {
 	Title            string `json:"title" binding:"required,min=1,max=255"`
@@ -39,6 +41,15 @@ type AudiobookResponse struct {
 	TotalDuration    string          `json:"total_duration"`
 	Genres           []GenreResponse `json:"genres"`
 	Tracks           []TrackResponse `json:"tracks,omitempty"`
+
+	// Publication workflow
+	Status      string     `json:"status"`
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	SubmittedBy string     `json:"submitted_by,omitempty"`
+	ApprovedBy  string     `json:"approved_by,omitempty"`
+	ApprovedAt  *time.Time `json:"approved_at,omitempty"`
+	ReviewNote  string     `json:"review_note,omitempty"`
 }
 
 // AudiobookListResponse represents the response for audiobook list
@@ -52,6 +63,20 @@ type AudiobookListResponse struct {
 	YearOfPublishing  int               `json:"year_of_publishing"`
 	TotalDuration     string            `json:"total_duration"`
 	Genres            []GenreResponse   `json:"genres"`
+	Status            string            `json:"status"`
+	PublishAt         *time.Time        `json:"publish_at,omitempty"`
+	PublishedAt       *time.Time        `json:"published_at,omitempty"`
+}
+
+// SubmitAudiobookRequest represents the request to submit an audiobook for review.
+// PublishAt schedules the release; without it the audiobook is published once approved.
+type SubmitAudiobookRequest struct {
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+// RejectAudiobookRequest represents the request to send an audiobook in review back to draft
+type RejectAudiobookRequest struct {
+	Note string `json:"note" binding:"max=2000"`
 }
 
 // AudiobookFilter represents filtering options for audiobooks
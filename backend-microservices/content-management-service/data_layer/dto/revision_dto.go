@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RevisionResponse represents the response for revision data
+type RevisionResponse struct {
+	ID          uint            `json:"id"`
+	AudiobookID uint            `json:"audiobook_id"`
+	EntityType  string          `json:"entity_type"`
+	EntityID    uint            `json:"entity_id"`
+	Action      string          `json:"action"`
+	UserID      string          `json:"user_id"`
+	Snapshot    json.RawMessage `json:"snapshot"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// RevisionFieldChange represents a field that differs between two revisions
+type RevisionFieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// RevisionDiffResponse represents the field-level differences between two revisions of an entity
+type RevisionDiffResponse struct {
+	EntityType string                `json:"entity_type"`
+	EntityID   uint                  `json:"entity_id"`
+	From       RevisionResponse      `json:"from"`
+	To         RevisionResponse      `json:"to"`
+	Changes    []RevisionFieldChange `json:"changes"`
+}
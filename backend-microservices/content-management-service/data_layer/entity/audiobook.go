@@ -18,6 +18,15 @@ type Audiobook struct {
 	CreatedAt        time.Time `json:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at"`
 
+	// Publication workflow. Audiobooks that existed before the workflow stay published.
+	Status      string     `gorm:"size:20;not null;default:'published';index" json:"status"`
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	SubmittedBy string     `gorm:"size:64" json:"submitted_by,omitempty"`
+	ApprovedBy  string     `gorm:"size:64" json:"approved_by,omitempty"`
+	ApprovedAt  *time.Time `json:"approved_at,omitempty"`
+	ReviewNote  string     `gorm:"type:text" json:"review_note,omitempty"`
+
 	// Relationships
 	Author    *Author      `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
 	Reader    *Reader      `gorm:"foreignKey:ReaderID" json:"reader,omitempty"`
@@ -25,6 +34,15 @@ type Audiobook struct {
 	Tracks    []Track     `gorm:"foreignKey:AudiobookID" json:"tracks,omitempty"`
 }
 
+// Publication states of an audiobook. Drafts are submitted for review; an approved audiobook is
+// published at its publish time, or right away without one. Only published audiobooks are public.
+const (
+	AudiobookDraft       = "draft"
+	AudiobookInReview    = "in_review"
+	AudiobookPublished   = "published"
+	AudiobookUnpublished = "unpublished"
+)
+
 // TableName specifies the table name for the Audiobook model
 func (Audiobook) TableName() string {
 	return "audiobooks"
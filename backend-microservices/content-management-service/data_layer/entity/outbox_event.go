@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"time"
+)
+
+// OutboxEvent represents the outbox_events table: a domain event written in the same transaction
+// as the change it describes, waiting to be relayed to catalog-service. The ID is the offset of the
+// event in the outbox; events are relayed in offset order.
+type OutboxEvent struct {
+	ID            uint      `json:"offset" gorm:"primaryKey;autoIncrement"`
+	AggregateType string    `json:"aggregate_type" gorm:"size:20;not null"`
+	AggregateID   uint      `json:"aggregate_id" gorm:"not null"`
+	EventType     string    `json:"event_type" gorm:"size:40;not null"`
+	Payload       string    `json:"payload" gorm:"type:jsonb;not null"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Outbox aggregate types
+const (
+	AggregateAudiobook = "audiobook"
+	AggregateTrack     = "track"
+	AggregateAuthor    = "author"
+	AggregateReader    = "reader"
+	AggregateGenre     = "genre"
+)
+
+// Outbox event kinds; the event type is the aggregate type and the kind, e.g. "audiobook.upserted"
+const (
+	EventUpserted = "upserted"
+	EventDeleted  = "deleted"
+)
+
+// NamedPayload is the state of an author, reader or genre in its upserted events
+type NamedPayload struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// TrackPayload is the state of a track in its upserted events
+type TrackPayload struct {
+	ID          uint   `json:"id"`
+	AudiobookID uint   `json:"audiobook_id"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Duration    string `json:"duration"`
+}
+
+// AudiobookPayload is the state of an audiobook in its upserted events, with its genres and its
+// tracks in play order
+type AudiobookPayload struct {
+	ID               uint           `json:"id"`
+	Title            string         `json:"title"`
+	AuthorID         uint           `json:"author_id"`
+	ReaderID         uint           `json:"reader_id"`
+	Description      string         `json:"description"`
+	ImageURL         string         `json:"image_url"`
+	Language         string         `json:"language"`
+	YearOfPublishing int            `json:"year_of_publishing"`
+	TotalDuration    string         `json:"total_duration"`
+	Status           string         `json:"status"`
+	GenreIDs         []uint         `json:"genre_ids"`
+	Tracks           []TrackPayload `json:"tracks"`
+}
+
+// DeletedPayload identifies the aggregate of a deleted event
+type DeletedPayload struct {
+	ID uint `json:"id"`
+}
+
+// TableName specifies the table name for the OutboxEvent model
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"time"
+)
+
+// Revision represents the revisions table: a snapshot of an audiobook, a track or the genres of an
+// audiobook taken on every change. Every revision belongs to the history of an audiobook.
+type Revision struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	AudiobookID uint      `json:"audiobook_id" gorm:"not null;index"`
+	EntityType  string    `json:"entity_type" gorm:"size:20;not null;index:idx_revisions_entity"`
+	EntityID    uint      `json:"entity_id" gorm:"not null;index:idx_revisions_entity"`
+	Action      string    `json:"action" gorm:"size:20;not null"`
+	UserID      string    `json:"user_id" gorm:"size:64"`
+	Snapshot    string    `json:"snapshot" gorm:"type:jsonb;not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Revision entity types. Genre revisions have the audiobook as their entity.
+const (
+	RevisionAudiobook = "audiobook"
+	RevisionTrack     = "track"
+	RevisionGenres    = "genres"
+)
+
+// Revision actions. The snapshot of a delete revision is the state before the delete; every other
+// snapshot is the state after the change.
+const (
+	RevisionCreate   = "create"
+	RevisionUpdate   = "update"
+	RevisionDelete   = "delete"
+	RevisionRollback = "rollback"
+)
+
+// AudiobookSnapshot is the metadata of an audiobook kept in its revisions. The publication state is
+// left out: it only changes through the review workflow.
+type AudiobookSnapshot struct {
+	Title            string `json:"title"`
+	AuthorID         uint   `json:"author_id"`
+	ReaderID         uint   `json:"reader_id"`
+	Description      string `json:"description"`
+	ImageURL         string `json:"image_url"`
+	Language         string `json:"language"`
+	YearOfPublishing int    `json:"year_of_publishing"`
+	TotalDuration    string `json:"total_duration"`
+}
+
+// TrackSnapshot is a track as kept in its revisions
+type TrackSnapshot struct {
+	AudiobookID uint   `json:"audiobook_id"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Duration    string `json:"duration"`
+}
+
+// GenresSnapshot is the genre assignment of an audiobook as kept in its revisions
+type GenresSnapshot struct {
+	GenreIDs []uint `json:"genre_ids"`
+}
+
+// NewAudiobookSnapshot takes a snapshot of an audiobook
+func NewAudiobookSnapshot(audiobook *Audiobook) AudiobookSnapshot {
+	return AudiobookSnapshot{
+		Title:            audiobook.Title,
+		AuthorID:         audiobook.AuthorID,
+		ReaderID:         audiobook.ReaderID,
+		Description:      audiobook.Description,
+		ImageURL:         audiobook.ImageURL,
+		Language:         audiobook.Language,
+		YearOfPublishing: audiobook.YearOfPublishing,
+		TotalDuration:    audiobook.TotalDuration,
+	}
+}
+
+// NewTrackSnapshot takes a snapshot of a track
+func NewTrackSnapshot(track *Track) TrackSnapshot {
+	return TrackSnapshot{
+		AudiobookID: track.AudiobookID,
+		Title:       track.Title,
+		URL:         track.URL,
+		Duration:    track.Duration,
+	}
+}
+
+// TableName specifies the table name for the Revision model
+func (Revision) TableName() string {
+	return "revisions"
+}
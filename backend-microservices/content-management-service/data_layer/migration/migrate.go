@@ -20,6 +20,8 @@ func AutoMigrate(db *gorm.DB) error {
 		&entity.Audiobook{},
 		&entity.Track{},
 		&entity.Analytics{},
+		&entity.Revision{},
+		&entity.OutboxEvent{},
 	)
 
 	if err != nil {
@@ -2,26 +2,33 @@ package repository
 
 import (
 	"content-management-service/data_layer/entity"
+	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 // AudiobookRepositoryInterface defines the contract for audiobook repository
 type AudiobookRepositoryInterface interface {
-	Create(audiobook *entity.Audiobook) error
+	Create(audiobook *entity.Audiobook, genreIDs []uint, userID string) error
 	GetByID(id uint) (*entity.Audiobook, error)
 	GetByIDWithRelations(id uint) (*entity.Audiobook, error)
+	GetPublishedByIDWithRelations(id uint) (*entity.Audiobook, error)
+	GetByStatus(status string, offset, limit int) ([]entity.Audiobook, int64, error)
 	GetAll(offset, limit int) ([]entity.Audiobook, int64, error)
 	GetAllWithRelations(offset, limit int) ([]entity.Audiobook, int64, error)
-	Update(audiobook *entity.Audiobook) error
-	Delete(id uint) error
+	Update(audiobook *entity.Audiobook, genreIDs []uint, userID string) error
+	Delete(id uint, userID string) error
 	SearchByTitle(query string, offset, limit int) ([]entity.Audiobook, int64, error)
 	GetByAuthorID(authorID uint, offset, limit int) ([]entity.Audiobook, int64, error)
 	GetByReaderID(readerID uint, offset, limit int) ([]entity.Audiobook, int64, error)
 	GetByGenreID(genreID uint, offset, limit int) ([]entity.Audiobook, int64, error)
-	AssignGenres(audiobookID uint, genreIDs []uint) error
-	RemoveGenres(audiobookID uint, genreIDs []uint) error
+	AssignGenres(audiobookID uint, genreIDs []uint, userID string) error
+	RemoveGenres(audiobookID uint, genreIDs []uint, userID string) error
 	RemoveAllGenres(audiobookID uint) error
+	GetGenreIDs(audiobookID uint) ([]uint, error)
+	UpdateStatus(id uint, from string, updates map[string]interface{}) (bool, error)
+	PublishDue(now time.Time) (int64, error)
 }
 
 // AudiobookRepository implements AudiobookRepositoryInterface
@@ -34,9 +41,27 @@ func NewAudiobookRepository(db *gorm.DB) AudiobookRepositoryInterface {
 	return &AudiobookRepository{db: db}
 }
 
-// Create creates a new audiobook
-func (r *AudiobookRepository) Create(audiobook *entity.Audiobook) error {
-	return r.db.Create(audiobook).Error
+// Create creates a new audiobook with the existing ones of genreIDs, records its revisions and
+// publishes it to the outbox
+func (r *AudiobookRepository) Create(audiobook *entity.Audiobook, genreIDs []uint, userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(audiobook).Error; err != nil {
+			return err
+		}
+		if err := recordAudiobook(tx, audiobook, entity.RevisionCreate, userID); err != nil {
+			return err
+		}
+
+		if len(genreIDs) > 0 {
+			if err := appendGenres(tx, audiobook, genreIDs); err != nil {
+				return err
+			}
+			if err := recordCurrentGenres(tx, audiobook.ID, entity.RevisionCreate, userID); err != nil {
+				return err
+			}
+		}
+		return publishAudiobook(tx, audiobook.ID)
+	})
 }
 
 // GetByID retrieves an audiobook by ID
@@ -59,6 +84,41 @@ func (r *AudiobookRepository) GetByIDWithRelations(id uint) (*entity.Audiobook,
 	return &audiobook, nil
 }
 
+// GetPublishedByIDWithRelations retrieves a published audiobook by ID with all relations
+func (r *AudiobookRepository) GetPublishedByIDWithRelations(id uint) (*entity.Audiobook, error) {
+	var audiobook entity.Audiobook
+	err := r.db.Preload("Author").Preload("Reader").Preload("Genres").Preload("Tracks").
+		Where("status = ?", entity.AudiobookPublished).First(&audiobook, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &audiobook, nil
+}
+
+// GetByStatus retrieves audiobooks in any publication state, or in one state if status is set,
+// with relations and pagination, most recently updated first
+func (r *AudiobookRepository) GetByStatus(status string, offset, limit int) ([]entity.Audiobook, int64, error) {
+	var audiobooks []entity.Audiobook
+	var total int64
+
+	dbQuery := r.db.Model(&entity.Audiobook{})
+	if status != "" {
+		dbQuery = dbQuery.Where("status = ?", status)
+	}
+
+	// Count total records
+	if err := dbQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results with relations
+	if err := dbQuery.Preload("Author").Preload("Reader").Preload("Genres").Order("updated_at DESC").Offset(offset).Limit(limit).Find(&audiobooks).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return audiobooks, total, nil
+}
+
 // GetAll retrieves all audiobooks with pagination
 func (r *AudiobookRepository) GetAll(offset, limit int) ([]entity.Audiobook, int64, error) {
 	var audiobooks []entity.Audiobook
@@ -77,40 +137,106 @@ func (r *AudiobookRepository) GetAll(offset, limit int) ([]entity.Audiobook, int
 	return audiobooks, total, nil
 }
 
-// GetAllWithRelations retrieves all audiobooks with relations and pagination
+// GetAllWithRelations retrieves all published audiobooks with relations and pagination
 func (r *AudiobookRepository) GetAllWithRelations(offset, limit int) ([]entity.Audiobook, int64, error) {
 	var audiobooks []entity.Audiobook
 	var total int64
 
+	dbQuery := r.db.Model(&entity.Audiobook{}).Where("status = ?", entity.AudiobookPublished)
+
 	// Count total records
-	if err := r.db.Model(&entity.Audiobook{}).Count(&total).Error; err != nil {
+	if err := dbQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get paginated results with relations
-	if err := r.db.Preload("Author").Preload("Reader").Preload("Genres").Offset(offset).Limit(limit).Find(&audiobooks).Error; err != nil {
+	if err := dbQuery.Preload("Author").Preload("Reader").Preload("Genres").Offset(offset).Limit(limit).Find(&audiobooks).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return audiobooks, total, nil
 }
 
-// Update updates an existing audiobook
-func (r *AudiobookRepository) Update(audiobook *entity.Audiobook) error {
-	return r.db.Save(audiobook).Error
+// Update updates an existing audiobook, replacing its genres with the existing ones of genreIDs
+// unless there are none, records its revisions and publishes it to the outbox; its publication
+// state is only changed by UpdateStatus
+func (r *AudiobookRepository) Update(audiobook *entity.Audiobook, genreIDs []uint, userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(workflowColumns...).Save(audiobook).Error; err != nil {
+			return err
+		}
+		if err := recordAudiobook(tx, audiobook, entity.RevisionUpdate, userID); err != nil {
+			return err
+		}
+
+		if len(genreIDs) > 0 {
+			if err := tx.Model(audiobook).Association("Genres").Clear(); err != nil {
+				return err
+			}
+			if err := appendGenres(tx, audiobook, genreIDs); err != nil {
+				return err
+			}
+			if err := recordCurrentGenres(tx, audiobook.ID, entity.RevisionUpdate, userID); err != nil {
+				return err
+			}
+		}
+		return publishAudiobook(tx, audiobook.ID)
+	})
 }
 
-// Delete deletes an audiobook by ID
-func (r *AudiobookRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Audiobook{}, id).Error
+// Delete deletes an audiobook by ID with its tracks, genre assignment and analytics, keeping the last
+// state of all but the analytics in its revisions, and publishes the deletions to the outbox
+func (r *AudiobookRepository) Delete(id uint, userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var audiobook entity.Audiobook
+		if err := tx.First(&audiobook, id).Error; err != nil {
+			return err
+		}
+		var tracks []entity.Track
+		if err := tx.Where("audiobook_id = ?", id).Order("id ASC").Find(&tracks).Error; err != nil {
+			return err
+		}
+		genreIDs, err := genreIDsOf(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&audiobook).Association("Genres").Clear(); err != nil {
+			return fmt.Errorf("failed to remove genre associations: %v", err)
+		}
+		if err := NewTrackRepository(tx).DeleteByAudiobookID(id); err != nil {
+			return fmt.Errorf("failed to delete tracks: %v", err)
+		}
+		if err := NewAnalyticsRepository(tx).DeleteByAudiobookID(id); err != nil {
+			return fmt.Errorf("failed to delete analytics: %v", err)
+		}
+		if err := tx.Delete(&entity.Audiobook{}, id).Error; err != nil {
+			return fmt.Errorf("failed to delete audiobook: %v", err)
+		}
+
+		for i := range tracks {
+			if err := recordTrack(tx, &tracks[i], entity.RevisionDelete, userID); err != nil {
+				return err
+			}
+		}
+		if len(genreIDs) > 0 {
+			if err := recordGenres(tx, id, genreIDs, entity.RevisionDelete, userID); err != nil {
+				return err
+			}
+		}
+		if err := recordAudiobook(tx, &audiobook, entity.RevisionDelete, userID); err != nil {
+			return err
+		}
+		return publishAudiobook(tx, id)
+	})
 }
 
-// SearchByTitle searches audiobooks by title
+// SearchByTitle searches published audiobooks by title
 func (r *AudiobookRepository) SearchByTitle(query string, offset, limit int) ([]entity.Audiobook, int64, error) {
 	var audiobooks []entity.Audiobook
 	var total int64
 
-	dbQuery := r.db.Model(&entity.Audiobook{}).Preload("Author").Preload("Reader").Preload("Genres")
+	dbQuery := r.db.Model(&entity.Audiobook{}).Where("status = ?", entity.AudiobookPublished).Preload("Author").Preload("Reader").Preload("Genres")
 	if query != "" {
 		dbQuery = dbQuery.Where("title LIKE ?", "%"+query+"%")
 	}
@@ -128,12 +254,12 @@ func (r *AudiobookRepository) SearchByTitle(query string, offset, limit int) ([]
 	return audiobooks, total, nil
 }
 
-// GetByAuthorID retrieves audiobooks by author ID
+// GetByAuthorID retrieves published audiobooks by author ID
 func (r *AudiobookRepository) GetByAuthorID(authorID uint, offset, limit int) ([]entity.Audiobook, int64, error) {
 	var audiobooks []entity.Audiobook
 	var total int64
 
-	dbQuery := r.db.Model(&entity.Audiobook{}).Where("author_id = ?", authorID).Preload("Author").Preload("Reader").Preload("Genres")
+	dbQuery := r.db.Model(&entity.Audiobook{}).Where("author_id = ? AND status = ?", authorID, entity.AudiobookPublished).Preload("Author").Preload("Reader").Preload("Genres")
 
 	// Count total records
 	if err := dbQuery.Count(&total).Error; err != nil {
@@ -148,12 +274,12 @@ func (r *AudiobookRepository) GetByAuthorID(authorID uint, offset, limit int) ([
 	return audiobooks, total, nil
 }
 
-// GetByReaderID retrieves audiobooks by reader ID
+// GetByReaderID retrieves published audiobooks by reader ID
 func (r *AudiobookRepository) GetByReaderID(readerID uint, offset, limit int) ([]entity.Audiobook, int64, error) {
 	var audiobooks []entity.Audiobook
 	var total int64
 
-	dbQuery := r.db.Model(&entity.Audiobook{}).Where("reader_id = ?", readerID).Preload("Author").Preload("Reader").Preload("Genres")
+	dbQuery := r.db.Model(&entity.Audiobook{}).Where("reader_id = ? AND status = ?", readerID, entity.AudiobookPublished).Preload("Author").Preload("Reader").Preload("Genres")
 
 	// Count total records
 	if err := dbQuery.Count(&total).Error; err != nil {
@@ -168,14 +294,14 @@ func (r *AudiobookRepository) GetByReaderID(readerID uint, offset, limit int) ([
 	return audiobooks, total, nil
 }
 
-// GetByGenreID retrieves audiobooks by genre ID
+// GetByGenreID retrieves published audiobooks by genre ID
 func (r *AudiobookRepository) GetByGenreID(genreID uint, offset, limit int) ([]entity.Audiobook, int64, error) {
 	var audiobooks []entity.Audiobook
 	var total int64
 
 	dbQuery := r.db.Model(&entity.Audiobook{}).
 		Joins("JOIN audiobook_genres ON audiobooks.id = audiobook_genres.audiobook_id").
-		Where("audiobook_genres.genre_id = ?", genreID).
+		Where("audiobook_genres.genre_id = ? AND audiobooks.status = ?", genreID, entity.AudiobookPublished).
 		Preload("Author").Preload("Reader").Preload("Genres")
 
 	// Count total records
@@ -191,43 +317,138 @@ func (r *AudiobookRepository) GetByGenreID(genreID uint, offset, limit int) ([]e
 	return audiobooks, total, nil
 }
 
-// AssignGenres assigns genres to an audiobook
-func (r *AudiobookRepository) AssignGenres(audiobookID uint, genreIDs []uint) error {
-	var audiobook entity.Audiobook
-	if err := r.db.First(&audiobook, audiobookID).Error; err != nil {
-		return err
-	}
+// AssignGenres assigns genres to an audiobook, records its genres revision and publishes it to the outbox
+func (r *AudiobookRepository) AssignGenres(audiobookID uint, genreIDs []uint, userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var audiobook entity.Audiobook
+		if err := tx.First(&audiobook, audiobookID).Error; err != nil {
+			return err
+		}
+
+		if err := appendGenres(tx, &audiobook, genreIDs); err != nil {
+			return err
+		}
+		if err := recordCurrentGenres(tx, audiobookID, entity.RevisionUpdate, userID); err != nil {
+			return err
+		}
+		return publishAudiobook(tx, audiobookID)
+	})
+}
 
-	var genres []entity.Genre
-	if err := r.db.Where("id IN ?", genreIDs).Find(&genres).Error; err != nil {
-		return err
-	}
+// RemoveGenres removes genres from an audiobook, records its genres revision and publishes it to the outbox
+func (r *AudiobookRepository) RemoveGenres(audiobookID uint, genreIDs []uint, userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var audiobook entity.Audiobook
+		if err := tx.First(&audiobook, audiobookID).Error; err != nil {
+			return err
+		}
+
+		var genres []entity.Genre
+		if err := tx.Where("id IN ?", genreIDs).Find(&genres).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&audiobook).Association("Genres").Delete(genres); err != nil {
+			return err
+		}
+		if err := recordCurrentGenres(tx, audiobookID, entity.RevisionUpdate, userID); err != nil {
+			return err
+		}
+		return publishAudiobook(tx, audiobookID)
+	})
+}
 
-	return r.db.Model(&audiobook).Association("Genres").Append(genres)
+// RemoveAllGenres removes all genres from an audiobook and publishes it to the outbox
+func (r *AudiobookRepository) RemoveAllGenres(audiobookID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var audiobook entity.Audiobook
+		if err := tx.First(&audiobook, audiobookID).Error; err != nil {
+			return err
+		}
+
+		// Clear all genre associations
+		if err := tx.Model(&audiobook).Association("Genres").Clear(); err != nil {
+			return err
+		}
+		return publishAudiobook(tx, audiobookID)
+	})
 }
 
-// RemoveGenres removes genres from an audiobook
-func (r *AudiobookRepository) RemoveGenres(audiobookID uint, genreIDs []uint) error {
-	var audiobook entity.Audiobook
-	if err := r.db.First(&audiobook, audiobookID).Error; err != nil {
-		return err
-	}
+// GetGenreIDs retrieves the IDs of the genres assigned to an audiobook
+func (r *AudiobookRepository) GetGenreIDs(audiobookID uint) ([]uint, error) {
+	return genreIDsOf(r.db, audiobookID)
+}
+
+// genreIDsOf retrieves the IDs of the genres assigned to an audiobook in ID order
+func genreIDsOf(db *gorm.DB, audiobookID uint) ([]uint, error) {
+	genreIDs := []uint{}
+	err := db.Table("audiobook_genres").Where("audiobook_id = ?", audiobookID).Order("genre_id ASC").Pluck("genre_id", &genreIDs).Error
+	return genreIDs, err
+}
 
+// appendGenres assigns the existing ones of genreIDs to an audiobook
+func appendGenres(tx *gorm.DB, audiobook *entity.Audiobook, genreIDs []uint) error {
 	var genres []entity.Genre
-	if err := r.db.Where("id IN ?", genreIDs).Find(&genres).Error; err != nil {
+	if err := tx.Where("id IN ?", genreIDs).Find(&genres).Error; err != nil {
 		return err
 	}
-
-	return r.db.Model(&audiobook).Association("Genres").Delete(genres)
+	if len(genres) == 0 {
+		return nil
+	}
+	return tx.Model(audiobook).Association("Genres").Append(genres)
 }
 
-// RemoveAllGenres removes all genres from an audiobook
-func (r *AudiobookRepository) RemoveAllGenres(audiobookID uint) error {
-    var audiobook entity.Audiobook
-    if err := r.db.First(&audiobook, audiobookID).Error; err != nil {
-        return err
-    }
+// workflowColumns are the columns of the publication workflow
+var workflowColumns = []string{"status", "publish_at", "published_at", "submitted_by", "approved_by", "approved_at", "review_note"}
+
+// UpdateStatus applies workflow updates to an audiobook if it is still in the from state,
+// reporting whether it was, and publishes it to the outbox if so
+func (r *AudiobookRepository) UpdateStatus(id uint, from string, updates map[string]interface{}) (bool, error) {
+	updated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Audiobook{}).Where("id = ? AND status = ?", id, from).Updates(updates)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		updated = true
+		return publishAudiobook(tx, id)
+	})
+	if err != nil {
+		return false, err
+	}
+	return updated, nil
+}
 
-    // Clear all genre associations
-    return r.db.Model(&audiobook).Association("Genres").Clear()
+// PublishDue publishes the approved audiobooks whose publish time has come, returning how many,
+// and publishes each of them to the outbox
+func (r *AudiobookRepository) PublishDue(now time.Time) (int64, error) {
+	var ids []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.Audiobook{}).
+			Where("status = ? AND approved_at IS NOT NULL AND publish_at <= ?", entity.AudiobookInReview, now).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		err = tx.Model(&entity.Audiobook{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":       entity.AudiobookPublished,
+				"published_at": gorm.Expr("publish_at"),
+			}).Error
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := publishAudiobook(tx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
 }
@@ -27,9 +27,14 @@ func NewAuthorRepository(db *gorm.DB) AuthorRepositoryInterface {
 	return &AuthorRepository{db: db}
 }
 
-// Create creates a new author
+// Create creates a new author and publishes it to the outbox
 func (r *AuthorRepository) Create(author *entity.Author) error {
-	return r.db.Create(author).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(author).Error; err != nil {
+			return err
+		}
+		return publishAuthor(tx, author.ID)
+	})
 }
 
 // GetByID retrieves an author by ID
@@ -60,14 +65,24 @@ func (r *AuthorRepository) GetAll(offset, limit int) ([]entity.Author, int64, er
 	return authors, total, nil
 }
 
-// Update updates an existing author
+// Update updates an existing author and publishes it to the outbox
 func (r *AuthorRepository) Update(author *entity.Author) error {
-	return r.db.Save(author).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(author).Error; err != nil {
+			return err
+		}
+		return publishAuthor(tx, author.ID)
+	})
 }
 
-// Delete deletes an author by ID
+// Delete deletes an author by ID and publishes the deletion to the outbox
 func (r *AuthorRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Author{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entity.Author{}, id).Error; err != nil {
+			return err
+		}
+		return publishAuthor(tx, id)
+	})
 }
 
 // SearchByName searches authors by name
@@ -28,9 +28,14 @@ func NewGenreRepository(db *gorm.DB) GenreRepositoryInterface {
 	return &GenreRepository{db: db}
 }
 
-// Create creates a new genre
+// Create creates a new genre and publishes it to the outbox
 func (r *GenreRepository) Create(genre *entity.Genre) error {
-	return r.db.Create(genre).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(genre).Error; err != nil {
+			return err
+		}
+		return publishGenre(tx, genre.ID)
+	})
 }
 
 // GetByID retrieves a genre by ID
@@ -61,14 +66,24 @@ func (r *GenreRepository) GetAll(offset, limit int) ([]entity.Genre, int64, erro
 	return genres, total, nil
 }
 
-// Update updates an existing genre
+// Update updates an existing genre and publishes it to the outbox
 func (r *GenreRepository) Update(genre *entity.Genre) error {
-	return r.db.Save(genre).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(genre).Error; err != nil {
+			return err
+		}
+		return publishGenre(tx, genre.ID)
+	})
 }
 
-// Delete deletes a genre by ID
+// Delete deletes a genre by ID and publishes the deletion to the outbox
 func (r *GenreRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Genre{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entity.Genre{}, id).Error; err != nil {
+			return err
+		}
+		return publishGenre(tx, id)
+	})
 }
 
 // SearchByName searches genres by name
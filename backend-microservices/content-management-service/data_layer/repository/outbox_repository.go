@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"content-management-service/data_layer/entity"
+	"encoding/json"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// outboxLockKey is the transaction-level advisory lock taken before an event is written. Holding it
+// until commit makes events visible in offset order, so a relay that has seen an offset has seen
+// every earlier event that will ever commit.
+const outboxLockKey = 4230817
+
+// OutboxRepositoryInterface defines the contract for outbox repository
+type OutboxRepositoryInterface interface {
+	GetAfter(offset uint, limit int) ([]entity.OutboxEvent, error)
+	GetHeadOffset() (uint, error)
+	PublishAll() (int64, error)
+}
+
+// OutboxRepository implements OutboxRepositoryInterface
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db *gorm.DB) OutboxRepositoryInterface {
+	return &OutboxRepository{db: db}
+}
+
+// GetAfter retrieves up to limit events after an offset, in offset order
+func (r *OutboxRepository) GetAfter(offset uint, limit int) ([]entity.OutboxEvent, error) {
+	var events []entity.OutboxEvent
+	err := r.db.Where("id > ?", offset).Order("id ASC").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+// GetHeadOffset returns the offset of the latest event, or 0 for an empty outbox
+func (r *OutboxRepository) GetHeadOffset() (uint, error) {
+	var head uint
+	err := r.db.Model(&entity.OutboxEvent{}).Select("COALESCE(MAX(id), 0)").Scan(&head).Error
+	return head, err
+}
+
+// PublishAll writes an upserted event for every author, reader, genre and audiobook in one
+// transaction, returning how many. Relaying them brings a consumer that missed changes made
+// before the outbox, or outside the repositories, up to date.
+func (r *OutboxRepository) PublishAll() (int64, error) {
+	var published int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		aggregates := []struct {
+			model   interface{}
+			publish func(tx *gorm.DB, id uint) error
+		}{
+			{&entity.Author{}, publishAuthor},
+			{&entity.Reader{}, publishReader},
+			{&entity.Genre{}, publishGenre},
+			{&entity.Audiobook{}, publishAudiobook},
+		}
+
+		for _, aggregate := range aggregates {
+			var ids []uint
+			if err := tx.Model(aggregate.model).Order("id ASC").Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if err := aggregate.publish(tx, id); err != nil {
+					return err
+				}
+			}
+			published += int64(len(ids))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return published, nil
+}
+
+// publishAuthor writes the current state of an author to the outbox, or a deleted event if it is gone
+func publishAuthor(tx *gorm.DB, id uint) error {
+	var author entity.Author
+	err := tx.First(&author, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return publishEvent(tx, entity.AggregateAuthor, id, entity.EventDeleted, entity.DeletedPayload{ID: id})
+	}
+	if err != nil {
+		return err
+	}
+	return publishEvent(tx, entity.AggregateAuthor, id, entity.EventUpserted, entity.NamedPayload{ID: author.ID, Name: author.Name})
+}
+
+// publishReader writes the current state of a reader to the outbox, or a deleted event if it is gone
+func publishReader(tx *gorm.DB, id uint) error {
+	var reader entity.Reader
+	err := tx.First(&reader, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return publishEvent(tx, entity.AggregateReader, id, entity.EventDeleted, entity.DeletedPayload{ID: id})
+	}
+	if err != nil {
+		return err
+	}
+	return publishEvent(tx, entity.AggregateReader, id, entity.EventUpserted, entity.NamedPayload{ID: reader.ID, Name: reader.Name})
+}
+
+// publishGenre writes the current state of a genre to the outbox, or a deleted event if it is gone
+func publishGenre(tx *gorm.DB, id uint) error {
+	var genre entity.Genre
+	err := tx.First(&genre, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return publishEvent(tx, entity.AggregateGenre, id, entity.EventDeleted, entity.DeletedPayload{ID: id})
+	}
+	if err != nil {
+		return err
+	}
+	return publishEvent(tx, entity.AggregateGenre, id, entity.EventUpserted, entity.NamedPayload{ID: genre.ID, Name: genre.Name})
+}
+
+// publishTrack writes the current state of a track to the outbox, or a deleted event if it is gone
+func publishTrack(tx *gorm.DB, id uint) error {
+	var track entity.Track
+	err := tx.First(&track, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return publishEvent(tx, entity.AggregateTrack, id, entity.EventDeleted, entity.DeletedPayload{ID: id})
+	}
+	if err != nil {
+		return err
+	}
+	return publishEvent(tx, entity.AggregateTrack, id, entity.EventUpserted, newTrackPayload(&track))
+}
+
+// publishAudiobook writes the current state of an audiobook with its genres and tracks to the
+// outbox, or a deleted event if it is gone
+func publishAudiobook(tx *gorm.DB, id uint) error {
+	var audiobook entity.Audiobook
+	err := tx.First(&audiobook, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return publishEvent(tx, entity.AggregateAudiobook, id, entity.EventDeleted, entity.DeletedPayload{ID: id})
+	}
+	if err != nil {
+		return err
+	}
+
+	payload := entity.AudiobookPayload{
+		ID:               audiobook.ID,
+		Title:            audiobook.Title,
+		AuthorID:         audiobook.AuthorID,
+		ReaderID:         audiobook.ReaderID,
+		Description:      audiobook.Description,
+		ImageURL:         audiobook.ImageURL,
+		Language:         audiobook.Language,
+		YearOfPublishing: audiobook.YearOfPublishing,
+		TotalDuration:    audiobook.TotalDuration,
+		Status:           audiobook.Status,
+		GenreIDs:         []uint{},
+		Tracks:           []entity.TrackPayload{},
+	}
+
+	err = tx.Table("audiobook_genres").Where("audiobook_id = ?", id).Order("genre_id ASC").Pluck("genre_id", &payload.GenreIDs).Error
+	if err != nil {
+		return err
+	}
+
+	var tracks []entity.Track
+	if err := tx.Where("audiobook_id = ?", id).Order("id ASC").Find(&tracks).Error; err != nil {
+		return err
+	}
+	for _, track := range tracks {
+		payload.Tracks = append(payload.Tracks, newTrackPayload(&track))
+	}
+
+	return publishEvent(tx, entity.AggregateAudiobook, id, entity.EventUpserted, payload)
+}
+
+// publishEvent writes an event to the outbox. tx must be a transaction.
+func publishEvent(tx *gorm.DB, aggregateType string, aggregateID uint, kind string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", outboxLockKey).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&entity.OutboxEvent{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     aggregateType + "." + kind,
+		Payload:       string(data),
+	}).Error
+}
+
+func newTrackPayload(track *entity.Track) entity.TrackPayload {
+	return entity.TrackPayload{
+		ID:          track.ID,
+		AudiobookID: track.AudiobookID,
+		Title:       track.Title,
+		URL:         track.URL,
+		Duration:    track.Duration,
+	}
+}
@@ -27,9 +27,14 @@ func NewReaderRepository(db *gorm.DB) ReaderRepositoryInterface {
 	return &ReaderRepository{db: db}
 }
 
-// Create creates a new reader
+// Create creates a new reader and publishes it to the outbox
 func (r *ReaderRepository) Create(reader *entity.Reader) error {
-	return r.db.Create(reader).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(reader).Error; err != nil {
+			return err
+		}
+		return publishReader(tx, reader.ID)
+	})
 }
 
 // GetByID retrieves a reader by ID
@@ -60,14 +65,24 @@ func (r *ReaderRepository) GetAll(offset, limit int) ([]entity.Reader, int64, er
 	return readers, total, nil
 }
 
-// Update updates an existing reader
+// Update updates an existing reader and publishes it to the outbox
 func (r *ReaderRepository) Update(reader *entity.Reader) error {
-	return r.db.Save(reader).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(reader).Error; err != nil {
+			return err
+		}
+		return publishReader(tx, reader.ID)
+	})
 }
 
-// Delete deletes a reader by ID
+// Delete deletes a reader by ID and publishes the deletion to the outbox
 func (r *ReaderRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Reader{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entity.Reader{}, id).Error; err != nil {
+			return err
+		}
+		return publishReader(tx, id)
+	})
 }
 
 // SearchByName searches readers by name
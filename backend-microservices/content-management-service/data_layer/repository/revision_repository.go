@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"content-management-service/data_layer/entity"
+	"encoding/json"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RevisionRepositoryInterface defines the contract for revision repository
+type RevisionRepositoryInterface interface {
+	GetByID(id uint) (*entity.Revision, error)
+	GetByAudiobookID(audiobookID uint, entityType string, offset, limit int) ([]entity.Revision, int64, error)
+	Rollback(revision *entity.Revision, userID string) (*entity.Revision, error)
+}
+
+// RevisionRepository implements RevisionRepositoryInterface
+type RevisionRepository struct {
+	db *gorm.DB
+}
+
+// NewRevisionRepository creates a new revision repository
+func NewRevisionRepository(db *gorm.DB) RevisionRepositoryInterface {
+	return &RevisionRepository{db: db}
+}
+
+// GetByID retrieves a revision by ID
+func (r *RevisionRepository) GetByID(id uint) (*entity.Revision, error) {
+	var revision entity.Revision
+	err := r.db.First(&revision, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// GetByAudiobookID retrieves the history of an audiobook, or of one entity type in it if entityType
+// is set, with pagination, newest first
+func (r *RevisionRepository) GetByAudiobookID(audiobookID uint, entityType string, offset, limit int) ([]entity.Revision, int64, error) {
+	var revisions []entity.Revision
+	var total int64
+
+	dbQuery := r.db.Model(&entity.Revision{}).Where("audiobook_id = ?", audiobookID)
+	if entityType != "" {
+		dbQuery = dbQuery.Where("entity_type = ?", entityType)
+	}
+
+	// Count total records
+	if err := dbQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	if err := dbQuery.Order("id DESC").Offset(offset).Limit(limit).Find(&revisions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return revisions, total, nil
+}
+
+// Rollback brings the entity of a revision back to the state in its snapshot, recreating it if it
+// was deleted, and records a rollback revision in the same transaction. Genres deleted since the
+// revision are left out of a restored genre assignment.
+func (r *RevisionRepository) Rollback(revision *entity.Revision, userID string) (*entity.Revision, error) {
+	rollback := &entity.Revision{
+		AudiobookID: revision.AudiobookID,
+		EntityType:  revision.EntityType,
+		EntityID:    revision.EntityID,
+		Action:      entity.RevisionRollback,
+		UserID:      userID,
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var snapshot interface{}
+		var err error
+		switch revision.EntityType {
+		case entity.RevisionAudiobook:
+			snapshot, err = rollbackAudiobook(tx, revision)
+		case entity.RevisionTrack:
+			snapshot, err = rollbackTrack(tx, revision)
+		case entity.RevisionGenres:
+			snapshot, err = rollbackGenres(tx, revision)
+		default:
+			err = errors.New("unknown revision entity type: " + revision.EntityType)
+		}
+		if err != nil {
+			return err
+		}
+
+		// Genre revisions belong to the audiobook aggregate
+		if revision.EntityType == entity.RevisionTrack {
+			err = publishTrack(tx, revision.EntityID)
+		} else {
+			err = publishAudiobook(tx, revision.AudiobookID)
+		}
+		if err != nil {
+			return err
+		}
+
+		return record(tx, rollback, snapshot)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rollback, nil
+}
+
+func rollbackAudiobook(tx *gorm.DB, revision *entity.Revision) (interface{}, error) {
+	var snapshot entity.AudiobookSnapshot
+	if err := json.Unmarshal([]byte(revision.Snapshot), &snapshot); err != nil {
+		return nil, err
+	}
+
+	var audiobook entity.Audiobook
+	err := tx.First(&audiobook, revision.EntityID).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	recreate := err != nil
+
+	if recreate {
+		// A deleted audiobook comes back as a draft and goes through review again
+		audiobook = entity.Audiobook{ID: revision.EntityID, Status: entity.AudiobookDraft}
+	}
+	audiobook.Title = snapshot.Title
+	audiobook.AuthorID = snapshot.AuthorID
+	audiobook.ReaderID = snapshot.ReaderID
+	audiobook.Description = snapshot.Description
+	audiobook.ImageURL = snapshot.ImageURL
+	audiobook.Language = snapshot.Language
+	audiobook.YearOfPublishing = snapshot.YearOfPublishing
+	audiobook.TotalDuration = snapshot.TotalDuration
+
+	if recreate {
+		err = tx.Create(&audiobook).Error
+	} else {
+		err = tx.Omit(workflowColumns...).Save(&audiobook).Error
+	}
+	return snapshot, err
+}
+
+func rollbackTrack(tx *gorm.DB, revision *entity.Revision) (interface{}, error) {
+	var snapshot entity.TrackSnapshot
+	if err := json.Unmarshal([]byte(revision.Snapshot), &snapshot); err != nil {
+		return nil, err
+	}
+
+	var track entity.Track
+	err := tx.First(&track, revision.EntityID).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	recreate := err != nil
+
+	if recreate {
+		track = entity.Track{ID: revision.EntityID}
+	}
+	track.AudiobookID = snapshot.AudiobookID
+	track.Title = snapshot.Title
+	track.URL = snapshot.URL
+	track.Duration = snapshot.Duration
+
+	if recreate {
+		err = tx.Omit(clause.Associations).Create(&track).Error
+	} else {
+		err = tx.Omit(clause.Associations).Save(&track).Error
+	}
+	return snapshot, err
+}
+
+func rollbackGenres(tx *gorm.DB, revision *entity.Revision) (interface{}, error) {
+	var snapshot entity.GenresSnapshot
+	if err := json.Unmarshal([]byte(revision.Snapshot), &snapshot); err != nil {
+		return nil, err
+	}
+
+	var audiobook entity.Audiobook
+	if err := tx.First(&audiobook, revision.EntityID).Error; err != nil {
+		return nil, err
+	}
+
+	var genres []entity.Genre
+	if len(snapshot.GenreIDs) > 0 {
+		if err := tx.Where("id IN ?", snapshot.GenreIDs).Order("id ASC").Find(&genres).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	association := tx.Model(&audiobook).Association("Genres")
+	var err error
+	if len(genres) == 0 {
+		err = association.Clear()
+	} else {
+		err = association.Replace(genres)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	restored := entity.GenresSnapshot{GenreIDs: []uint{}}
+	for _, genre := range genres {
+		restored.GenreIDs = append(restored.GenreIDs, genre.ID)
+	}
+	return restored, nil
+}
+
+// record marshals a snapshot into a revision and creates it
+func record(db *gorm.DB, revision *entity.Revision, snapshot interface{}) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	revision.Snapshot = string(data)
+	return db.Create(revision).Error
+}
+
+// recordAudiobook records a revision of the metadata of an audiobook
+func recordAudiobook(tx *gorm.DB, audiobook *entity.Audiobook, action, userID string) error {
+	return record(tx, &entity.Revision{
+		AudiobookID: audiobook.ID,
+		EntityType:  entity.RevisionAudiobook,
+		EntityID:    audiobook.ID,
+		Action:      action,
+		UserID:      userID,
+	}, entity.NewAudiobookSnapshot(audiobook))
+}
+
+// recordTrack records a revision of a track
+func recordTrack(tx *gorm.DB, track *entity.Track, action, userID string) error {
+	return record(tx, &entity.Revision{
+		AudiobookID: track.AudiobookID,
+		EntityType:  entity.RevisionTrack,
+		EntityID:    track.ID,
+		Action:      action,
+		UserID:      userID,
+	}, entity.NewTrackSnapshot(track))
+}
+
+// recordGenres records a revision of the given genre assignment of an audiobook
+func recordGenres(tx *gorm.DB, audiobookID uint, genreIDs []uint, action, userID string) error {
+	return record(tx, &entity.Revision{
+		AudiobookID: audiobookID,
+		EntityType:  entity.RevisionGenres,
+		EntityID:    audiobookID,
+		Action:      action,
+		UserID:      userID,
+	}, entity.GenresSnapshot{GenreIDs: genreIDs})
+}
+
+// recordCurrentGenres records a revision of the genres currently assigned to an audiobook
+func recordCurrentGenres(tx *gorm.DB, audiobookID uint, action, userID string) error {
+	genreIDs, err := genreIDsOf(tx, audiobookID)
+	if err != nil {
+		return err
+	}
+	return recordGenres(tx, audiobookID, genreIDs, action, userID)
+}
@@ -8,17 +8,22 @@ import (
 
 // TrackRepositoryInterface defines the contract for track repository
 type TrackRepositoryInterface interface {
-	Create(track *entity.Track) error
+	Create(track *entity.Track, userID string) error
 	GetByID(id uint) (*entity.Track, error)
 	GetByIDWithRelations(id uint) (*entity.Track, error)
+	GetPublishedByID(id uint) (*entity.Track, error)
 	GetAll(offset, limit int) ([]entity.Track, int64, error)
-	Update(track *entity.Track) error
-	Delete(id uint) error
+	Update(track *entity.Track, userID string) error
+	Delete(id uint, userID string) error
 	GetByAudiobookID(audiobookID uint) ([]entity.Track, error)
+	GetPublishedByAudiobookID(audiobookID uint) ([]entity.Track, error)
 	SearchByTitle(query string, offset, limit int) ([]entity.Track, int64, error)
 	DeleteByAudiobookID(audiobookID uint) error
 }
 
+// publishedAudiobookTracks limits a track query to the tracks of published audiobooks
+const publishedAudiobookTracks = "audiobook_id IN (SELECT id FROM audiobooks WHERE status = '" + entity.AudiobookPublished + "')"
+
 // TrackRepository implements TrackRepositoryInterface
 type TrackRepository struct {
 	db *gorm.DB
@@ -29,9 +34,17 @@ func NewTrackRepository(db *gorm.DB) TrackRepositoryInterface {
 	return &TrackRepository{db: db}
 }
 
-// Create creates a new track
-func (r *TrackRepository) Create(track *entity.Track) error {
-	return r.db.Create(track).Error
+// Create creates a new track, records its revision and publishes it to the outbox
+func (r *TrackRepository) Create(track *entity.Track, userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(track).Error; err != nil {
+			return err
+		}
+		if err := recordTrack(tx, track, entity.RevisionCreate, userID); err != nil {
+			return err
+		}
+		return publishTrack(tx, track.ID)
+	})
 }
 
 // GetByID retrieves a track by ID
@@ -54,32 +67,66 @@ func (r *TrackRepository) GetByIDWithRelations(id uint) (*entity.Track, error) {
 	return &track, nil
 }
 
-// GetAll retrieves all tracks with pagination
+// GetPublishedByID retrieves a track of a published audiobook by ID
+func (r *TrackRepository) GetPublishedByID(id uint) (*entity.Track, error) {
+	var track entity.Track
+	err := r.db.Where(publishedAudiobookTracks).First(&track, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &track, nil
+}
+
+// GetAll retrieves all tracks of published audiobooks with pagination
 func (r *TrackRepository) GetAll(offset, limit int) ([]entity.Track, int64, error) {
 	var tracks []entity.Track
 	var total int64
 
+	dbQuery := r.db.Model(&entity.Track{}).Where(publishedAudiobookTracks)
+
 	// Count total records
-	if err := r.db.Model(&entity.Track{}).Count(&total).Error; err != nil {
+	if err := dbQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get paginated results
-	if err := r.db.Offset(offset).Limit(limit).Find(&tracks).Error; err != nil {
+	if err := dbQuery.Offset(offset).Limit(limit).Find(&tracks).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return tracks, total, nil
 }
 
-// Update updates an existing track
-func (r *TrackRepository) Update(track *entity.Track) error {
-	return r.db.Save(track).Error
+// Update updates an existing track, records its revision and publishes it to the outbox
+func (r *TrackRepository) Update(track *entity.Track, userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(track).Error; err != nil {
+			return err
+		}
+		if err := recordTrack(tx, track, entity.RevisionUpdate, userID); err != nil {
+			return err
+		}
+		return publishTrack(tx, track.ID)
+	})
 }
 
-// Delete deletes a track by ID
-func (r *TrackRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Track{}, id).Error
+// Delete deletes a track by ID, keeping its last state in a revision, and publishes the deletion to
+// the outbox
+func (r *TrackRepository) Delete(id uint, userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var track entity.Track
+		if err := tx.First(&track, id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&entity.Track{}, id).Error; err != nil {
+			return err
+		}
+		if err := recordTrack(tx, &track, entity.RevisionDelete, userID); err != nil {
+			return err
+		}
+		return publishTrack(tx, id)
+	})
 }
 
 // GetByAudiobookID retrieves all tracks for a specific audiobook
@@ -89,6 +136,13 @@ func (r *TrackRepository) GetByAudiobookID(audiobookID uint) ([]entity.Track, er
 	return tracks, err
 }
 
+// GetPublishedByAudiobookID retrieves all tracks for a specific audiobook if it is published
+func (r *TrackRepository) GetPublishedByAudiobookID(audiobookID uint) ([]entity.Track, error) {
+	var tracks []entity.Track
+	err := r.db.Where("audiobook_id = ?", audiobookID).Where(publishedAudiobookTracks).Order("id ASC").Find(&tracks).Error
+	return tracks, err
+}
+
 // SearchByTitle searches tracks by title
 func (r *TrackRepository) SearchByTitle(query string, offset, limit int) ([]entity.Track, int64, error) {
 	var tracks []entity.Track
@@ -112,7 +166,25 @@ func (r *TrackRepository) SearchByTitle(query string, offset, limit int) ([]enti
 	return tracks, total, nil
 }
 
-// DeleteByAudiobookID deletes all tracks for a specific audiobook
+// DeleteByAudiobookID deletes all tracks for a specific audiobook and publishes their deletion to the outbox
 func (r *TrackRepository) DeleteByAudiobookID(audiobookID uint) error {
-	return r.db.Where("audiobook_id = ?", audiobookID).Delete(&entity.Track{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		if err := tx.Model(&entity.Track{}).Where("audiobook_id = ?", audiobookID).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := tx.Where("id IN ?", ids).Delete(&entity.Track{}).Error; err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := publishTrack(tx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
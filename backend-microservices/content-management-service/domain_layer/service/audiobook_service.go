@@ -4,8 +4,10 @@ import (
 	"content-management-service/data_layer/dto"
 	"content-management-service/data_layer/entity"
 	"content-management-service/data_layer/repository"
+	"context"
 	"errors"
-	"fmt"
+	"log"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -15,8 +17,6 @@ type AudiobookService struct {
 	authorRepo    repository.AuthorRepositoryInterface
 	readerRepo    repository.ReaderRepositoryInterface
 	genreRepo     repository.GenreRepositoryInterface
-	trackRepo     repository.TrackRepositoryInterface
-	analyticsRepo repository.AnalyticsRepositoryInterface
 }
 
 func NewAudiobookService(
@@ -24,21 +24,17 @@ func NewAudiobookService(
 	authorRepo repository.AuthorRepositoryInterface,
 	readerRepo repository.ReaderRepositoryInterface,
 	genreRepo repository.GenreRepositoryInterface,
-	trackRepo repository.TrackRepositoryInterface,
-	analyticsRepo repository.AnalyticsRepositoryInterface,
 ) *AudiobookService {
 	return &AudiobookService{
 		audiobookRepo: audiobookRepo,
 		authorRepo:    authorRepo,
 		readerRepo:    readerRepo,
 		genreRepo:     genreRepo,
-		trackRepo:     trackRepo,
-		analyticsRepo: analyticsRepo,
 	}
 }
 
-// CreateAudiobook creates a new audiobook
-func (s *AudiobookService) CreateAudiobook(req dto.CreateAudiobookRequest) (*dto.AudiobookResponse, error) {
+// CreateAudiobook creates a new draft audiobook
+func (s *AudiobookService) CreateAudiobook(req dto.CreateAudiobookRequest, userID string) (*dto.AudiobookResponse, error) {
 	// Validate author exists
 	_, err := s.authorRepo.GetByID(req.AuthorID)
 	if err != nil {
@@ -67,19 +63,14 @@ func (s *AudiobookService) CreateAudiobook(req dto.CreateAudiobookRequest) (*dto
 		Language:         req.Language,
 		YearOfPublishing: req.YearOfPublishing,
 		TotalDuration:    req.TotalDuration,
+		Status:           entity.AudiobookDraft,
 	}
 
-	if err := s.audiobookRepo.Create(&audiobook); err != nil {
+	// Create it with its genres, if provided, and their revisions
+	if err := s.audiobookRepo.Create(&audiobook, req.GenreIDs, userID); err != nil {
 		return nil, err
 	}
 
-	// Associate genres if provided
-	if len(req.GenreIDs) > 0 {
-		if err := s.audiobookRepo.AssignGenres(audiobook.ID, req.GenreIDs); err != nil {
-			return nil, err
-		}
-	}
-
 	// Get audiobook with relations for response
 	audiobookWithRelations, err := s.audiobookRepo.GetByIDWithRelations(audiobook.ID)
 	if err != nil {
@@ -89,9 +80,9 @@ func (s *AudiobookService) CreateAudiobook(req dto.CreateAudiobookRequest) (*dto
 	return s.convertToAudiobookResponse(audiobookWithRelations), nil
 }
 
-// GetAudiobookByID retrieves an audiobook by ID with all relationships
+// GetAudiobookByID retrieves a published audiobook by ID with all relationships
 func (s *AudiobookService) GetAudiobookByID(id uint) (*dto.AudiobookResponse, error) {
-	audiobook, err := s.audiobookRepo.GetByIDWithRelations(id)
+	audiobook, err := s.audiobookRepo.GetPublishedByIDWithRelations(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("audiobook not found")
@@ -136,8 +127,8 @@ func (s *AudiobookService) GetAllAudiobooks(req dto.PaginationRequest) (*dto.Lis
 	}, nil
 }
 
-// UpdateAudiobook updates an existing audiobook
-func (s *AudiobookService) UpdateAudiobook(id uint, req dto.UpdateAudiobookRequest) (*dto.AudiobookResponse, error) {
+// UpdateAudiobook updates an existing audiobook that is not in review
+func (s *AudiobookService) UpdateAudiobook(id uint, req dto.UpdateAudiobookRequest, userID string) (*dto.AudiobookResponse, error) {
 	// Get existing audiobook
 	audiobook, err := s.audiobookRepo.GetByID(id)
 	if err != nil {
@@ -147,6 +138,11 @@ func (s *AudiobookService) UpdateAudiobook(id uint, req dto.UpdateAudiobookReque
 		return nil, err
 	}
 
+	// What a reviewer approved is what gets published
+	if audiobook.Status == entity.AudiobookInReview {
+		return nil, errors.New("audiobook is in review")
+	}
+
 	// Validate author exists
 	_, err = s.authorRepo.GetByID(req.AuthorID)
 	if err != nil {
@@ -175,22 +171,11 @@ func (s *AudiobookService) UpdateAudiobook(id uint, req dto.UpdateAudiobookReque
 	audiobook.YearOfPublishing = req.YearOfPublishing
 	audiobook.TotalDuration = req.TotalDuration
 
-	if err := s.audiobookRepo.Update(audiobook); err != nil {
+	// Save it, replacing its genres if provided, with their revisions
+	if err := s.audiobookRepo.Update(audiobook, req.GenreIDs, userID); err != nil {
 		return nil, err
 	}
 
-	// Update genres if provided
-	if len(req.GenreIDs) > 0 {
-		// Remove all existing genres first
-		if err := s.audiobookRepo.RemoveGenres(audiobook.ID, []uint{}); err != nil {
-			return nil, err
-		}
-		// Add new genres
-		if err := s.audiobookRepo.AssignGenres(audiobook.ID, req.GenreIDs); err != nil {
-			return nil, err
-		}
-	}
-
 	// Get updated audiobook with relations
 	updatedAudiobook, err := s.audiobookRepo.GetByIDWithRelations(audiobook.ID)
 	if err != nil {
@@ -200,40 +185,18 @@ func (s *AudiobookService) UpdateAudiobook(id uint, req dto.UpdateAudiobookReque
 	return s.convertToAudiobookResponse(updatedAudiobook), nil
 }
 
-// DeleteAudiobook deletes an audiobook
-func (s *AudiobookService) DeleteAudiobook(id uint) error {
+// DeleteAudiobook deletes an audiobook with its tracks, genre associations and analytics, keeping
+// the last state of it, its tracks and its genres in its revisions
+func (s *AudiobookService) DeleteAudiobook(id uint, userID string) error {
 	// Check if audiobook exists
-	_, err := s.audiobookRepo.GetByID(id)
-	if err != nil {
+	if _, err := s.audiobookRepo.GetByID(id); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("audiobook not found")
 		}
 		return err
 	}
 
-	// ✅ Clean up all related data before deleting audiobook
-
-	// 1. Remove all genre associations
-	if err := s.audiobookRepo.RemoveAllGenres(id); err != nil {
-		return fmt.Errorf("failed to remove genre associations: %v", err)
-	}
-
-	// 2. Delete all tracks for this audiobook
-	if err := s.trackRepo.DeleteByAudiobookID(id); err != nil {
-		return fmt.Errorf("failed to delete tracks: %v", err)
-	}
-
-	// 3. Delete all analytics records for this audiobook
-	if err := s.analyticsRepo.DeleteByAudiobookID(id); err != nil {
-		return fmt.Errorf("failed to delete analytics: %v", err)
-	}
-
-	// 4. Finally delete the audiobook itself
-	if err := s.audiobookRepo.Delete(id); err != nil {
-		return fmt.Errorf("failed to delete audiobook: %v", err)
-	}
-
-	return nil
+	return s.audiobookRepo.Delete(id, userID)
 }
 
 // SearchAudiobooks searches audiobooks by title
@@ -314,6 +277,13 @@ func (s *AudiobookService) convertToAudiobookResponse(audiobook *entity.Audioboo
 		Language:         audiobook.Language,
 		YearOfPublishing: audiobook.YearOfPublishing,
 		TotalDuration:    audiobook.TotalDuration,
+		Status:           audiobook.Status,
+		PublishAt:        audiobook.PublishAt,
+		PublishedAt:      audiobook.PublishedAt,
+		SubmittedBy:      audiobook.SubmittedBy,
+		ApprovedBy:       audiobook.ApprovedBy,
+		ApprovedAt:       audiobook.ApprovedAt,
+		ReviewNote:       audiobook.ReviewNote,
 		Author: dto.AuthorResponse{
 			ID:   audiobook.Author.ID,
 			Name: audiobook.Author.Name,
@@ -374,15 +344,18 @@ func (s *AudiobookService) convertToAudiobookListResponse(audiobook *entity.Audi
 	}
 
 	return dto.AudiobookListResponse{
-		ID:                audiobook.ID,
-		Title:             audiobook.Title,
-		Author:            author,
-		Reader:            reader,
-		ImageURL:          audiobook.ImageURL,
-		Language:          audiobook.Language,
-		YearOfPublishing:  audiobook.YearOfPublishing,  // Tambahkan ini
-		TotalDuration:     audiobook.TotalDuration,
-		Genres:            genres,
+		ID:               audiobook.ID,
+		Title:            audiobook.Title,
+		Author:           author,
+		Reader:           reader,
+		ImageURL:         audiobook.ImageURL,
+		Language:         audiobook.Language,
+		YearOfPublishing: audiobook.YearOfPublishing, // Tambahkan ini
+		TotalDuration:    audiobook.TotalDuration,
+		Genres:           genres,
+		Status:           audiobook.Status,
+		PublishAt:        audiobook.PublishAt,
+		PublishedAt:      audiobook.PublishedAt,
 	}
 }
 
@@ -434,15 +407,18 @@ func (s *AudiobookService) GetAudiobooks(filter dto.AudiobookFilter, page, limit
 }
 
 // AddGenresToAudiobook adds genres to an audiobook
-func (s *AudiobookService) AddGenresToAudiobook(audiobookID uint, genreIDs []uint) error {
-	// Check if audiobook exists
-	_, err := s.audiobookRepo.GetByID(audiobookID)
+func (s *AudiobookService) AddGenresToAudiobook(audiobookID uint, genreIDs []uint, userID string) error {
+	// Check if audiobook exists and can be edited
+	audiobook, err := s.audiobookRepo.GetByID(audiobookID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("audiobook not found")
 		}
 		return err
 	}
+	if audiobook.Status == entity.AudiobookInReview {
+		return errors.New("audiobook is in review")
+	}
 
 	// Validate that all genres exist
 	for _, genreID := range genreIDs {
@@ -455,19 +431,213 @@ func (s *AudiobookService) AddGenresToAudiobook(audiobookID uint, genreIDs []uin
 		}
 	}
 
-	return s.audiobookRepo.AssignGenres(audiobookID, genreIDs)
+	return s.audiobookRepo.AssignGenres(audiobookID, genreIDs, userID)
 }
 
 // RemoveGenresFromAudiobook removes genres from an audiobook
-func (s *AudiobookService) RemoveGenresFromAudiobook(audiobookID uint, genreIDs []uint) error {
-	// Check if audiobook exists
-	_, err := s.audiobookRepo.GetByID(audiobookID)
+func (s *AudiobookService) RemoveGenresFromAudiobook(audiobookID uint, genreIDs []uint, userID string) error {
+	// Check if audiobook exists and can be edited
+	audiobook, err := s.audiobookRepo.GetByID(audiobookID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("audiobook not found")
 		}
 		return err
 	}
+	if audiobook.Status == entity.AudiobookInReview {
+		return errors.New("audiobook is in review")
+	}
+
+	return s.audiobookRepo.RemoveGenres(audiobookID, genreIDs, userID)
+}
+
+// GetEditorialAudiobooks retrieves audiobooks in every publication state, or in one state if status is set
+func (s *AudiobookService) GetEditorialAudiobooks(status string, page, limit int) (*dto.ListResponse, error) {
+	switch status {
+	case "", entity.AudiobookDraft, entity.AudiobookInReview, entity.AudiobookPublished, entity.AudiobookUnpublished:
+	default:
+		return nil, errors.New("invalid status")
+	}
+
+	// Calculate offset
+	offset := (page - 1) * limit
+
+	audiobooks, total, err := s.audiobookRepo.GetByStatus(status, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response format
+	var audiobookResponses []dto.AudiobookListResponse
+	for _, audiobook := range audiobooks {
+		audiobookResponses = append(audiobookResponses, s.convertToAudiobookListResponse(&audiobook))
+	}
+
+	// Calculate total pages
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &dto.ListResponse{
+		Items: audiobookResponses,
+		Pagination: dto.PaginationResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// GetEditorialAudiobookByID retrieves an audiobook in any publication state by ID with all relationships
+func (s *AudiobookService) GetEditorialAudiobookByID(id uint) (*dto.AudiobookResponse, error) {
+	audiobook, err := s.audiobookRepo.GetByIDWithRelations(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("audiobook not found")
+		}
+		return nil, err
+	}
+
+	return s.convertToAudiobookResponse(audiobook), nil
+}
+
+// SubmitAudiobook submits a draft or unpublished audiobook for review, to be published at publishAt
+// once approved, or right away without a publish time
+func (s *AudiobookService) SubmitAudiobook(id uint, userID string, publishAt *time.Time) (*dto.AudiobookResponse, error) {
+	audiobook, err := s.audiobookRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("audiobook not found")
+		}
+		return nil, err
+	}
+
+	if audiobook.Status != entity.AudiobookDraft && audiobook.Status != entity.AudiobookUnpublished {
+		return nil, errors.New("only draft or unpublished audiobooks can be submitted")
+	}
+
+	return s.transition(audiobook, map[string]interface{}{
+		"status":       entity.AudiobookInReview,
+		"publish_at":   publishAt,
+		"submitted_by": userID,
+		"approved_by":  "",
+		"approved_at":  nil,
+		"review_note":  "",
+	})
+}
+
+// ApproveAudiobook approves an audiobook in review. Reviewers cannot approve their own submissions.
+// The audiobook is published right away unless its publish time is still to come, in which case
+// the publish scheduler releases it then.
+func (s *AudiobookService) ApproveAudiobook(id uint, userID string) (*dto.AudiobookResponse, error) {
+	audiobook, err := s.audiobookRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("audiobook not found")
+		}
+		return nil, err
+	}
+
+	if audiobook.Status != entity.AudiobookInReview || audiobook.ApprovedAt != nil {
+		return nil, errors.New("only audiobooks awaiting review can be approved")
+	}
+	if audiobook.SubmittedBy == userID {
+		return nil, errors.New("audiobooks cannot be approved by their submitter")
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"approved_by": userID,
+		"approved_at": now,
+	}
+	if audiobook.PublishAt == nil || !audiobook.PublishAt.After(now) {
+		updates["status"] = entity.AudiobookPublished
+		updates["published_at"] = now
+	}
+
+	return s.transition(audiobook, updates)
+}
+
+// RejectAudiobook sends an audiobook in review back to draft with a note. It also withdraws
+// submissions and cancels scheduled releases.
+func (s *AudiobookService) RejectAudiobook(id uint, note string) (*dto.AudiobookResponse, error) {
+	audiobook, err := s.audiobookRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("audiobook not found")
+		}
+		return nil, err
+	}
+
+	if audiobook.Status != entity.AudiobookInReview {
+		return nil, errors.New("only audiobooks in review can be rejected")
+	}
+
+	return s.transition(audiobook, map[string]interface{}{
+		"status":      entity.AudiobookDraft,
+		"approved_by": "",
+		"approved_at": nil,
+		"review_note": note,
+	})
+}
+
+// UnpublishAudiobook takes a published audiobook off the public routes
+func (s *AudiobookService) UnpublishAudiobook(id uint) (*dto.AudiobookResponse, error) {
+	audiobook, err := s.audiobookRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("audiobook not found")
+		}
+		return nil, err
+	}
+
+	if audiobook.Status != entity.AudiobookPublished {
+		return nil, errors.New("only published audiobooks can be unpublished")
+	}
+
+	return s.transition(audiobook, map[string]interface{}{
+		"status": entity.AudiobookUnpublished,
+	})
+}
+
+// PublishDueAudiobooks publishes the approved audiobooks whose publish time has come
+func (s *AudiobookService) PublishDueAudiobooks() (int64, error) {
+	return s.audiobookRepo.PublishDue(time.Now())
+}
+
+// RunPublishScheduler publishes due audiobooks every interval until ctx is cancelled
+func (s *AudiobookService) RunPublishScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			published, err := s.PublishDueAudiobooks()
+			if err != nil {
+				log.Printf("Publish scheduler: %v", err)
+				continue
+			}
+			if published > 0 {
+				log.Printf("Publish scheduler: published %d audiobooks", published)
+			}
+		}
+	}
+}
+
+// transition applies workflow updates to an audiobook unless its state changed since it was read
+func (s *AudiobookService) transition(audiobook *entity.Audiobook, updates map[string]interface{}) (*dto.AudiobookResponse, error) {
+	updated, err := s.audiobookRepo.UpdateStatus(audiobook.ID, audiobook.Status, updates)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, errors.New("audiobook status changed, try again")
+	}
 
-	return s.audiobookRepo.RemoveGenres(audiobookID, genreIDs)
+	return s.GetEditorialAudiobookByID(audiobook.ID)
 }
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"bytes"
+	"content-management-service/data_layer/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// ReplicationSource identifies this service to the catalog service replication consumer
+const ReplicationSource = "content-management"
+
+// OutboxRelayService delivers outbox events to the catalog service replication consumer in offset
+// order. The consumer owns the offset: the relay starts from whatever offset the consumer reports and
+// follows it after a replay.
+type OutboxRelayService struct {
+	outboxRepo repository.OutboxRepositoryInterface
+	baseURL    string
+	apiKey     string
+	batchSize  int
+	httpClient *http.Client
+	offset     uint
+}
+
+// ReplicationEvent represents an outbox event as sent to the replication consumer
+type ReplicationEvent struct {
+	Offset        uint            `json:"offset"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uint            `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// ReplicationBatch represents a batch of events following the After offset
+type ReplicationBatch struct {
+	Source     string             `json:"source"`
+	After      uint               `json:"after"`
+	HeadOffset uint               `json:"head_offset"`
+	Events     []ReplicationEvent `json:"events"`
+}
+
+// ReplicationOffsetResponse represents the consumer's offset after a batch, or its current offset
+// when it rejected one
+type ReplicationOffsetResponse struct {
+	Offset uint   `json:"offset"`
+	Error  string `json:"error,omitempty"`
+}
+
+// NewOutboxRelayService creates a new outbox relay service
+func NewOutboxRelayService(outboxRepo repository.OutboxRepositoryInterface, baseURL, apiKey string, batchSize int) *OutboxRelayService {
+	return &OutboxRelayService{
+		outboxRepo: outboxRepo,
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		batchSize:  batchSize,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// Relay sends the events after the consumer's offset in batches until it is caught up, returning how
+// many were sent. A batch is sent even when there are no events, so the consumer can tell how far
+// behind it is.
+func (s *OutboxRelayService) Relay(ctx context.Context) (int, error) {
+	relayed := 0
+
+	for {
+		events, err := s.outboxRepo.GetAfter(s.offset, s.batchSize)
+		if err != nil {
+			return relayed, err
+		}
+		head, err := s.outboxRepo.GetHeadOffset()
+		if err != nil {
+			return relayed, err
+		}
+
+		batch := ReplicationBatch{
+			Source:     ReplicationSource,
+			After:      s.offset,
+			HeadOffset: head,
+			Events:     []ReplicationEvent{},
+		}
+		for _, event := range events {
+			batch.Events = append(batch.Events, ReplicationEvent{
+				Offset:        event.ID,
+				AggregateType: event.AggregateType,
+				AggregateID:   event.AggregateID,
+				EventType:     event.EventType,
+				Payload:       json.RawMessage(event.Payload),
+				CreatedAt:     event.CreatedAt,
+			})
+		}
+
+		offset, accepted, err := s.send(ctx, &batch)
+		if err != nil {
+			return relayed, err
+		}
+		if !accepted {
+			// The consumer is elsewhere, after a replay or another relay; continue from its offset
+			if offset == s.offset {
+				return relayed, fmt.Errorf("consumer rejected batch after offset %d", s.offset)
+			}
+			s.offset = offset
+			continue
+		}
+
+		s.offset = offset
+		relayed += len(events)
+		if len(events) < s.batchSize {
+			return relayed, nil
+		}
+	}
+}
+
+// RunRelay relays the outbox every interval until ctx is cancelled
+func (s *OutboxRelayService) RunRelay(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			relayed, err := s.Relay(ctx)
+			if err != nil {
+				log.Printf("Outbox relay: %v", err)
+				continue
+			}
+			if relayed > 0 {
+				log.Printf("Outbox relay: relayed %d events", relayed)
+			}
+		}
+	}
+}
+
+// PublishSnapshot writes the current state of every author, reader, genre and audiobook to the
+// outbox, returning how many aggregates were published
+func (s *OutboxRelayService) PublishSnapshot() (int64, error) {
+	return s.outboxRepo.PublishAll()
+}
+
+// send posts a batch to the consumer and returns its offset, and whether it accepted the batch
+func (s *OutboxRelayService) send(ctx context.Context, batch *ReplicationBatch) (uint, bool, error) {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to encode batch: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/replication/events", s.baseURL)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("X-API-Key", s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var offsetResponse ReplicationOffsetResponse
+	decodeErr := json.NewDecoder(resp.Body).Decode(&offsetResponse)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusConflict:
+		if decodeErr != nil {
+			return 0, false, fmt.Errorf("failed to decode response: %w", decodeErr)
+		}
+		return offsetResponse.Offset, resp.StatusCode == http.StatusOK, nil
+	default:
+		if offsetResponse.Error != "" {
+			return 0, false, fmt.Errorf("replication failed with status %d: %s", resp.StatusCode, offsetResponse.Error)
+		}
+		return 0, false, fmt.Errorf("replication failed with status: %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"content-management-service/data_layer/dto"
+	"content-management-service/data_layer/entity"
+	"content-management-service/data_layer/repository"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+type RevisionService struct {
+	revisionRepo  repository.RevisionRepositoryInterface
+	audiobookRepo repository.AudiobookRepositoryInterface
+	authorRepo    repository.AuthorRepositoryInterface
+	readerRepo    repository.ReaderRepositoryInterface
+}
+
+func NewRevisionService(
+	revisionRepo repository.RevisionRepositoryInterface,
+	audiobookRepo repository.AudiobookRepositoryInterface,
+	authorRepo repository.AuthorRepositoryInterface,
+	readerRepo repository.ReaderRepositoryInterface,
+) *RevisionService {
+	return &RevisionService{
+		revisionRepo:  revisionRepo,
+		audiobookRepo: audiobookRepo,
+		authorRepo:    authorRepo,
+		readerRepo:    readerRepo,
+	}
+}
+
+// GetAudiobookRevisions retrieves the revisions of an audiobook, its tracks and its genres, newest
+// first, optionally only those of one entity type. Deleted audiobooks keep their history.
+func (s *RevisionService) GetAudiobookRevisions(audiobookID uint, entityType string, page, limit int) (*dto.ListResponse, error) {
+	switch entityType {
+	case "", entity.RevisionAudiobook, entity.RevisionTrack, entity.RevisionGenres:
+	default:
+		return nil, errors.New("invalid entity type")
+	}
+
+	// Calculate offset
+	offset := (page - 1) * limit
+
+	revisions, total, err := s.revisionRepo.GetByAudiobookID(audiobookID, entityType, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response format
+	var revisionResponses []dto.RevisionResponse
+	for _, revision := range revisions {
+		revisionResponses = append(revisionResponses, s.convertToRevisionResponse(&revision))
+	}
+
+	// Calculate total pages
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &dto.ListResponse{
+		Items: revisionResponses,
+		Pagination: dto.PaginationResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// GetRevisionByID retrieves a revision by ID
+func (s *RevisionService) GetRevisionByID(id uint) (*dto.RevisionResponse, error) {
+	revision, err := s.getRevision(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToRevisionResponse(revision)
+	return &response, nil
+}
+
+// DiffRevisions compares two revisions of the same entity field by field
+func (s *RevisionService) DiffRevisions(fromID, toID uint) (*dto.RevisionDiffResponse, error) {
+	from, err := s.getRevision(fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.getRevision(toID)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.EntityType != to.EntityType || from.EntityID != to.EntityID {
+		return nil, errors.New("revisions belong to different entities")
+	}
+
+	var fromFields, toFields map[string]interface{}
+	if err := json.Unmarshal([]byte(from.Snapshot), &fromFields); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(to.Snapshot), &toFields); err != nil {
+		return nil, err
+	}
+
+	// Compare every field of either snapshot, in a stable order
+	var fields []string
+	for field := range fromFields {
+		fields = append(fields, field)
+	}
+	for field := range toFields {
+		if _, ok := fromFields[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := []dto.RevisionFieldChange{}
+	for _, field := range fields {
+		if !reflect.DeepEqual(fromFields[field], toFields[field]) {
+			changes = append(changes, dto.RevisionFieldChange{
+				Field: field,
+				From:  fromFields[field],
+				To:    toFields[field],
+			})
+		}
+	}
+
+	return &dto.RevisionDiffResponse{
+		EntityType: from.EntityType,
+		EntityID:   from.EntityID,
+		From:       s.convertToRevisionResponse(from),
+		To:         s.convertToRevisionResponse(to),
+		Changes:    changes,
+	}, nil
+}
+
+// RollbackToRevision brings the entity of a revision back to the state it recorded, recreating it
+// if it was deleted, and records the rollback as a new revision. A deleted audiobook comes back as
+// a draft; its tracks and genres are rolled back separately, after it.
+func (s *RevisionService) RollbackToRevision(id uint, userID string) (*dto.RevisionResponse, error) {
+	revision, err := s.getRevision(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// To undo a delete, roll back to the revision before it
+	if revision.Action == entity.RevisionDelete {
+		return nil, errors.New("cannot roll back to a delete revision")
+	}
+
+	audiobook, err := s.audiobookRepo.GetByID(revision.AudiobookID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if audiobook == nil && revision.EntityType != entity.RevisionAudiobook {
+		return nil, errors.New("audiobook not found")
+	}
+	if audiobook != nil && audiobook.Status == entity.AudiobookInReview {
+		return nil, errors.New("audiobook is in review")
+	}
+
+	if revision.EntityType == entity.RevisionAudiobook {
+		var snapshot entity.AudiobookSnapshot
+		if err := json.Unmarshal([]byte(revision.Snapshot), &snapshot); err != nil {
+			return nil, err
+		}
+		if _, err := s.authorRepo.GetByID(snapshot.AuthorID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("author not found")
+			}
+			return nil, err
+		}
+		if _, err := s.readerRepo.GetByID(snapshot.ReaderID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("reader not found")
+			}
+			return nil, err
+		}
+	}
+
+	rollback, err := s.revisionRepo.Rollback(revision, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToRevisionResponse(rollback)
+	return &response, nil
+}
+
+// Helper methods
+
+func (s *RevisionService) getRevision(id uint) (*entity.Revision, error) {
+	revision, err := s.revisionRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("revision not found")
+		}
+		return nil, err
+	}
+	return revision, nil
+}
+
+func (s *RevisionService) convertToRevisionResponse(revision *entity.Revision) dto.RevisionResponse {
+	return dto.RevisionResponse{
+		ID:          revision.ID,
+		AudiobookID: revision.AudiobookID,
+		EntityType:  revision.EntityType,
+		EntityID:    revision.EntityID,
+		Action:      revision.Action,
+		UserID:      revision.UserID,
+		Snapshot:    json.RawMessage(revision.Snapshot),
+		CreatedAt:   revision.CreatedAt,
+	}
+}
@@ -10,15 +10,20 @@ import (
 )
 
 type TrackService struct {
-	trackRepo repository.TrackRepositoryInterface
+	trackRepo     repository.TrackRepositoryInterface
+	audiobookRepo repository.AudiobookRepositoryInterface
 }
 
-func NewTrackService(trackRepo repository.TrackRepositoryInterface) *TrackService {
-	return &TrackService{trackRepo: trackRepo}
+func NewTrackService(trackRepo repository.TrackRepositoryInterface, audiobookRepo repository.AudiobookRepositoryInterface) *TrackService {
+	return &TrackService{trackRepo: trackRepo, audiobookRepo: audiobookRepo}
 }
 
-// CreateTrack creates a new track
-func (s *TrackService) CreateTrack(req dto.CreateTrackRequest) (*dto.TrackResponse, error) {
+// CreateTrack creates a new track in an audiobook that is not in review
+func (s *TrackService) CreateTrack(req dto.CreateTrackRequest, userID string) (*dto.TrackResponse, error) {
+	if err := s.checkAudiobookEditable(req.AudiobookID); err != nil {
+		return nil, err
+	}
+
 	track := entity.Track{
 		AudiobookID: req.AudiobookID,
 		Title:       req.Title,
@@ -26,7 +31,7 @@ func (s *TrackService) CreateTrack(req dto.CreateTrackRequest) (*dto.TrackRespon
 		Duration:    req.Duration,
 	}
 
-	if err := s.trackRepo.Create(&track); err != nil {
+	if err := s.trackRepo.Create(&track, userID); err != nil {
 		return nil, err
 	}
 
@@ -38,9 +43,9 @@ func (s *TrackService) CreateTrack(req dto.CreateTrackRequest) (*dto.TrackRespon
 	}, nil
 }
 
-// GetTrackByID retrieves a track by ID
+// GetTrackByID retrieves a track of a published audiobook by ID
 func (s *TrackService) GetTrackByID(id uint) (*dto.TrackResponse, error) {
-	track, err := s.trackRepo.GetByID(id)
+	track, err := s.trackRepo.GetPublishedByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("track not found")
@@ -56,7 +61,7 @@ func (s *TrackService) GetTrackByID(id uint) (*dto.TrackResponse, error) {
 	}, nil
 }
 
-// GetAllTracks retrieves all tracks with pagination
+// GetAllTracks retrieves all tracks of published audiobooks with pagination
 func (s *TrackService) GetAllTracks(req dto.PaginationRequest) (*dto.ListResponse, error) {
 	// Calculate offset
 	offset := (req.Page - 1) * req.Limit
@@ -95,8 +100,8 @@ func (s *TrackService) GetAllTracks(req dto.PaginationRequest) (*dto.ListRespons
 	}, nil
 }
 
-// UpdateTrack updates an existing track
-func (s *TrackService) UpdateTrack(id uint, req dto.UpdateTrackRequest) (*dto.TrackResponse, error) {
+// UpdateTrack updates an existing track of an audiobook that is not in review
+func (s *TrackService) UpdateTrack(id uint, req dto.UpdateTrackRequest, userID string) (*dto.TrackResponse, error) {
 	track, err := s.trackRepo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -104,12 +109,15 @@ func (s *TrackService) UpdateTrack(id uint, req dto.UpdateTrackRequest) (*dto.Tr
 		}
 		return nil, err
 	}
+	if err := s.checkAudiobookEditable(track.AudiobookID); err != nil {
+		return nil, err
+	}
 
 	track.Title = req.Title
 	track.URL = req.URL
 	track.Duration = req.Duration
 
-	if err := s.trackRepo.Update(track); err != nil {
+	if err := s.trackRepo.Update(track, userID); err != nil {
 		return nil, err
 	}
 
@@ -121,17 +129,20 @@ func (s *TrackService) UpdateTrack(id uint, req dto.UpdateTrackRequest) (*dto.Tr
 	}, nil
 }
 
-// DeleteTrack deletes a track
-func (s *TrackService) DeleteTrack(id uint) error {
-	_, err := s.trackRepo.GetByID(id)
+// DeleteTrack deletes a track of an audiobook that is not in review
+func (s *TrackService) DeleteTrack(id uint, userID string) error {
+	track, err := s.trackRepo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("track not found")
 		}
 		return err
 	}
+	if err := s.checkAudiobookEditable(track.AudiobookID); err != nil {
+		return err
+	}
 
-	return s.trackRepo.Delete(id)
+	return s.trackRepo.Delete(id, userID)
 }
 
 // SearchTracks searches tracks by title
@@ -193,10 +204,10 @@ func (s *TrackService) GetTracksByAudiobookID(audiobookID uint) ([]dto.TrackResp
 	return trackResponses, nil
 }
 
-// GetTracksByAudiobook retrieves tracks by audiobook ID with pagination
+// GetTracksByAudiobook retrieves tracks of a published audiobook by audiobook ID with pagination
 func (s *TrackService) GetTracksByAudiobook(audiobookID uint, page, limit int) (*dto.ListResponse, error) {
 	// Get all tracks for the audiobook first
-	allTracks, err := s.trackRepo.GetByAudiobookID(audiobookID)
+	allTracks, err := s.trackRepo.GetPublishedByAudiobookID(audiobookID)
 	if err != nil {
 		return nil, err
 	}
@@ -259,3 +270,19 @@ func (s *TrackService) UpdateTrackOrder(audiobookID uint, trackOrders []struct {
 	// additional fields in the track entity
 	return nil
 }
+
+// checkAudiobookEditable fails when the audiobook of a track is missing or in review; what a reviewer
+// approved is what gets published
+func (s *TrackService) checkAudiobookEditable(audiobookID uint) error {
+	audiobook, err := s.audiobookRepo.GetByID(audiobookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("audiobook not found")
+		}
+		return err
+	}
+	if audiobook.Status == entity.AudiobookInReview {
+		return errors.New("audiobook is in review")
+	}
+	return nil
+}
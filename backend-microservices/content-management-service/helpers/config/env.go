@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
 // GetUserManagementBaseURL returns the base URL for user management service
@@ -28,3 +30,54 @@ func GetUserManagementValidateURL() string {
 	}
 	return url
 }
+
+// GetPublishSchedulerInterval returns how often scheduled audiobooks are checked for release
+func GetPublishSchedulerInterval() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("PUBLISH_SCHEDULER_INTERVAL_SECONDS"))
+	if err != nil || seconds < 1 {
+		seconds = 60 // default
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// GetCatalogBaseURL returns the base URL for catalog service, which content changes are replicated to
+func GetCatalogBaseURL() string {
+	host := os.Getenv("CATALOG_HOST")
+	if host == "" {
+		host = "localhost" // default
+	}
+
+	port := os.Getenv("CATALOG_PORT")
+	if port == "" {
+		port = "3163" // default
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, port)
+}
+
+// GetReplicationAPIKey returns the API key shared with catalog service for replication requests
+func GetReplicationAPIKey() string {
+	apiKey := os.Getenv("REPLICATION_API_KEY")
+	if apiKey == "" {
+		apiKey = "alat-replication-api-key" // default
+	}
+	return apiKey
+}
+
+// GetOutboxRelayInterval returns how often the outbox is relayed to catalog service
+func GetOutboxRelayInterval() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("OUTBOX_RELAY_INTERVAL_SECONDS"))
+	if err != nil || seconds < 1 {
+		seconds = 5 // default
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// GetOutboxRelayBatchSize returns how many outbox events are relayed per request
+func GetOutboxRelayBatchSize() int {
+	size, err := strconv.Atoi(os.Getenv("OUTBOX_RELAY_BATCH_SIZE"))
+	if err != nil || size < 1 {
+		size = 100 // default
+	}
+	return size
+}
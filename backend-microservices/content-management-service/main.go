@@ -7,6 +7,7 @@ import (
 	"content-management-service/helpers/config"
 	"content-management-service/presentation_layer/controller"
 	"content-management-service/presentation_layer/route"
+	"context"
 	"log"
 	"os"
 
@@ -42,6 +43,8 @@ func main() {
 	trackRepo := repository.NewTrackRepository(db)
 	userRepo := repository.NewUserRepository(db)
 	analyticsRepo := repository.NewAnalyticsRepository(db)
+	revisionRepo := repository.NewRevisionRepository(db)
+	outboxRepo := repository.NewOutboxRepository(db)
 
 	// Initialize user management service for API validation
 	userManagementBaseURL := config.GetUserManagementBaseURL()
@@ -57,12 +60,23 @@ func main() {
 		authorRepo,
 		readerRepo,
 		genreRepo,
-		trackRepo,     // Add trackRepo
-		analyticsRepo, // Add analyticsRepo
 	)
-	trackService := service.NewTrackService(trackRepo)
+	trackService := service.NewTrackService(trackRepo, audiobookRepo)
 	userService := service.NewUserService(userRepo)
 	analyticsService := service.NewAnalyticsService(analyticsRepo)
+	revisionService := service.NewRevisionService(revisionRepo, audiobookRepo, authorRepo, readerRepo)
+	outboxRelayService := service.NewOutboxRelayService(
+		outboxRepo,
+		config.GetCatalogBaseURL(),
+		config.GetReplicationAPIKey(),
+		config.GetOutboxRelayBatchSize(),
+	)
+
+	// Release approved audiobooks when their publish time arrives
+	go audiobookService.RunPublishScheduler(context.Background(), config.GetPublishSchedulerInterval())
+
+	// Replicate content changes to the catalog service
+	go outboxRelayService.RunRelay(context.Background(), config.GetOutboxRelayInterval())
 
 	// Initialize controllers
 	authorController := controller.NewAuthorController(authorService)
@@ -72,6 +86,8 @@ func main() {
 	trackController := controller.NewTrackController(trackService)
 	userController := controller.NewUserController(userService)
 	analyticsController := controller.NewAnalyticsController(analyticsService)
+	revisionController := controller.NewRevisionController(revisionService)
+	outboxController := controller.NewOutboxController(outboxRelayService)
 
 	// Setup Gin router
 	if os.Getenv("GIN_MODE") == "release" {
@@ -95,7 +111,7 @@ func main() {
 	})
 
 	// Setup routes with user management service for middleware
-	route.SetupRoutes(router, authorController, readerController, genreController, audiobookController, trackController, userController, analyticsController, userManagementService)
+	route.SetupRoutes(router, authorController, readerController, genreController, audiobookController, trackController, userController, analyticsController, revisionController, outboxController, userManagementService)
 
 	// Get port from environment or use default
 	port := os.Getenv("SERVER_PORT")
@@ -28,7 +28,7 @@ func (ac *AudiobookController) CreateAudiobook(c *gin.Context) {
 		return
 	}
 
-	audiobook, err := ac.audiobookService.CreateAudiobook(req)
+	audiobook, err := ac.audiobookService.CreateAudiobook(req, c.GetString("user_id"))
 	if err != nil {
 		if err.Error() == "author not found" || err.Error() == "reader not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -106,7 +106,7 @@ func (ac *AudiobookController) UpdateAudiobook(c *gin.Context) {
 		return
 	}
 
-	audiobook, err := ac.audiobookService.UpdateAudiobook(uint(id), req)
+	audiobook, err := ac.audiobookService.UpdateAudiobook(uint(id), req, c.GetString("user_id"))
 	if err != nil {
 		if err.Error() == "audiobook not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -116,6 +116,10 @@ func (ac *AudiobookController) UpdateAudiobook(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == "audiobook is in review" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -131,7 +135,7 @@ func (ac *AudiobookController) DeleteAudiobook(c *gin.Context) {
 		return
 	}
 
-	if err := ac.audiobookService.DeleteAudiobook(uint(id)); err != nil {
+	if err := ac.audiobookService.DeleteAudiobook(uint(id), c.GetString("user_id")); err != nil {
 		if err.Error() == "audiobook not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -195,11 +199,15 @@ func (ac *AudiobookController) AddGenresToAudiobook(c *gin.Context) {
 		return
 	}
 
-	if err := ac.audiobookService.AddGenresToAudiobook(uint(audiobookID), req.GenreIDs); err != nil {
+	if err := ac.audiobookService.AddGenresToAudiobook(uint(audiobookID), req.GenreIDs, c.GetString("user_id")); err != nil {
 		if err.Error() == "audiobook not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == "audiobook is in review" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -224,14 +232,167 @@ func (ac *AudiobookController) RemoveGenresFromAudiobook(c *gin.Context) {
 		return
 	}
 
-	if err := ac.audiobookService.RemoveGenresFromAudiobook(uint(audiobookID), req.GenreIDs); err != nil {
+	if err := ac.audiobookService.RemoveGenresFromAudiobook(uint(audiobookID), req.GenreIDs, c.GetString("user_id")); err != nil {
 		if err.Error() == "audiobook not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == "audiobook is in review" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Genres removed successfully"})
 }
+
+// GetEditorialAudiobooks retrieves audiobooks in any publication state, optionally filtered by status
+func (ac *AudiobookController) GetEditorialAudiobooks(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	audiobooks, err := ac.audiobookService.GetEditorialAudiobooks(c.Query("status"), page, limit)
+	if err != nil {
+		if err.Error() == "invalid status" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, audiobooks)
+}
+
+// GetEditorialAudiobookByID retrieves an audiobook in any publication state by ID
+func (ac *AudiobookController) GetEditorialAudiobookByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	audiobook, err := ac.audiobookService.GetEditorialAudiobookByID(uint(id))
+	if err != nil {
+		if err.Error() == "audiobook not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, audiobook)
+}
+
+// SubmitAudiobook submits an audiobook for review
+func (ac *AudiobookController) SubmitAudiobook(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	// The body is optional
+	var req dto.SubmitAudiobookRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	audiobook, err := ac.audiobookService.SubmitAudiobook(uint(id), c.GetString("user_id"), req.PublishAt)
+	if err != nil {
+		ac.workflowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, audiobook)
+}
+
+// ApproveAudiobook approves an audiobook in review
+func (ac *AudiobookController) ApproveAudiobook(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	audiobook, err := ac.audiobookService.ApproveAudiobook(uint(id), c.GetString("user_id"))
+	if err != nil {
+		ac.workflowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, audiobook)
+}
+
+// RejectAudiobook sends an audiobook in review back to draft
+func (ac *AudiobookController) RejectAudiobook(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	// The body is optional
+	var req dto.RejectAudiobookRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	audiobook, err := ac.audiobookService.RejectAudiobook(uint(id), req.Note)
+	if err != nil {
+		ac.workflowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, audiobook)
+}
+
+// UnpublishAudiobook takes a published audiobook off the public routes
+func (ac *AudiobookController) UnpublishAudiobook(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	audiobook, err := ac.audiobookService.UnpublishAudiobook(uint(id))
+	if err != nil {
+		ac.workflowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, audiobook)
+}
+
+// workflowError responds with the status of a failed publication workflow transition
+func (ac *AudiobookController) workflowError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "audiobook not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "only draft or unpublished audiobooks can be submitted",
+		"only audiobooks awaiting review can be approved",
+		"audiobooks cannot be approved by their submitter",
+		"only audiobooks in review can be rejected",
+		"only published audiobooks can be unpublished",
+		"audiobook status changed, try again":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
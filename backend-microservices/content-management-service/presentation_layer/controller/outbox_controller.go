@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"content-management-service/domain_layer/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OutboxController struct {
+	outboxRelayService *service.OutboxRelayService
+}
+
+func NewOutboxController(outboxRelayService *service.OutboxRelayService) *OutboxController {
+	return &OutboxController{
+		outboxRelayService: outboxRelayService,
+	}
+}
+
+// PublishSnapshot publishes the current state of the whole catalog to the outbox
+func (oc *OutboxController) PublishSnapshot(c *gin.Context) {
+	published, err := oc.outboxRelayService.PublishSnapshot()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"published": published})
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"content-management-service/domain_layer/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RevisionController struct {
+	revisionService *service.RevisionService
+}
+
+func NewRevisionController(revisionService *service.RevisionService) *RevisionController {
+	return &RevisionController{
+		revisionService: revisionService,
+	}
+}
+
+// GetAudiobookRevisions retrieves the revision history of an audiobook with pagination
+func (rc *RevisionController) GetAudiobookRevisions(c *gin.Context) {
+	audiobookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	revisions, err := rc.revisionService.GetAudiobookRevisions(uint(audiobookID), c.Query("entity_type"), page, limit)
+	if err != nil {
+		if err.Error() == "invalid entity type" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity type"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// GetRevisionByID retrieves a revision by ID
+func (rc *RevisionController) GetRevisionByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision ID"})
+		return
+	}
+
+	revision, err := rc.revisionService.GetRevisionByID(uint(id))
+	if err != nil {
+		if err.Error() == "revision not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
+
+// DiffRevisions shows the field-level differences between a revision and another revision of the same entity
+func (rc *RevisionController) DiffRevisions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision ID"})
+		return
+	}
+
+	to, err := strconv.ParseUint(c.Query("to"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "To parameter must be a revision ID"})
+		return
+	}
+
+	diff, err := rc.revisionService.DiffRevisions(uint(id), uint(to))
+	if err != nil {
+		if err.Error() == "revision not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "revisions belong to different entities" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RollbackToRevision restores the entity of a revision to the state it recorded
+func (rc *RevisionController) RollbackToRevision(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision ID"})
+		return
+	}
+
+	revision, err := rc.revisionService.RollbackToRevision(uint(id), c.GetString("user_id"))
+	if err != nil {
+		switch err.Error() {
+		case "revision not found", "audiobook not found", "author not found", "reader not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "cannot roll back to a delete revision", "audiobook is in review":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
@@ -28,12 +28,16 @@ func (tc *TrackController) CreateTrack(c *gin.Context) {
 		return
 	}
 
-	track, err := tc.trackService.CreateTrack(req)
+	track, err := tc.trackService.CreateTrack(req, c.GetString("user_id"))
 	if err != nil {
 		if err.Error() == "audiobook not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == "audiobook is in review" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -129,7 +133,7 @@ func (tc *TrackController) UpdateTrack(c *gin.Context) {
 		return
 	}
 
-	track, err := tc.trackService.UpdateTrack(uint(id), req)
+	track, err := tc.trackService.UpdateTrack(uint(id), req, c.GetString("user_id"))
 	if err != nil {
 		if err.Error() == "track not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -139,6 +143,10 @@ func (tc *TrackController) UpdateTrack(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == "audiobook is in review" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -154,11 +162,15 @@ func (tc *TrackController) DeleteTrack(c *gin.Context) {
 		return
 	}
 
-	if err := tc.trackService.DeleteTrack(uint(id)); err != nil {
+	if err := tc.trackService.DeleteTrack(uint(id), c.GetString("user_id")); err != nil {
 		if err.Error() == "track not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == "audiobook is in review" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -28,6 +28,14 @@ func AudiobookRoutes(router *gin.RouterGroup, audiobookController *controller.Au
 			// Genre management
 			adminRoutes.POST("/:id/genres", audiobookController.AddGenresToAudiobook)
 			adminRoutes.DELETE("/:id/genres", audiobookController.RemoveGenresFromAudiobook)
+
+			// Publication workflow
+			adminRoutes.GET("/editorial", audiobookController.GetEditorialAudiobooks)
+			adminRoutes.GET("/editorial/:id", audiobookController.GetEditorialAudiobookByID)
+			adminRoutes.POST("/:id/submit", audiobookController.SubmitAudiobook)
+			adminRoutes.POST("/:id/approve", audiobookController.ApproveAudiobook)
+			adminRoutes.POST("/:id/reject", audiobookController.RejectAudiobook)
+			adminRoutes.POST("/:id/unpublish", audiobookController.UnpublishAudiobook)
 		}
 	}
 }
@@ -0,0 +1,19 @@
+package route
+
+import (
+	"content-management-service/domain_layer/middleware"
+	"content-management-service/domain_layer/service"
+	"content-management-service/presentation_layer/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OutboxRoutes sets up all outbox-related routes
+func OutboxRoutes(router *gin.RouterGroup, outboxController *controller.OutboxController, userManagementService *service.UserManagementService) {
+	// Protected routes (SuperAdmin only)
+	adminRoutes := router.Group("/outbox")
+	adminRoutes.Use(middleware.RequireSuperAdminWithAPIValidationMiddleware(userManagementService))
+	{
+		adminRoutes.POST("/snapshot", outboxController.PublishSnapshot)
+	}
+}
@@ -0,0 +1,22 @@
+package route
+
+import (
+	"content-management-service/domain_layer/middleware"
+	"content-management-service/domain_layer/service"
+	"content-management-service/presentation_layer/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RevisionRoutes sets up all revision-related routes
+func RevisionRoutes(router *gin.RouterGroup, revisionController *controller.RevisionController, userManagementService *service.UserManagementService) {
+	// Protected routes (SuperAdmin only)
+	adminRoutes := router.Group("")
+	adminRoutes.Use(middleware.RequireSuperAdminWithAPIValidationMiddleware(userManagementService))
+	{
+		adminRoutes.GET("/audiobooks/:id/revisions", revisionController.GetAudiobookRevisions)
+		adminRoutes.GET("/revisions/:id", revisionController.GetRevisionByID)
+		adminRoutes.GET("/revisions/:id/diff", revisionController.DiffRevisions)
+		adminRoutes.POST("/revisions/:id/rollback", revisionController.RollbackToRevision)
+	}
+}
@@ -17,6 +17,8 @@ func SetupRoutes(
 	trackController *controller.TrackController,
 	userController *controller.UserController,
 	analyticsController *controller.AnalyticsController,
+	revisionController *controller.RevisionController,
+	outboxController *controller.OutboxController,
 	userManagementService *service.UserManagementService,
 ) {
 	// API versioning
@@ -38,4 +40,6 @@ func SetupRoutes(
 	TrackRoutes(api, trackController, userManagementService)
 	UserRoutes(api, userController, userManagementService)
 	AnalyticsRoutes(api, analyticsController, userManagementService)
+	RevisionRoutes(api, revisionController, userManagementService)
+	OutboxRoutes(api, outboxController, userManagementService)
 }
@@ -0,0 +1,82 @@
+package dto
+
+import "time"
+
+// SaveProgressRequest represents the request to save listening progress on a track.
+// ClientUpdatedAt is when the device recorded the position; it defaults to the time of the request.
+type SaveProgressRequest struct {
+	AudiobookID     uint       `json:"audiobook_id" binding:"required"`
+	TrackID         uint       `json:"track_id" binding:"required"`
+	PositionSeconds int        `json:"position_seconds" binding:"min=0"`
+	DurationSeconds int        `json:"duration_seconds" binding:"min=0"`
+	Completed       bool       `json:"completed"`
+	DeviceID        string     `json:"device_id" binding:"max=100"`
+	ClientUpdatedAt *time.Time `json:"client_updated_at"`
+}
+
+// SyncProgressUpdate represents one progress update recorded by a device, possibly while offline
+type SyncProgressUpdate struct {
+	AudiobookID     uint      `json:"audiobook_id" binding:"required"`
+	TrackID         uint      `json:"track_id" binding:"required"`
+	PositionSeconds int       `json:"position_seconds" binding:"min=0"`
+	DurationSeconds int       `json:"duration_seconds" binding:"min=0"`
+	Completed       bool      `json:"completed"`
+	ClientUpdatedAt time.Time `json:"client_updated_at" binding:"required"`
+}
+
+// SyncProgressRequest represents a batch of progress updates from one device.
+// Since is the server_time of the device's previous sync; records changed after it are returned too.
+type SyncProgressRequest struct {
+	DeviceID string               `json:"device_id" binding:"required,max=100"`
+	Since    *time.Time           `json:"since"`
+	Updates  []SyncProgressUpdate `json:"updates" binding:"max=500,dive"`
+}
+
+// SyncRejectedUpdate reports an update of a sync batch that could not be applied
+type SyncRejectedUpdate struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// SyncProgressResponse represents the merged progress state a device must apply after a sync
+type SyncProgressResponse struct {
+	ServerTime   time.Time               `json:"server_time"`
+	Applied      int                     `json:"applied"`
+	Rejected     []SyncRejectedUpdate    `json:"rejected"`
+	Tracks       []TrackProgressResponse `json:"tracks"`
+	ResumePoints []ResumePointResponse   `json:"resume_points"`
+}
+
+// TrackProgressResponse represents the response for track progress data
+type TrackProgressResponse struct {
+	TrackID         uint      `json:"track_id"`
+	AudiobookID     uint      `json:"audiobook_id"`
+	PositionSeconds int       `json:"position_seconds"`
+	DurationSeconds int       `json:"duration_seconds"`
+	Completed       bool      `json:"completed"`
+	DeviceID        string    `json:"device_id"`
+	ClientUpdatedAt time.Time `json:"client_updated_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ResumePointResponse represents where a user should resume an audiobook
+type ResumePointResponse struct {
+	AudiobookID     uint                    `json:"audiobook_id"`
+	TrackID         uint                    `json:"track_id"`
+	PositionSeconds int                     `json:"position_seconds"`
+	Finished        bool                    `json:"finished"`
+	LastPlayedAt    time.Time               `json:"last_played_at"`
+	DeviceID        string                  `json:"device_id"`
+	Tracks          []TrackProgressResponse `json:"tracks,omitempty"`
+}
+
+// ContinueListeningResponse represents an item on the "continue listening" shelf
+type ContinueListeningResponse struct {
+	AudiobookID     uint      `json:"audiobook_id"`
+	Title           string    `json:"title"`
+	ImageURL        string    `json:"image_url"`
+	TrackID         uint      `json:"track_id"`
+	TrackTitle      string    `json:"track_title"`
+	PositionSeconds int       `json:"position_seconds"`
+	LastPlayedAt    time.Time `json:"last_played_at"`
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+)
+
+// TrackProgress represents the track_progress table.
+// TrackID and AudiobookID reference catalog-service entity.Track and entity.Audiobook IDs.
+// DeviceID and ClientUpdatedAt describe the device update that currently holds the record.
+type TrackProgress struct {
+	ID              uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID          string    `json:"user_id" gorm:"size:255;not null;uniqueIndex:idx_track_progress_user_track"`
+	TrackID         uint      `json:"track_id" gorm:"not null;uniqueIndex:idx_track_progress_user_track"`
+	AudiobookID     uint      `json:"audiobook_id" gorm:"not null;index"`
+	PositionSeconds int       `json:"position_seconds" gorm:"not null;default:0"`
+	DurationSeconds int       `json:"duration_seconds" gorm:"not null;default:0"`
+	Completed       bool      `json:"completed" gorm:"not null;default:false"`
+	DeviceID        string    `json:"device_id" gorm:"size:100;not null;default:''"`
+	ClientUpdatedAt time.Time `json:"client_updated_at" gorm:"not null;default:'1970-01-01 00:00:00+00'"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"autoUpdateTime;index"`
+}
+
+// TableName specifies the table name for the TrackProgress model
+func (TrackProgress) TableName() string {
+	return "track_progress"
+}
+
+// AudiobookProgress represents the audiobook_progress table.
+// It holds the resume point (last track and position) of a user for an audiobook.
+// LastPlayedAt is the client timestamp of the update that set the resume point.
+type AudiobookProgress struct {
+	ID              uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID          string    `json:"user_id" gorm:"size:255;not null;uniqueIndex:idx_audiobook_progress_user_audiobook"`
+	AudiobookID     uint      `json:"audiobook_id" gorm:"not null;uniqueIndex:idx_audiobook_progress_user_audiobook"`
+	TrackID         uint      `json:"track_id" gorm:"not null"`
+	PositionSeconds int       `json:"position_seconds" gorm:"not null;default:0"`
+	Finished        bool      `json:"finished" gorm:"not null;default:false"`
+	LastPlayedAt    time.Time `json:"last_played_at" gorm:"not null;index"`
+	DeviceID        string    `json:"device_id" gorm:"size:100;not null;default:''"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"autoUpdateTime;index"`
+}
+
+// TableName specifies the table name for the AudiobookProgress model
+func (AudiobookProgress) TableName() string {
+	return "audiobook_progress"
+}
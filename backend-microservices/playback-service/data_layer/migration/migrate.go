@@ -0,0 +1,26 @@
+package migration
+
+import (
+	"log"
+	"playback-service/data_layer/entity"
+
+	"gorm.io/gorm"
+)
+
+// AutoMigrate runs database migration for all entities
+func AutoMigrate(db *gorm.DB) error {
+	log.Println("Starting database migration...")
+
+	err := db.AutoMigrate(
+		&entity.TrackProgress{},
+		&entity.AudiobookProgress{},
+	)
+
+	if err != nil {
+		log.Printf("Migration failed: %v", err)
+		return err
+	}
+
+	log.Println("Database migration completed successfully")
+	return nil
+}
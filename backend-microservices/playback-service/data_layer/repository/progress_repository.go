@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"playback-service/data_layer/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProgressRepositoryInterface defines the contract for progress repository
+type ProgressRepositoryInterface interface {
+	UpsertTrackProgress(progress *entity.TrackProgress) error
+	GetTrackProgress(userID string, trackID uint) (*entity.TrackProgress, error)
+	GetTrackProgressByAudiobook(userID string, audiobookID uint) ([]entity.TrackProgress, error)
+	UpsertAudiobookProgress(progress *entity.AudiobookProgress) error
+	GetAudiobookProgress(userID string, audiobookID uint) (*entity.AudiobookProgress, error)
+	GetInProgressAudiobooks(userID string, offset, limit int) ([]entity.AudiobookProgress, int64, error)
+	DeleteByAudiobook(userID string, audiobookID uint) error
+	GetTrackProgressUpdatedSince(userID string, since time.Time) ([]entity.TrackProgress, error)
+	GetAudiobookProgressUpdatedSince(userID string, since time.Time) ([]entity.AudiobookProgress, error)
+	LockUser(userID string) error
+	Transaction(fn func(repo ProgressRepositoryInterface) error) error
+}
+
+// ProgressRepository implements ProgressRepositoryInterface
+type ProgressRepository struct {
+	db *gorm.DB
+}
+
+// NewProgressRepository creates a new progress repository
+func NewProgressRepository(db *gorm.DB) ProgressRepositoryInterface {
+	return &ProgressRepository{db: db}
+}
+
+// UpsertTrackProgress creates or updates the progress of a user on a track
+func (r *ProgressRepository) UpsertTrackProgress(progress *entity.TrackProgress) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "track_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"audiobook_id", "position_seconds", "duration_seconds", "completed", "device_id", "client_updated_at", "updated_at"}),
+	}).Create(progress).Error
+}
+
+// GetTrackProgress retrieves the progress of a user on a track
+func (r *ProgressRepository) GetTrackProgress(userID string, trackID uint) (*entity.TrackProgress, error) {
+	var progress entity.TrackProgress
+	err := r.db.Where("user_id = ? AND track_id = ?", userID, trackID).First(&progress).Error
+	if err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// GetTrackProgressByAudiobook retrieves the progress of a user on every track of an audiobook
+func (r *ProgressRepository) GetTrackProgressByAudiobook(userID string, audiobookID uint) ([]entity.TrackProgress, error) {
+	var progress []entity.TrackProgress
+	err := r.db.Where("user_id = ? AND audiobook_id = ?", userID, audiobookID).Order("track_id ASC").Find(&progress).Error
+	return progress, err
+}
+
+// UpsertAudiobookProgress creates or updates the resume point of a user on an audiobook
+func (r *ProgressRepository) UpsertAudiobookProgress(progress *entity.AudiobookProgress) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "audiobook_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"track_id", "position_seconds", "finished", "last_played_at", "device_id", "updated_at"}),
+	}).Create(progress).Error
+}
+
+// GetAudiobookProgress retrieves the resume point of a user on an audiobook
+func (r *ProgressRepository) GetAudiobookProgress(userID string, audiobookID uint) (*entity.AudiobookProgress, error) {
+	var progress entity.AudiobookProgress
+	err := r.db.Where("user_id = ? AND audiobook_id = ?", userID, audiobookID).First(&progress).Error
+	if err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// GetInProgressAudiobooks retrieves unfinished audiobooks of a user, most recently played first
+func (r *ProgressRepository) GetInProgressAudiobooks(userID string, offset, limit int) ([]entity.AudiobookProgress, int64, error) {
+	var progress []entity.AudiobookProgress
+	var total int64
+
+	dbQuery := r.db.Model(&entity.AudiobookProgress{}).Where("user_id = ? AND finished = ?", userID, false)
+
+	// Count total records
+	if err := dbQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	if err := dbQuery.Order("last_played_at DESC").Order("audiobook_id ASC").Offset(offset).Limit(limit).Find(&progress).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return progress, total, nil
+}
+
+// DeleteByAudiobook deletes all progress of a user on an audiobook
+func (r *ProgressRepository) DeleteByAudiobook(userID string, audiobookID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND audiobook_id = ?", userID, audiobookID).Delete(&entity.TrackProgress{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ? AND audiobook_id = ?", userID, audiobookID).Delete(&entity.AudiobookProgress{}).Error
+	})
+}
+
+// GetTrackProgressUpdatedSince retrieves the track progress of a user changed after the given time
+func (r *ProgressRepository) GetTrackProgressUpdatedSince(userID string, since time.Time) ([]entity.TrackProgress, error) {
+	var progress []entity.TrackProgress
+	err := r.db.Where("user_id = ? AND updated_at > ?", userID, since).Order("audiobook_id ASC, track_id ASC").Find(&progress).Error
+	return progress, err
+}
+
+// GetAudiobookProgressUpdatedSince retrieves the resume points of a user changed after the given time
+func (r *ProgressRepository) GetAudiobookProgressUpdatedSince(userID string, since time.Time) ([]entity.AudiobookProgress, error) {
+	var progress []entity.AudiobookProgress
+	err := r.db.Where("user_id = ? AND updated_at > ?", userID, since).Order("audiobook_id ASC").Find(&progress).Error
+	return progress, err
+}
+
+// LockUser serializes progress writes of a user until the surrounding transaction ends
+func (r *ProgressRepository) LockUser(userID string) error {
+	return r.db.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "progress:"+userID).Error
+}
+
+// Transaction runs fn with a repository bound to a single database transaction
+func (r *ProgressRepository) Transaction(fn func(repo ProgressRepositoryInterface) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&ProgressRepository{db: tx})
+	})
+}
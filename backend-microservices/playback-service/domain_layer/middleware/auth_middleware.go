@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"playback-service/domain_layer/service"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireAuthWithAPIValidationMiddleware ensures the caller is an authenticated user
+// This middleware validates the bearer token against the user-management validate-token API
+func RequireAuthWithAPIValidationMiddleware(userManagementService *service.UserManagementService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract token from Authorization header
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			log.Printf("Auth API Validation Middleware: Authorization header is missing")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"details": "Authorization header is required",
+				"source":  "auth_middleware",
+			})
+			return
+		}
+
+		// Check if the header has the Bearer prefix
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			log.Printf("Auth API Validation Middleware: Invalid Authorization header format")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"details": "Authorization header must be in format: Bearer {token}",
+				"source":  "auth_middleware",
+			})
+			return
+		}
+
+		// Validate token using external API
+		validationResponse, err := userManagementService.ValidateToken(c.Request.Context(), parts[1])
+		if err != nil {
+			log.Printf("Auth API Validation Middleware: Error validating token: %v", err)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"details": "Failed to validate token: " + err.Error(),
+				"source":  "auth_middleware",
+			})
+			return
+		}
+
+		if !validationResponse.IsValid || validationResponse.UserInfo == nil {
+			log.Printf("Auth API Validation Middleware: Access denied - %s", validationResponse.Error)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"details": validationResponse.Error,
+				"source":  "auth_middleware",
+			})
+			return
+		}
+
+		if !validationResponse.UserInfo.IsActive {
+			log.Printf("Auth API Validation Middleware: Access denied - user %s is not active", validationResponse.UserInfo.UserID)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"details": "User account is not active",
+				"source":  "auth_middleware",
+			})
+			return
+		}
+
+		// Store information in context for later use
+		c.Set("user_id", validationResponse.UserInfo.UserID)
+		c.Set("user_role", validationResponse.UserInfo.Role)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrAudiobookNotFound is returned when an audiobook is not in the catalog, or no longer
+var ErrAudiobookNotFound = errors.New("audiobook not found")
+
+// CatalogService handles external API calls to catalog-service
+type CatalogService struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// CatalogTrack mirrors the catalog-service TrackResponse
+type CatalogTrack struct {
+	ID       uint   `json:"id"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Duration string `json:"duration"`
+}
+
+// CatalogAudiobook mirrors the fields of the catalog-service AudiobookResponse used here
+type CatalogAudiobook struct {
+	ID       uint           `json:"id"`
+	Title    string         `json:"title"`
+	ImageURL string         `json:"image_url"`
+	Tracks   []CatalogTrack `json:"tracks"`
+}
+
+// NewCatalogService creates a new catalog service client
+func NewCatalogService(baseURL string) *CatalogService {
+	return &CatalogService{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// GetAudiobook retrieves an audiobook with its ordered tracks from catalog-service
+func (s *CatalogService) GetAudiobook(ctx context.Context, audiobookID uint) (*CatalogAudiobook, error) {
+	url := fmt.Sprintf("%s/api/v1/audiobooks/%d", s.baseURL, audiobookID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrAudiobookNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("catalog request failed with status: %d", resp.StatusCode)
+	}
+
+	var audiobook CatalogAudiobook
+	if err := json.NewDecoder(resp.Body).Decode(&audiobook); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &audiobook, nil
+}
@@ -0,0 +1,525 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"playback-service/data_layer/dto"
+	"playback-service/data_layer/entity"
+	"playback-service/data_layer/repository"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrTrackNotFound is returned when a track is not part of the audiobook in the catalog
+var ErrTrackNotFound = errors.New("track not found")
+
+type ProgressService struct {
+	progressRepo   repository.ProgressRepositoryInterface
+	catalogService *CatalogService
+	conflictWindow time.Duration
+}
+
+func NewProgressService(progressRepo repository.ProgressRepositoryInterface, catalogService *CatalogService, conflictWindow time.Duration) *ProgressService {
+	return &ProgressService{
+		progressRepo:   progressRepo,
+		catalogService: catalogService,
+		conflictWindow: conflictWindow,
+	}
+}
+
+// progressUpdate is one position report of a device, from a single save or a sync batch
+type progressUpdate struct {
+	audiobookID     uint
+	trackID         uint
+	positionSeconds int
+	durationSeconds int
+	completed       bool
+	deviceID        string
+	clientUpdatedAt time.Time
+}
+
+// SaveProgress stores the position of a user on a track and moves the audiobook resume point.
+// The update is merged with what other devices saved, so the stored state is returned.
+func (s *ProgressService) SaveProgress(ctx context.Context, userID string, req dto.SaveProgressRequest) (*dto.TrackProgressResponse, error) {
+	// Validate the track belongs to the audiobook in the catalog
+	audiobook, err := s.catalogService.GetAudiobook(ctx, req.AudiobookID)
+	if err != nil {
+		return nil, err
+	}
+
+	update := progressUpdate{
+		audiobookID:     req.AudiobookID,
+		trackID:         req.TrackID,
+		positionSeconds: req.PositionSeconds,
+		durationSeconds: req.DurationSeconds,
+		completed:       req.Completed,
+		deviceID:        req.DeviceID,
+		clientUpdatedAt: time.Now(),
+	}
+	if req.ClientUpdatedAt != nil {
+		update.clientUpdatedAt = *req.ClientUpdatedAt
+	}
+
+	var trackProgress *entity.TrackProgress
+	err = s.progressRepo.Transaction(func(repo repository.ProgressRepositoryInterface) error {
+		if err := repo.LockUser(userID); err != nil {
+			return err
+		}
+		trackProgress, err = s.applyUpdate(repo, userID, audiobook, update)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToTrackProgressResponse(trackProgress)
+	return &response, nil
+}
+
+// SyncProgress merges a batch of offline progress updates from one device and returns the
+// merged state of everything the batch touched or that changed since the device last synced
+func (s *ProgressService) SyncProgress(ctx context.Context, userID string, req dto.SyncProgressRequest) (*dto.SyncProgressResponse, error) {
+	// Taken before writing so the next sync also sees updates committed concurrently with this one
+	serverTime := time.Now()
+
+	response := &dto.SyncProgressResponse{
+		ServerTime:   serverTime,
+		Rejected:     []dto.SyncRejectedUpdate{},
+		Tracks:       []dto.TrackProgressResponse{},
+		ResumePoints: []dto.ResumePointResponse{},
+	}
+
+	// Apply in client time order so the outcome does not depend on how the batch was assembled
+	order := make([]int, len(req.Updates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return req.Updates[order[a]].ClientUpdatedAt.Before(req.Updates[order[b]].ClientUpdatedAt)
+	})
+
+	// Look up catalog data before taking the lock
+	audiobooks := make(map[uint]*CatalogAudiobook)
+	for _, update := range req.Updates {
+		if _, seen := audiobooks[update.AudiobookID]; seen {
+			continue
+		}
+		audiobook, err := s.catalogService.GetAudiobook(ctx, update.AudiobookID)
+		if err != nil && !errors.Is(err, ErrAudiobookNotFound) {
+			return nil, err
+		}
+		audiobooks[update.AudiobookID] = audiobook
+	}
+
+	type trackKey struct{ audiobookID, trackID uint }
+	touchedTracks := make(map[trackKey]bool)
+	touchedAudiobooks := make(map[uint]bool)
+
+	err := s.progressRepo.Transaction(func(repo repository.ProgressRepositoryInterface) error {
+		if err := repo.LockUser(userID); err != nil {
+			return err
+		}
+
+		for _, i := range order {
+			update := req.Updates[i]
+
+			audiobook := audiobooks[update.AudiobookID]
+			if audiobook == nil {
+				response.Rejected = append(response.Rejected, dto.SyncRejectedUpdate{Index: i, Error: ErrAudiobookNotFound.Error()})
+				continue
+			}
+
+			_, err := s.applyUpdate(repo, userID, audiobook, progressUpdate{
+				audiobookID:     update.AudiobookID,
+				trackID:         update.TrackID,
+				positionSeconds: update.PositionSeconds,
+				durationSeconds: update.DurationSeconds,
+				completed:       update.Completed,
+				deviceID:        req.DeviceID,
+				clientUpdatedAt: update.ClientUpdatedAt,
+			})
+			if err != nil {
+				if errors.Is(err, ErrTrackNotFound) {
+					response.Rejected = append(response.Rejected, dto.SyncRejectedUpdate{Index: i, Error: err.Error()})
+					continue
+				}
+				return err
+			}
+
+			response.Applied++
+			touchedTracks[trackKey{update.AudiobookID, update.TrackID}] = true
+			touchedAudiobooks[update.AudiobookID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(response.Rejected, func(a, b int) bool {
+		return response.Rejected[a].Index < response.Rejected[b].Index
+	})
+
+	// Collect the merged state: every record the batch touched plus everything changed since the last sync
+	var trackProgress []entity.TrackProgress
+	var audiobookProgress []entity.AudiobookProgress
+	if req.Since != nil {
+		if trackProgress, err = s.progressRepo.GetTrackProgressUpdatedSince(userID, *req.Since); err != nil {
+			return nil, err
+		}
+		if audiobookProgress, err = s.progressRepo.GetAudiobookProgressUpdatedSince(userID, *req.Since); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, progress := range trackProgress {
+		delete(touchedTracks, trackKey{progress.AudiobookID, progress.TrackID})
+	}
+	for key := range touchedTracks {
+		progress, err := s.progressRepo.GetTrackProgress(userID, key.trackID)
+		if err != nil {
+			return nil, err
+		}
+		trackProgress = append(trackProgress, *progress)
+	}
+
+	for _, progress := range audiobookProgress {
+		delete(touchedAudiobooks, progress.AudiobookID)
+	}
+	for audiobookID := range touchedAudiobooks {
+		progress, err := s.progressRepo.GetAudiobookProgress(userID, audiobookID)
+		if err != nil {
+			return nil, err
+		}
+		audiobookProgress = append(audiobookProgress, *progress)
+	}
+
+	sort.Slice(trackProgress, func(a, b int) bool {
+		if trackProgress[a].AudiobookID != trackProgress[b].AudiobookID {
+			return trackProgress[a].AudiobookID < trackProgress[b].AudiobookID
+		}
+		return trackProgress[a].TrackID < trackProgress[b].TrackID
+	})
+	sort.Slice(audiobookProgress, func(a, b int) bool {
+		return audiobookProgress[a].AudiobookID < audiobookProgress[b].AudiobookID
+	})
+
+	for _, progress := range trackProgress {
+		response.Tracks = append(response.Tracks, s.convertToTrackProgressResponse(&progress))
+	}
+	for _, progress := range audiobookProgress {
+		response.ResumePoints = append(response.ResumePoints, s.convertToResumePointResponse(&progress))
+	}
+
+	return response, nil
+}
+
+// GetTrackProgress retrieves the progress of a user on a track
+func (s *ProgressService) GetTrackProgress(userID string, trackID uint) (*dto.TrackProgressResponse, error) {
+	progress, err := s.progressRepo.GetTrackProgress(userID, trackID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("progress not found")
+		}
+		return nil, err
+	}
+
+	response := s.convertToTrackProgressResponse(progress)
+	return &response, nil
+}
+
+// GetResumePoint retrieves where a user should resume an audiobook
+func (s *ProgressService) GetResumePoint(userID string, audiobookID uint) (*dto.ResumePointResponse, error) {
+	progress, err := s.progressRepo.GetAudiobookProgress(userID, audiobookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("progress not found")
+		}
+		return nil, err
+	}
+
+	tracks, err := s.progressRepo.GetTrackProgressByAudiobook(userID, audiobookID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToResumePointResponse(progress)
+	response.Tracks = []dto.TrackProgressResponse{}
+
+	for _, track := range tracks {
+		response.Tracks = append(response.Tracks, s.convertToTrackProgressResponse(&track))
+	}
+
+	return &response, nil
+}
+
+// GetContinueListening retrieves the unfinished audiobooks of a user, most recently played first.
+// Progress lives apart from the catalog, so only the audiobooks of the page are looked up there;
+// the progress of those the catalog no longer has is deleted and the page read again, which keeps
+// them out of the total.
+func (s *ProgressService) GetContinueListening(ctx context.Context, userID string, req dto.PaginationRequest) (*dto.ListResponse, error) {
+	// Calculate offset
+	offset := (req.Page - 1) * req.Limit
+
+	var progress []entity.AudiobookProgress
+	var total int64
+	audiobooks := make(map[uint]*CatalogAudiobook)
+	for {
+		var err error
+		progress, total, err = s.progressRepo.GetInProgressAudiobooks(userID, offset, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+
+		pruned := false
+		for _, item := range progress {
+			if _, found := audiobooks[item.AudiobookID]; found {
+				continue
+			}
+			audiobook, err := s.catalogService.GetAudiobook(ctx, item.AudiobookID)
+			if errors.Is(err, ErrAudiobookNotFound) {
+				if err := s.progressRepo.DeleteByAudiobook(userID, item.AudiobookID); err != nil {
+					return nil, err
+				}
+				pruned = true
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			audiobooks[item.AudiobookID] = audiobook
+		}
+
+		// The page is only read again after rows were deleted from it, so this ends
+		if !pruned {
+			break
+		}
+	}
+
+	// Enrich with catalog data
+	shelf := []dto.ContinueListeningResponse{}
+	for _, item := range progress {
+		audiobook := audiobooks[item.AudiobookID]
+
+		entry := dto.ContinueListeningResponse{
+			AudiobookID:     item.AudiobookID,
+			Title:           audiobook.Title,
+			ImageURL:        audiobook.ImageURL,
+			TrackID:         item.TrackID,
+			PositionSeconds: item.PositionSeconds,
+			LastPlayedAt:    item.LastPlayedAt,
+		}
+		for _, track := range audiobook.Tracks {
+			if track.ID == item.TrackID {
+				entry.TrackTitle = track.Title
+				break
+			}
+		}
+
+		shelf = append(shelf, entry)
+	}
+
+	// Calculate total pages
+	totalPages := int(total) / req.Limit
+	if int(total)%req.Limit > 0 {
+		totalPages++
+	}
+
+	return &dto.ListResponse{
+		Items: shelf,
+		Pagination: dto.PaginationResponse{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// ResetAudiobookProgress deletes all progress of a user on an audiobook
+func (s *ProgressService) ResetAudiobookProgress(userID string, audiobookID uint) error {
+	return s.progressRepo.DeleteByAudiobook(userID, audiobookID)
+}
+
+// Helper methods
+
+// applyUpdate merges one update into the stored progress of a user and returns the track record that won.
+// It must run inside a transaction holding the user's lock.
+func (s *ProgressService) applyUpdate(repo repository.ProgressRepositoryInterface, userID string, audiobook *CatalogAudiobook, update progressUpdate) (*entity.TrackProgress, error) {
+	trackIndex := catalogTrackIndex(audiobook, update.trackID)
+	if trackIndex < 0 {
+		return nil, ErrTrackNotFound
+	}
+
+	// A device clock running ahead must not win every future conflict
+	now := time.Now()
+	if update.clientUpdatedAt.IsZero() || update.clientUpdatedAt.After(now) {
+		update.clientUpdatedAt = now
+	}
+
+	position := update.positionSeconds
+	if update.durationSeconds > 0 && position > update.durationSeconds {
+		position = update.durationSeconds
+	}
+	completed := update.completed || (update.durationSeconds > 0 && position >= update.durationSeconds)
+
+	incomingTrack := &entity.TrackProgress{
+		UserID:          userID,
+		TrackID:         update.trackID,
+		AudiobookID:     update.audiobookID,
+		PositionSeconds: position,
+		DurationSeconds: update.durationSeconds,
+		Completed:       completed,
+		DeviceID:        update.deviceID,
+		ClientUpdatedAt: update.clientUpdatedAt,
+	}
+
+	currentTrack, err := repo.GetTrackProgress(userID, update.trackID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	trackProgress := currentTrack
+	if currentTrack == nil || s.incomingWins(
+		compareTrackProgress(incomingTrack, currentTrack),
+		currentTrack.DeviceID, currentTrack.ClientUpdatedAt,
+		incomingTrack.DeviceID, incomingTrack.ClientUpdatedAt,
+	) {
+		if err := repo.UpsertTrackProgress(incomingTrack); err != nil {
+			return nil, err
+		}
+		trackProgress = incomingTrack
+	}
+
+	// A completed track resumes at the start of the next one
+	resumeTrackID := update.trackID
+	resumePosition := position
+	finished := false
+	if completed {
+		if trackIndex+1 < len(audiobook.Tracks) {
+			resumeTrackID = audiobook.Tracks[trackIndex+1].ID
+			resumePosition = 0
+		} else {
+			finished = true
+		}
+	}
+
+	incomingResume := &entity.AudiobookProgress{
+		UserID:          userID,
+		AudiobookID:     update.audiobookID,
+		TrackID:         resumeTrackID,
+		PositionSeconds: resumePosition,
+		Finished:        finished,
+		LastPlayedAt:    update.clientUpdatedAt,
+		DeviceID:        update.deviceID,
+	}
+
+	currentResume, err := repo.GetAudiobookProgress(userID, update.audiobookID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if currentResume == nil || s.incomingWins(
+		compareResumePoints(audiobook, incomingResume, currentResume),
+		currentResume.DeviceID, currentResume.LastPlayedAt,
+		incomingResume.DeviceID, incomingResume.LastPlayedAt,
+	) {
+		if err := repo.UpsertAudiobookProgress(incomingResume); err != nil {
+			return nil, err
+		}
+	}
+
+	return trackProgress, nil
+}
+
+// incomingWins resolves a conflict between the stored update and an incoming one.
+// Updates from different devices recorded within the conflict window keep whichever listened
+// further (progress > 0 means the incoming update did); otherwise the newest update wins.
+// Remaining ties fall back to the device ID so every replica resolves the same way.
+func (s *ProgressService) incomingWins(progress int, currentDevice string, currentAt time.Time, incomingDevice string, incomingAt time.Time) bool {
+	gap := incomingAt.Sub(currentAt)
+	if gap < 0 {
+		gap = -gap
+	}
+
+	if currentDevice != incomingDevice && gap <= s.conflictWindow && progress != 0 {
+		return progress > 0
+	}
+	if !incomingAt.Equal(currentAt) {
+		return incomingAt.After(currentAt)
+	}
+	if progress != 0 {
+		return progress > 0
+	}
+	return incomingDevice >= currentDevice
+}
+
+// compareTrackProgress compares how far two records of the same track have listened
+func compareTrackProgress(a, b *entity.TrackProgress) int {
+	if a.Completed != b.Completed {
+		if a.Completed {
+			return 1
+		}
+		return -1
+	}
+	return compareInts(a.PositionSeconds, b.PositionSeconds)
+}
+
+// compareResumePoints compares how far two resume points of an audiobook have listened, in track order
+func compareResumePoints(audiobook *CatalogAudiobook, a, b *entity.AudiobookProgress) int {
+	if a.Finished != b.Finished {
+		if a.Finished {
+			return 1
+		}
+		return -1
+	}
+	if c := compareInts(catalogTrackIndex(audiobook, a.TrackID), catalogTrackIndex(audiobook, b.TrackID)); c != 0 {
+		return c
+	}
+	return compareInts(a.PositionSeconds, b.PositionSeconds)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a > b:
+		return 1
+	case a < b:
+		return -1
+	}
+	return 0
+}
+
+// catalogTrackIndex returns the position of a track in the audiobook, or -1 if it is not part of it
+func catalogTrackIndex(audiobook *CatalogAudiobook, trackID uint) int {
+	for i, track := range audiobook.Tracks {
+		if track.ID == trackID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *ProgressService) convertToResumePointResponse(progress *entity.AudiobookProgress) dto.ResumePointResponse {
+	return dto.ResumePointResponse{
+		AudiobookID:     progress.AudiobookID,
+		TrackID:         progress.TrackID,
+		PositionSeconds: progress.PositionSeconds,
+		Finished:        progress.Finished,
+		LastPlayedAt:    progress.LastPlayedAt,
+		DeviceID:        progress.DeviceID,
+	}
+}
+
+func (s *ProgressService) convertToTrackProgressResponse(progress *entity.TrackProgress) dto.TrackProgressResponse {
+	return dto.TrackProgressResponse{
+		TrackID:         progress.TrackID,
+		AudiobookID:     progress.AudiobookID,
+		PositionSeconds: progress.PositionSeconds,
+		DurationSeconds: progress.DurationSeconds,
+		Completed:       progress.Completed,
+		DeviceID:        progress.DeviceID,
+		ClientUpdatedAt: progress.ClientUpdatedAt,
+		UpdatedAt:       progress.UpdatedAt,
+	}
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"errors"
+	"playback-service/data_layer/entity"
+	"playback-service/data_layer/repository"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const testConflictWindow = 5 * time.Minute
+
+func TestIncomingWins(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		progress       int // > 0 when the incoming update listened further
+		currentDevice  string
+		currentAt      time.Time
+		incomingDevice string
+		incomingAt     time.Time
+		want           bool
+	}{
+		{name: "same device, newer but behind", progress: -1, currentDevice: "phone", currentAt: base, incomingDevice: "phone", incomingAt: base.Add(time.Second), want: true},
+		{name: "same device, older but further", progress: 1, currentDevice: "phone", currentAt: base, incomingDevice: "phone", incomingAt: base.Add(-time.Second), want: false},
+		{name: "other device in the window, older but further", progress: 1, currentDevice: "phone", currentAt: base, incomingDevice: "web", incomingAt: base.Add(-time.Minute), want: true},
+		{name: "other device in the window, newer but behind", progress: -1, currentDevice: "phone", currentAt: base, incomingDevice: "web", incomingAt: base.Add(time.Minute), want: false},
+		{name: "other device at the edge of the window", progress: 1, currentDevice: "phone", currentAt: base, incomingDevice: "web", incomingAt: base.Add(-testConflictWindow), want: true},
+		{name: "other device past the window, older but further", progress: 1, currentDevice: "phone", currentAt: base, incomingDevice: "web", incomingAt: base.Add(-testConflictWindow - time.Second), want: false},
+		{name: "other device past the window, newer but behind", progress: -1, currentDevice: "phone", currentAt: base, incomingDevice: "web", incomingAt: base.Add(testConflictWindow + time.Second), want: true},
+		{name: "other device in the window, same place, newer", progress: 0, currentDevice: "phone", currentAt: base, incomingDevice: "web", incomingAt: base.Add(time.Second), want: true},
+		{name: "other device in the window, same place, older", progress: 0, currentDevice: "phone", currentAt: base, incomingDevice: "web", incomingAt: base.Add(-time.Second), want: false},
+		{name: "same time and place, higher device ID", progress: 0, currentDevice: "phone", currentAt: base, incomingDevice: "web", incomingAt: base, want: true},
+		{name: "same time and place, lower device ID", progress: 0, currentDevice: "web", currentAt: base, incomingDevice: "phone", incomingAt: base, want: false},
+		{name: "same device, same time, further", progress: 1, currentDevice: "phone", currentAt: base, incomingDevice: "phone", incomingAt: base, want: true},
+		{name: "same device, same time, behind", progress: -1, currentDevice: "phone", currentAt: base, incomingDevice: "phone", incomingAt: base, want: false},
+		{name: "same device, same time and place", progress: 0, currentDevice: "phone", currentAt: base, incomingDevice: "phone", incomingAt: base, want: true},
+	}
+
+	s := &ProgressService{conflictWindow: testConflictWindow}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.incomingWins(tt.progress, tt.currentDevice, tt.currentAt, tt.incomingDevice, tt.incomingAt)
+			if got != tt.want {
+				t.Fatalf("incomingWins = %v, want %v", got, tt.want)
+			}
+
+			// Updates of two devices must settle the same way whichever arrives first
+			if tt.currentDevice != tt.incomingDevice {
+				swapped := s.incomingWins(-tt.progress, tt.incomingDevice, tt.incomingAt, tt.currentDevice, tt.currentAt)
+				if swapped == got {
+					t.Errorf("incomingWins with the updates swapped = %v, want %v", swapped, !got)
+				}
+			}
+		})
+	}
+}
+
+func TestCompareTrackProgress(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b entity.TrackProgress
+		want int
+	}{
+		{name: "further position", a: entity.TrackProgress{PositionSeconds: 120}, b: entity.TrackProgress{PositionSeconds: 60}, want: 1},
+		{name: "earlier position", a: entity.TrackProgress{PositionSeconds: 60}, b: entity.TrackProgress{PositionSeconds: 120}, want: -1},
+		{name: "same position", a: entity.TrackProgress{PositionSeconds: 60}, b: entity.TrackProgress{PositionSeconds: 60}, want: 0},
+		{name: "completed beats any position", a: entity.TrackProgress{Completed: true}, b: entity.TrackProgress{PositionSeconds: 600}, want: 1},
+		{name: "not completed loses to completed", a: entity.TrackProgress{PositionSeconds: 600}, b: entity.TrackProgress{Completed: true}, want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareTrackProgress(&tt.a, &tt.b); got != tt.want {
+				t.Errorf("compareTrackProgress = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareResumePoints(t *testing.T) {
+	audiobook := &CatalogAudiobook{ID: 1, Tracks: []CatalogTrack{{ID: 30}, {ID: 10}, {ID: 20}}}
+
+	tests := []struct {
+		name string
+		a, b entity.AudiobookProgress
+		want int
+	}{
+		{name: "later track in play order", a: entity.AudiobookProgress{TrackID: 10}, b: entity.AudiobookProgress{TrackID: 30, PositionSeconds: 600}, want: 1},
+		{name: "earlier track in play order", a: entity.AudiobookProgress{TrackID: 10, PositionSeconds: 600}, b: entity.AudiobookProgress{TrackID: 20}, want: -1},
+		{name: "same track, further position", a: entity.AudiobookProgress{TrackID: 10, PositionSeconds: 61}, b: entity.AudiobookProgress{TrackID: 10, PositionSeconds: 60}, want: 1},
+		{name: "same track and position", a: entity.AudiobookProgress{TrackID: 10, PositionSeconds: 60}, b: entity.AudiobookProgress{TrackID: 10, PositionSeconds: 60}, want: 0},
+		{name: "finished beats any track", a: entity.AudiobookProgress{TrackID: 30, Finished: true}, b: entity.AudiobookProgress{TrackID: 20, PositionSeconds: 600}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareResumePoints(audiobook, &tt.a, &tt.b); got != tt.want {
+				t.Errorf("compareResumePoints = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// stubProgressRepository keeps progress in maps; other methods are not used by these tests
+type stubProgressRepository struct {
+	repository.ProgressRepositoryInterface
+	tracks     map[uint]entity.TrackProgress
+	audiobooks map[uint]entity.AudiobookProgress
+}
+
+func newStubProgressRepository() *stubProgressRepository {
+	return &stubProgressRepository{tracks: map[uint]entity.TrackProgress{}, audiobooks: map[uint]entity.AudiobookProgress{}}
+}
+
+func (r *stubProgressRepository) GetTrackProgress(userID string, trackID uint) (*entity.TrackProgress, error) {
+	progress, ok := r.tracks[trackID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &progress, nil
+}
+
+func (r *stubProgressRepository) UpsertTrackProgress(progress *entity.TrackProgress) error {
+	r.tracks[progress.TrackID] = *progress
+	return nil
+}
+
+func (r *stubProgressRepository) GetAudiobookProgress(userID string, audiobookID uint) (*entity.AudiobookProgress, error) {
+	progress, ok := r.audiobooks[audiobookID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &progress, nil
+}
+
+func (r *stubProgressRepository) UpsertAudiobookProgress(progress *entity.AudiobookProgress) error {
+	r.audiobooks[progress.AudiobookID] = *progress
+	return nil
+}
+
+func TestApplyUpdateConvergesInAnyOrder(t *testing.T) {
+	audiobook := &CatalogAudiobook{ID: 1, Tracks: []CatalogTrack{{ID: 10}, {ID: 20}}}
+	base := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name         string
+		updates      []progressUpdate
+		wantTrack    uint
+		wantPosition int
+		wantDevice   string
+	}{
+		{
+			name: "furthest listened wins within the window",
+			updates: []progressUpdate{
+				{audiobookID: 1, trackID: 20, positionSeconds: 300, deviceID: "phone", clientUpdatedAt: base},
+				{audiobookID: 1, trackID: 20, positionSeconds: 100, deviceID: "web", clientUpdatedAt: base.Add(time.Minute)},
+			},
+			wantTrack: 20, wantPosition: 300, wantDevice: "phone",
+		},
+		{
+			name: "newest wins past the window",
+			updates: []progressUpdate{
+				{audiobookID: 1, trackID: 20, positionSeconds: 300, deviceID: "phone", clientUpdatedAt: base},
+				{audiobookID: 1, trackID: 20, positionSeconds: 100, deviceID: "web", clientUpdatedAt: base.Add(testConflictWindow + time.Minute)},
+			},
+			wantTrack: 20, wantPosition: 100, wantDevice: "web",
+		},
+		{
+			name: "a completed track resumes at the next one",
+			updates: []progressUpdate{
+				{audiobookID: 1, trackID: 10, positionSeconds: 600, durationSeconds: 600, deviceID: "phone", clientUpdatedAt: base},
+				{audiobookID: 1, trackID: 10, positionSeconds: 200, durationSeconds: 600, deviceID: "web", clientUpdatedAt: base.Add(time.Minute)},
+			},
+			wantTrack: 20, wantPosition: 0, wantDevice: "phone",
+		},
+		{
+			name: "identical reports tie-break on the device ID",
+			updates: []progressUpdate{
+				{audiobookID: 1, trackID: 20, positionSeconds: 100, deviceID: "phone", clientUpdatedAt: base},
+				{audiobookID: 1, trackID: 20, positionSeconds: 100, deviceID: "web", clientUpdatedAt: base},
+			},
+			wantTrack: 20, wantPosition: 100, wantDevice: "web",
+		},
+	}
+
+	s := &ProgressService{conflictWindow: testConflictWindow}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, reversed := range []bool{false, true} {
+				repo := newStubProgressRepository()
+				for i := range tt.updates {
+					update := tt.updates[i]
+					if reversed {
+						update = tt.updates[len(tt.updates)-1-i]
+					}
+					if _, err := s.applyUpdate(repo, "user-1", audiobook, update); err != nil {
+						t.Fatalf("applyUpdate: %v", err)
+					}
+				}
+
+				resume := repo.audiobooks[1]
+				if resume.TrackID != tt.wantTrack || resume.PositionSeconds != tt.wantPosition || resume.DeviceID != tt.wantDevice {
+					t.Errorf("reversed %v: resume point = track %d at %d from %q, want track %d at %d from %q",
+						reversed, resume.TrackID, resume.PositionSeconds, resume.DeviceID, tt.wantTrack, tt.wantPosition, tt.wantDevice)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyUpdateClampsClientClockAhead(t *testing.T) {
+	audiobook := &CatalogAudiobook{ID: 1, Tracks: []CatalogTrack{{ID: 10}}}
+	s := &ProgressService{conflictWindow: testConflictWindow}
+	repo := newStubProgressRepository()
+
+	update := progressUpdate{audiobookID: 1, trackID: 10, positionSeconds: 60, deviceID: "phone", clientUpdatedAt: time.Now().Add(24 * time.Hour)}
+	progress, err := s.applyUpdate(repo, "user-1", audiobook, update)
+	if err != nil {
+		t.Fatalf("applyUpdate: %v", err)
+	}
+	if progress.ClientUpdatedAt.After(time.Now()) {
+		t.Errorf("client time %v is still in the future", progress.ClientUpdatedAt)
+	}
+
+	if _, err := s.applyUpdate(repo, "user-1", audiobook, progressUpdate{audiobookID: 1, trackID: 99}); !errors.Is(err, ErrTrackNotFound) {
+		t.Errorf("applyUpdate for a track outside the audiobook = %v, want ErrTrackNotFound", err)
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// UserManagementService handles external API calls to auth service
+type UserManagementService struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// ExternalUserInfo represents the user info returned by the validate-token endpoint
+type ExternalUserInfo struct {
+	UserID   string `json:"userID"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	RoleID   string `json:"roleID"`
+	Status   string `json:"status"`
+	IsActive bool   `json:"isActive"`
+}
+
+// TokenValidationResponse represents the response from validate-token endpoint
+type TokenValidationResponse struct {
+	IsValid  bool              `json:"isValid"`
+	UserInfo *ExternalUserInfo `json:"userInfo,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// NewUserManagementService creates a new user management service
+func NewUserManagementService(baseURL, apiKey string) *UserManagementService {
+	return &UserManagementService{
+		baseURL: baseURL,
+		apiKey:  apiKey,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// ValidateToken validates a user JWT token and returns the user it belongs to
+func (s *UserManagementService) ValidateToken(ctx context.Context, token string) (*TokenValidationResponse, error) {
+	url := fmt.Sprintf("%s/api/external/auth/validate-token", s.baseURL)
+
+	body, err := json.Marshal(map[string]string{"token": token})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", s.apiKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("validation failed with status: %d", resp.StatusCode)
+	}
+
+	var validationResponse TokenValidationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&validationResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &validationResponse, nil
+}
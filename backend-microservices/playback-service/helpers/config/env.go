@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// GetUserManagementBaseURL returns the base URL for user management service
+func GetUserManagementBaseURL() string {
+	host := os.Getenv("USER_MANAGEMENT_HOST")
+	if host == "" {
+		host = "localhost" // default
+	}
+
+	port := os.Getenv("USER_MANAGEMENT_PORT")
+	if port == "" {
+		port = "3120" // default
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, port)
+}
+
+// GetUserManagementAPIKey returns the API key sent to the user management external API
+func GetUserManagementAPIKey() string {
+	apiKey := os.Getenv("USER_MANAGEMENT_API_KEY")
+	if apiKey == "" {
+		apiKey = "alat-service-api-key" // default test key accepted by user-management
+	}
+	return apiKey
+}
+
+// GetCatalogBaseURL returns the base URL for catalog service
+func GetCatalogBaseURL() string {
+	host := os.Getenv("CATALOG_HOST")
+	if host == "" {
+		host = "localhost" // default
+	}
+
+	port := os.Getenv("CATALOG_PORT")
+	if port == "" {
+		port = "3163" // default
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, port)
+}
+
+// GetSyncConflictWindow returns how close in time two devices' updates must be for the furthest one to win
+func GetSyncConflictWindow() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("SYNC_CONFLICT_WINDOW_SECONDS"))
+	if err != nil || seconds < 0 {
+		seconds = 300 // default 5 minutes
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"log"
+	"os"
+	"playback-service/data_layer/migration"
+	"playback-service/data_layer/repository"
+	"playback-service/domain_layer/service"
+	"playback-service/helpers/config"
+	"playback-service/presentation_layer/controller"
+	"playback-service/presentation_layer/route"
+
+	"github.com/gin-gonic/gin"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Load environment variables from .env file
+	if err := godotenv.Load(); err != nil {
+		log.Printf("Warning: Could not load .env file: %v", err)
+	}
+
+	// Initialize database
+	db, err := config.InitDatabase()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	// Run migrations
+	if err := migration.AutoMigrate(db); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	// Initialize repositories
+	progressRepo := repository.NewProgressRepository(db)
+
+	// Initialize user management service for token validation
+	userManagementBaseURL := config.GetUserManagementBaseURL()
+	userManagementService := service.NewUserManagementService(userManagementBaseURL, config.GetUserManagementAPIKey())
+	log.Printf("User Management Service configured at: %s", userManagementBaseURL)
+
+	// Initialize catalog client for track and audiobook lookups
+	catalogBaseURL := config.GetCatalogBaseURL()
+	catalogService := service.NewCatalogService(catalogBaseURL)
+	log.Printf("Catalog Service configured at: %s", catalogBaseURL)
+
+	// Initialize services
+	progressService := service.NewProgressService(progressRepo, catalogService, config.GetSyncConflictWindow())
+
+	// Initialize controllers
+	progressController := controller.NewProgressController(progressService)
+
+	// Setup Gin router
+	if os.Getenv("GIN_MODE") == "release" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	router := gin.Default()
+
+	// Add CORS middleware
+	router.Use(func(c *gin.Context) {
+		c.Header("Access-Control-Allow-Origin", "*")
+		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(204)
+			return
+		}
+
+		c.Next()
+	})
+
+	// Setup routes with user management service for middleware
+	route.SetupRoutes(router, progressController, userManagementService)
+
+	// Get port from environment or use default
+	port := os.Getenv("SERVER_PORT")
+	if port == "" {
+		port = "3162"
+	}
+
+	log.Printf("Server starting on port %s...", port)
+	if err := router.Run(":" + port); err != nil {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
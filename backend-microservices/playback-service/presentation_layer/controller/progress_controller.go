@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"playback-service/data_layer/dto"
+	"playback-service/domain_layer/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProgressController struct {
+	progressService *service.ProgressService
+}
+
+func NewProgressController(progressService *service.ProgressService) *ProgressController {
+	return &ProgressController{
+		progressService: progressService,
+	}
+}
+
+// SaveProgress saves the listening position of the current user on a track
+func (pc *ProgressController) SaveProgress(c *gin.Context) {
+	var req dto.SaveProgressRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	progress, err := pc.progressService.SaveProgress(c.Request.Context(), c.GetString("user_id"), req)
+	if err != nil {
+		if errors.Is(err, service.ErrAudiobookNotFound) || errors.Is(err, service.ErrTrackNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// SyncProgress merges a batch of offline progress updates from a device of the current user
+func (pc *ProgressController) SyncProgress(c *gin.Context) {
+	var req dto.SyncProgressRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	merged, err := pc.progressService.SyncProgress(c.Request.Context(), c.GetString("user_id"), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, merged)
+}
+
+// GetTrackProgress retrieves the listening position of the current user on a track
+func (pc *ProgressController) GetTrackProgress(c *gin.Context) {
+	trackID, err := strconv.ParseUint(c.Param("track_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid track ID"})
+		return
+	}
+
+	progress, err := pc.progressService.GetTrackProgress(c.GetString("user_id"), uint(trackID))
+	if err != nil {
+		if err.Error() == "progress not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// GetResumePoint retrieves where the current user should resume an audiobook
+func (pc *ProgressController) GetResumePoint(c *gin.Context) {
+	audiobookID, err := strconv.ParseUint(c.Param("audiobook_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	resumePoint, err := pc.progressService.GetResumePoint(c.GetString("user_id"), uint(audiobookID))
+	if err != nil {
+		if err.Error() == "progress not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resumePoint)
+}
+
+// ResetAudiobookProgress deletes the progress of the current user on an audiobook
+func (pc *ProgressController) ResetAudiobookProgress(c *gin.Context) {
+	audiobookID, err := strconv.ParseUint(c.Param("audiobook_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audiobook ID"})
+		return
+	}
+
+	if err := pc.progressService.ResetAudiobookProgress(c.GetString("user_id"), uint(audiobookID)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Progress reset successfully"})
+}
+
+// GetContinueListening retrieves the "continue listening" shelf of the current user
+func (pc *ProgressController) GetContinueListening(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	shelf, err := pc.progressService.GetContinueListening(c.Request.Context(), c.GetString("user_id"), dto.PaginationRequest{
+		Page:  page,
+		Limit: limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, shelf)
+}
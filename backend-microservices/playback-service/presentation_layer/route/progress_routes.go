@@ -0,0 +1,25 @@
+package route
+
+import (
+	"playback-service/domain_layer/middleware"
+	"playback-service/domain_layer/service"
+	"playback-service/presentation_layer/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProgressRoutes sets up all listening progress routes
+func ProgressRoutes(router *gin.RouterGroup, progressController *controller.ProgressController, userManagementService *service.UserManagementService) {
+	progress := router.Group("/progress")
+
+	// All progress routes are scoped to the authenticated user
+	progress.Use(middleware.RequireAuthWithAPIValidationMiddleware(userManagementService))
+	{
+		progress.PUT("", progressController.SaveProgress)
+		progress.POST("/sync", progressController.SyncProgress)
+		progress.GET("/continue-listening", progressController.GetContinueListening)
+		progress.GET("/tracks/:track_id", progressController.GetTrackProgress)
+		progress.GET("/audiobooks/:audiobook_id", progressController.GetResumePoint)
+		progress.DELETE("/audiobooks/:audiobook_id", progressController.ResetAudiobookProgress)
+	}
+}
@@ -0,0 +1,29 @@
+package route
+
+import (
+	"playback-service/domain_layer/service"
+	"playback-service/presentation_layer/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupRoutes configures all application routes
+func SetupRoutes(
+	router *gin.Engine,
+	progressController *controller.ProgressController,
+	userManagementService *service.UserManagementService,
+) {
+	// API versioning
+	api := router.Group("/api/v1")
+
+	// Health check endpoint
+	api.GET("/health", func(c *gin.Context) {
+		c.JSON(200, gin.H{
+			"status":  "ok",
+			"service": "playback-service",
+		})
+	})
+
+	// Setup route groups
+	ProgressRoutes(api, progressController, userManagementService)
+}